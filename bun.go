@@ -33,6 +33,9 @@ type (
 	BeforeScanRowHook = schema.BeforeScanRowHook
 	// AfterScanRowHook runs after scanning an individual row.
 	AfterScanRowHook = schema.AfterScanRowHook
+
+	// Enum is implemented by Go types that map to a database enumerated type.
+	Enum = schema.Enum
 )
 
 const (
@@ -0,0 +1,213 @@
+// Package gen generates typed column references for bun models, see bun.Column.
+// It is used by cmd/bungen, which builds a program that imports the models
+// and calls Generate.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// BuildTag excludes the generated files when cmd/bungen builds the package,
+// so stale columns don't break the generator.
+const BuildTag = "bungen"
+
+const bunPath = "github.com/uptrace/bun"
+
+// Generate writes the Go file of the package pkgName, whose import path is pkgPath,
+// with the typed columns of the models. For each model, e.g. User, the file declares
+// the variable UserColumns with a bun.Column field per column qualified with the table alias.
+func Generate(w io.Writer, pkgPath, pkgName string, models ...any) (err error) {
+	defer func() {
+		// Invalid models panic when their tables are created.
+		if v := recover(); v != nil {
+			err = fmt.Errorf("bungen: %v", v)
+		}
+	}()
+
+	tables := schema.NewNopQueryGen().Dialect().Tables()
+	g := &generator{
+		pkgPath: pkgPath,
+		imports: map[string]string{bunPath: "bun"},
+	}
+
+	var body bytes.Buffer
+	for _, model := range models {
+		typ := reflect.TypeOf(model)
+		if typ == nil {
+			return fmt.Errorf("bungen: got nil model")
+		}
+		g.writeTable(&body, tables.Get(typ))
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by bungen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "//go:build !%s\n\n", BuildTag)
+	fmt.Fprintf(&b, "package %s\n\n", pkgName)
+	g.writeImports(&b)
+	b.Write(body.Bytes())
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("bungen: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+type generator struct {
+	pkgPath string
+	imports map[string]string // import path -> package name
+}
+
+func (g *generator) writeTable(b *bytes.Buffer, table *schema.Table) {
+	type column struct {
+		goName string
+		typ    string
+		name   string
+	}
+
+	var columns []column
+	seen := make(map[string]bool)
+	for _, field := range table.Fields {
+		goName := field.GoName
+		for i := 2; seen[goName]; i++ {
+			goName = field.GoName + strconv.Itoa(i)
+		}
+		seen[goName] = true
+
+		typ, ok := g.typeString(field.StructField.Type)
+		if !ok {
+			typ = "any"
+		}
+		columns = append(columns, column{goName: goName, typ: typ, name: field.Name})
+	}
+
+	fmt.Fprintf(b, "// %sColumns holds the typed columns of the %s model with the alias %q.\n",
+		table.TypeName, table.TypeName, table.Alias)
+	fmt.Fprintf(b, "var %sColumns = struct {\n", table.TypeName)
+	for _, col := range columns {
+		fmt.Fprintf(b, "\t%s bun.Column[%s]\n", col.goName, col.typ)
+	}
+	b.WriteString("}{\n")
+	for _, col := range columns {
+		fmt.Fprintf(b, "\t%s: bun.NewColumn[%s](%q, %q),\n", col.goName, col.typ, table.Alias, col.name)
+	}
+	b.WriteString("}\n\n")
+}
+
+// typeString returns the Go source of the type, adding the imports it needs.
+// It returns false for types which can't be referenced from the generated package.
+func (g *generator) typeString(typ reflect.Type) (string, bool) {
+	if typ.Name() != "" {
+		if strings.IndexByte(typ.Name(), '[') != -1 {
+			return "", false // instantiated generic type
+		}
+		if typ.PkgPath() == "" || typ.PkgPath() == g.pkgPath {
+			return typ.Name(), true
+		}
+		if !token.IsExported(typ.Name()) {
+			return "", false
+		}
+		return g.importName(typ.PkgPath()) + "." + typ.Name(), true
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		elem, ok := g.typeString(typ.Elem())
+		return "*" + elem, ok
+	case reflect.Slice:
+		elem, ok := g.typeString(typ.Elem())
+		return "[]" + elem, ok
+	case reflect.Array:
+		elem, ok := g.typeString(typ.Elem())
+		return "[" + strconv.Itoa(typ.Len()) + "]" + elem, ok
+	case reflect.Map:
+		key, ok := g.typeString(typ.Key())
+		if !ok {
+			return "", false
+		}
+		elem, ok := g.typeString(typ.Elem())
+		return "map[" + key + "]" + elem, ok
+	case reflect.Interface:
+		if typ.NumMethod() == 0 {
+			return "any", true
+		}
+	}
+	return "", false
+}
+
+func (g *generator) importName(pkgPath string) string {
+	if name, ok := g.imports[pkgPath]; ok {
+		return name
+	}
+
+	base := path.Base(pkgPath)
+	if v := strings.TrimPrefix(base, "v"); v != base && v != "" && strings.Trim(v, "0123456789") == "" {
+		base = path.Base(path.Dir(pkgPath)) // e.g. github.com/google/uuid/v2
+	}
+	base = strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, base)
+
+	name := base
+	for i := 2; g.hasImportName(name); i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.imports[pkgPath] = name
+	return name
+}
+
+func (g *generator) hasImportName(name string) bool {
+	for _, other := range g.imports {
+		if other == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) writeImports(b *bytes.Buffer) {
+	paths := make([]string, 0, len(g.imports))
+	for pkgPath := range g.imports {
+		paths = append(paths, pkgPath)
+	}
+	sort.Strings(paths)
+
+	// The standard library goes first, like goimports does.
+	sort.SliceStable(paths, func(i, j int) bool {
+		return isStdPackage(paths[i]) && !isStdPackage(paths[j])
+	})
+
+	b.WriteString("import (\n")
+	for i, pkgPath := range paths {
+		if i > 0 && isStdPackage(paths[i-1]) != isStdPackage(pkgPath) {
+			b.WriteString("\n")
+		}
+		name := g.imports[pkgPath]
+		if name == path.Base(pkgPath) {
+			fmt.Fprintf(b, "\t%q\n", pkgPath)
+		} else {
+			fmt.Fprintf(b, "\t%s %q\n", name, pkgPath)
+		}
+	}
+	b.WriteString(")\n\n")
+}
+
+func isStdPackage(pkgPath string) bool {
+	first, _, _ := strings.Cut(pkgPath, "/")
+	return !strings.Contains(first, ".")
+}
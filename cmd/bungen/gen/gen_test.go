@@ -0,0 +1,82 @@
+package gen
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uptrace/bun"
+)
+
+type Status string
+
+type Account struct {
+	bun.BaseModel `bun:"table:accounts,alias:a"`
+
+	ID        int64 `bun:",pk,autoincrement"`
+	Email     string
+	Nickname  *string
+	Status    Status
+	Tags      []string
+	Settings  map[string]any
+	DeletedAt sql.NullTime
+	CreatedAt time.Time
+	Owner     *Account `bun:"rel:belongs-to"`
+	OwnerID   int64
+	Callback  func() `bun:"-"`
+}
+
+func TestGenerate(t *testing.T) {
+	var b bytes.Buffer
+	err := Generate(&b, "github.com/uptrace/bun/cmd/bungen/gen", "gen", (*Account)(nil))
+	require.NoError(t, err)
+	require.Equal(t, `// Code generated by bungen. DO NOT EDIT.
+
+//go:build !bungen
+
+package gen
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AccountColumns holds the typed columns of the Account model with the alias "a".
+var AccountColumns = struct {
+	ID        bun.Column[int64]
+	Email     bun.Column[string]
+	Nickname  bun.Column[*string]
+	Status    bun.Column[Status]
+	Tags      bun.Column[[]string]
+	Settings  bun.Column[map[string]any]
+	DeletedAt bun.Column[sql.NullTime]
+	CreatedAt bun.Column[time.Time]
+	OwnerID   bun.Column[int64]
+}{
+	ID:        bun.NewColumn[int64]("a", "id"),
+	Email:     bun.NewColumn[string]("a", "email"),
+	Nickname:  bun.NewColumn[*string]("a", "nickname"),
+	Status:    bun.NewColumn[Status]("a", "status"),
+	Tags:      bun.NewColumn[[]string]("a", "tags"),
+	Settings:  bun.NewColumn[map[string]any]("a", "settings"),
+	DeletedAt: bun.NewColumn[sql.NullTime]("a", "deleted_at"),
+	CreatedAt: bun.NewColumn[time.Time]("a", "created_at"),
+	OwnerID:   bun.NewColumn[int64]("a", "owner_id"),
+}
+`, b.String())
+}
+
+func TestGenerateInvalidModel(t *testing.T) {
+	type Invalid struct {
+		A int `bun:",version"`
+		B int `bun:",version"`
+	}
+
+	err := Generate(new(bytes.Buffer), "example.com/models", "models", (*Invalid)(nil))
+	require.Error(t, err)
+}
@@ -0,0 +1,141 @@
+// Command bungen generates typed column references for bun models.
+//
+// Run it in the directory of the package which declares the models, usually with go:generate:
+//
+//	//go:generate go run github.com/uptrace/bun/cmd/bungen -out bun_columns.go User Story
+//
+// For each model, e.g. User, the generated file declares the variable UserColumns
+// with a bun.Column per column, which can be used with WherePred, ColumnRef and OrderRef:
+//
+//	db.NewSelect().Model(&users).WherePred(UserColumns.Email.Eq("hello@example.com"))
+//
+// bungen reads the bun metadata of the models, so it builds and runs a temporary program
+// that imports the package. The generated file is excluded from that build.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/uptrace/bun/cmd/bungen/gen"
+)
+
+var (
+	dirFlag = flag.String("dir", ".", "directory of the package with the models")
+	outFlag = flag.String("out", "bun_columns.go", "name of the generated file in the package directory")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("bungen: ")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: bungen [flags] Model...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*dirFlag, *outFlag, flag.Args()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(dir, out string, models []string) error {
+	pkgPath, pkgName, err := listPackage(dir)
+	if err != nil {
+		return err
+	}
+	if pkgName == "main" {
+		return fmt.Errorf("package %s is a command and can't be imported", pkgPath)
+	}
+
+	src, err := generate(dir, pkgPath, pkgName, models)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, out), src, 0o644)
+}
+
+func listPackage(dir string) (pkgPath, pkgName string, err error) {
+	cmd := exec.Command("go", "list", "-tags", gen.BuildTag, "-f", "{{.ImportPath}} {{.Name}}", ".")
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+
+	b, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("go list: %w", err)
+	}
+
+	pkgPath, pkgName, ok := strings.Cut(strings.TrimSpace(string(b)), " ")
+	if !ok {
+		return "", "", fmt.Errorf("go list: unexpected output %q", b)
+	}
+	return pkgPath, pkgName, nil
+}
+
+var programTemplate = template.Must(template.New("main").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/uptrace/bun/cmd/bungen/gen"
+
+	models {{ printf "%q" .PkgPath }}
+)
+
+func main() {
+	if err := gen.Generate(os.Stdout, {{ printf "%q" .PkgPath }}, {{ printf "%q" .PkgName }},
+{{- range .Models }}
+		(*models.{{ . }})(nil),
+{{- end }}
+	); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+// generate builds and runs a program in a temporary directory inside the package,
+// so it uses the same module as the models.
+func generate(dir, pkgPath, pkgName string, models []string) ([]byte, error) {
+	tmpDir, err := os.MkdirTemp(dir, "bungen_")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var program bytes.Buffer
+	if err := programTemplate.Execute(&program, map[string]any{
+		"PkgPath": pkgPath,
+		"PkgName": pkgName,
+		"Models":  models,
+	}); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), program.Bytes(), 0o644); err != nil {
+		return nil, err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command("go", "run", "-tags", gen.BuildTag, "./"+filepath.Base(tmpDir))
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go run: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,171 @@
+package bun
+
+import (
+	"github.com/uptrace/bun/schema"
+)
+
+// ColumnRef is a reference to a model column, which is implemented by Column.
+type ColumnRef interface {
+	schema.QueryAppender
+	// ColumnName returns the unqualified name of the column.
+	ColumnName() string
+}
+
+// Column is a typed reference to a model column, usually generated with cmd/bungen.
+// It renders as the column name qualified with the table alias, e.g. "user"."email",
+// and builds predicates which only accept values of the column type:
+//
+//	db.NewSelect().Model(&users).WherePred(UserColumns.Email.Eq("hello@example.com"))
+type Column[T any] struct {
+	alias string
+	name  string
+}
+
+var _ ColumnRef = Column[int]{}
+
+// NewColumn returns a reference to the column of the table with the alias.
+// An empty alias renders the column unqualified.
+func NewColumn[T any](alias, name string) Column[T] {
+	return Column[T]{alias: alias, name: name}
+}
+
+// ColumnName returns the unqualified name of the column.
+func (c Column[T]) ColumnName() string {
+	return c.name
+}
+
+// WithAlias returns the reference to the same column of the table with another alias,
+// e.g. the name of the relation which joins the table.
+func (c Column[T]) WithAlias(alias string) Column[T] {
+	c.alias = alias
+	return c
+}
+
+// AppendQuery appends the quoted column name, qualified with the table alias.
+func (c Column[T]) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	if c.alias != "" {
+		b = gen.AppendName(b, c.alias)
+		b = append(b, '.')
+	}
+	return gen.AppendName(b, c.name), nil
+}
+
+// Eq returns the predicate column = value.
+func (c Column[T]) Eq(value T) Predicate {
+	return newPredicate("? = ?", c, value)
+}
+
+// Ne returns the predicate column <> value.
+func (c Column[T]) Ne(value T) Predicate {
+	return newPredicate("? <> ?", c, value)
+}
+
+// Lt returns the predicate column < value.
+func (c Column[T]) Lt(value T) Predicate {
+	return newPredicate("? < ?", c, value)
+}
+
+// Le returns the predicate column <= value.
+func (c Column[T]) Le(value T) Predicate {
+	return newPredicate("? <= ?", c, value)
+}
+
+// Gt returns the predicate column > value.
+func (c Column[T]) Gt(value T) Predicate {
+	return newPredicate("? > ?", c, value)
+}
+
+// Ge returns the predicate column >= value.
+func (c Column[T]) Ge(value T) Predicate {
+	return newPredicate("? >= ?", c, value)
+}
+
+// In returns the predicate column IN (values). It matches no rows if values are empty.
+func (c Column[T]) In(values ...T) Predicate {
+	return newPredicate("? IN (?)", c, List(values))
+}
+
+// Between returns the predicate column BETWEEN low AND high.
+func (c Column[T]) Between(low, high T) Predicate {
+	return newPredicate("? BETWEEN ? AND ?", c, low, high)
+}
+
+// IsNull returns the predicate column IS NULL.
+func (c Column[T]) IsNull() Predicate {
+	return newPredicate("? IS NULL", c)
+}
+
+// IsNotNull returns the predicate column IS NOT NULL.
+func (c Column[T]) IsNotNull() Predicate {
+	return newPredicate("? IS NOT NULL", c)
+}
+
+// Set returns the assignment of the value to the column for UpdateQuery.SetAssignment.
+func (c Column[T]) Set(value T) Assignment {
+	return Assignment{column: c.name, value: value}
+}
+
+//------------------------------------------------------------------------------
+
+// Predicate is a condition built from typed columns, see Column.
+type Predicate struct {
+	query string
+	args  []any
+}
+
+var _ schema.QueryAppender = Predicate{}
+
+func newPredicate(query string, args ...any) Predicate {
+	return Predicate{query: query, args: args}
+}
+
+// AppendQuery appends the condition.
+func (p Predicate) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return gen.AppendQuery(b, p.query, p.args...), nil
+}
+
+// And returns the predicate which matches if all the predicates match.
+func And(preds ...Predicate) Predicate {
+	return joinPredicates(" AND ", preds)
+}
+
+// Or returns the predicate which matches if any of the predicates matches.
+func Or(preds ...Predicate) Predicate {
+	return joinPredicates(" OR ", preds)
+}
+
+// Not returns the negated predicate.
+func Not(pred Predicate) Predicate {
+	return newPredicate("NOT (?)", pred)
+}
+
+func joinPredicates(sep string, preds []Predicate) Predicate {
+	switch len(preds) {
+	case 0:
+		if sep == " OR " {
+			return newPredicate("1 = 0")
+		}
+		return newPredicate("1 = 1")
+	case 1:
+		return preds[0]
+	}
+
+	var b []byte
+	args := make([]any, len(preds))
+	for i, pred := range preds {
+		if i > 0 {
+			b = append(b, sep...)
+		}
+		b = append(b, "(?)"...)
+		args[i] = pred
+	}
+	return newPredicate(string(b), args...)
+}
+
+//------------------------------------------------------------------------------
+
+// Assignment is the assignment of a value to a typed column, see Column.Set.
+type Assignment struct {
+	column string
+	value  any
+}
@@ -19,6 +19,9 @@ const (
 	discardUnknownColumns internal.Flag = 1 << iota
 )
 
+// defaultRelationBatchSize is the default max number of base models per has-many or m2m query.
+const defaultRelationBatchSize = 1000
+
 // DBStats tracks aggregate query counters collected by Bun.
 type DBStats struct {
 	Queries uint32
@@ -44,6 +47,29 @@ func WithDiscardUnknownColumns() DBOption {
 	}
 }
 
+// WithRelationBatchSize sets the max number of base models whose has-many and m2m relations
+// are selected by a single query. Larger result sets are loaded using several queries,
+// which keeps the IN lists below the parameter and packet limits of the database.
+// Zero or a negative size selects the relations of all base models at once.
+func WithRelationBatchSize(size int) DBOption {
+	return func(db *DB) {
+		db.relationBatchSize = size
+	}
+}
+
+// WithTenantFromContext enables tenant scoping of the models with the tenant field tag, e.g.
+//
+//	TenantID int64 `bun:",tenant"`
+//
+// When a query runs, fn extracts the tenant from the context. Queries of tenant-scoped models,
+// including relation joins, get the predicate on the tenant column and inserts get the tenant value.
+// A query which runs without a tenant in the context fails, unless it uses WithoutTenantScope.
+func WithTenantFromContext(fn func(ctx context.Context) (tenant any, ok bool)) DBOption {
+	return func(db *DB) {
+		db.tenantFromContext = fn
+	}
+}
+
 // ConnResolver enables routing queries to multiple databases.
 type ConnResolver interface {
 	ResolveConn(ctx context.Context, query Query) IConn
@@ -76,6 +102,10 @@ type noCopyState struct {
 	flags  internal.Flag
 	closed atomic.Bool
 
+	relationBatchSize int
+	tenantFromContext func(ctx context.Context) (any, bool)
+	scopes            scopeRegistry
+
 	stats DBStats
 }
 
@@ -87,6 +117,8 @@ func NewDB(sqldb *sql.DB, dialect schema.Dialect, opts ...DBOption) *DB {
 		noCopyState: &noCopyState{
 			DB:      sqldb,
 			dialect: dialect,
+
+			relationBatchSize: defaultRelationBatchSize,
 		},
 		gen: schema.NewQueryGen(dialect),
 	}
@@ -203,6 +235,21 @@ func (db *DB) NewDropColumn() *DropColumnQuery {
 	return NewDropColumnQuery(db)
 }
 
+// NewCreateView creates a CREATE VIEW DDL query builder.
+func (db *DB) NewCreateView() *CreateViewQuery {
+	return NewCreateViewQuery(db)
+}
+
+// NewDropView creates a DROP VIEW DDL query builder.
+func (db *DB) NewDropView() *DropViewQuery {
+	return NewDropViewQuery(db)
+}
+
+// NewRefreshMaterializedView creates a REFRESH MATERIALIZED VIEW DDL query builder.
+func (db *DB) NewRefreshMaterializedView() *RefreshMaterializedViewQuery {
+	return NewRefreshMaterializedViewQuery(db)
+}
+
 // ResetModel drops and recreates tables for the given models.
 // This is useful for testing and development but should not be used in production.
 func (db *DB) ResetModel(ctx context.Context, models ...any) error {
@@ -529,6 +576,21 @@ func (c Conn) NewDropColumn() *DropColumnQuery {
 	return NewDropColumnQuery(c.db).Conn(c)
 }
 
+// NewCreateView creates a CREATE VIEW query bound to this connection.
+func (c Conn) NewCreateView() *CreateViewQuery {
+	return NewCreateViewQuery(c.db).Conn(c)
+}
+
+// NewDropView creates a DROP VIEW query bound to this connection.
+func (c Conn) NewDropView() *DropViewQuery {
+	return NewDropViewQuery(c.db).Conn(c)
+}
+
+// NewRefreshMaterializedView creates a REFRESH MATERIALIZED VIEW query bound to this connection.
+func (c Conn) NewRefreshMaterializedView() *RefreshMaterializedViewQuery {
+	return NewRefreshMaterializedViewQuery(c.db).Conn(c)
+}
+
 // RunInTx runs the function in a transaction. If the function returns an error,
 // the transaction is rolled back. Otherwise, the transaction is committed.
 func (c Conn) RunInTx(
@@ -564,9 +626,10 @@ func (c Conn) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
 		return Tx{}, err
 	}
 	return Tx{
-		ctx: ctx,
-		db:  c.db,
-		Tx:  tx,
+		ctx:       ctx,
+		db:        c.db,
+		callbacks: newTxCallbacks(nil),
+		Tx:        tx,
 	}, nil
 }
 
@@ -599,6 +662,8 @@ type Tx struct {
 	db  *DB
 	// name is the name of a savepoint
 	name string
+	// callbacks are registered with OnCommit and OnRollback
+	callbacks *txCallbacks
 	*sql.Tx
 }
 
@@ -642,9 +707,10 @@ func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
 		return Tx{}, err
 	}
 	return Tx{
-		ctx: ctx,
-		db:  db,
-		Tx:  tx,
+		ctx:       ctx,
+		db:        db,
+		callbacks: newTxCallbacks(nil),
+		Tx:        tx,
 	}, nil
 }
 
@@ -660,16 +726,27 @@ func (tx Tx) commitTX() error {
 	ctx, event := tx.db.beforeQuery(tx.ctx, nil, "COMMIT", nil, "COMMIT", nil)
 	err := tx.Tx.Commit()
 	tx.db.afterQuery(ctx, event, nil, err)
-	return err
+	if err != nil {
+		if err != sql.ErrTxDone {
+			tx.callbacks.rolledBack(tx.ctx)
+		}
+		return err
+	}
+	tx.callbacks.committed(tx.ctx)
+	return nil
 }
 
 func (tx Tx) commitSP() error {
 	if tx.db.HasFeature(feature.MSSavepoint) {
+		tx.callbacks.release()
 		return nil
 	}
 	query := "RELEASE SAVEPOINT " + tx.name
-	_, err := tx.ExecContext(tx.ctx, query)
-	return err
+	if _, err := tx.ExecContext(tx.ctx, query); err != nil {
+		return err
+	}
+	tx.callbacks.release()
+	return nil
 }
 
 // Rollback rolls back the transaction or rolls back to the savepoint if this is a nested transaction.
@@ -684,6 +761,9 @@ func (tx Tx) rollbackTX() error {
 	ctx, event := tx.db.beforeQuery(tx.ctx, nil, "ROLLBACK", nil, "ROLLBACK", nil)
 	err := tx.Tx.Rollback()
 	tx.db.afterQuery(ctx, event, nil, err)
+	if err != sql.ErrTxDone {
+		tx.callbacks.rolledBack(tx.ctx)
+	}
 	return err
 }
 
@@ -693,6 +773,7 @@ func (tx Tx) rollbackSP() error {
 		query = "ROLLBACK TRANSACTION " + tx.name
 	}
 	_, err := tx.ExecContext(tx.ctx, query)
+	tx.callbacks.discard()
 	return err
 }
 
@@ -768,10 +849,11 @@ func (tx Tx) BeginTx(ctx context.Context, _ *sql.TxOptions) (Tx, error) {
 		return Tx{}, err
 	}
 	return Tx{
-		ctx:  ctx,
-		db:   tx.db,
-		Tx:   tx.Tx,
-		name: qName,
+		ctx:       ctx,
+		db:        tx.db,
+		Tx:        tx.Tx,
+		name:      qName,
+		callbacks: newTxCallbacks(tx.callbacks),
 	}, nil
 }
 
@@ -876,6 +958,21 @@ func (tx Tx) NewDropColumn() *DropColumnQuery {
 	return NewDropColumnQuery(tx.db).Conn(tx)
 }
 
+// NewCreateView creates a CREATE VIEW query bound to this transaction.
+func (tx Tx) NewCreateView() *CreateViewQuery {
+	return NewCreateViewQuery(tx.db).Conn(tx)
+}
+
+// NewDropView creates a DROP VIEW query bound to this transaction.
+func (tx Tx) NewDropView() *DropViewQuery {
+	return NewDropViewQuery(tx.db).Conn(tx)
+}
+
+// NewRefreshMaterializedView creates a REFRESH MATERIALIZED VIEW query bound to this transaction.
+func (tx Tx) NewRefreshMaterializedView() *RefreshMaterializedViewQuery {
+	return NewRefreshMaterializedViewQuery(tx.db).Conn(tx)
+}
+
 func (db *DB) makeQueryBytes() []byte {
 	return internal.MakeQueryBytes()
 }
@@ -213,7 +213,8 @@ func (f *Fixture) addRow(ctx context.Context, table *schema.Table, row row) erro
 	}
 
 	model := strct.Addr().Interface()
-	q := f.db.NewInsert().Model(model)
+	// Fixtures specify the tenants of the rows explicitly.
+	q := f.db.NewInsert().Model(model).WithoutTenantScope()
 
 	data := &BeforeInsertData{
 		Query: q,
@@ -39,6 +39,8 @@ const (
 	OffsetFetch
 	// SelectExists enables EXISTS subquery expressions.
 	SelectExists
+	// AggregateFilter enables the FILTER (WHERE ...) clause of aggregate functions (PostgreSQL, SQLite).
+	AggregateFilter
 
 	// INSERT features.
 
@@ -94,6 +96,12 @@ const (
 	AlterColumnExists
 	// CreateIndexIfNotExists enables CREATE INDEX IF NOT EXISTS syntax.
 	CreateIndexIfNotExists
+	// CommentOn enables COMMENT ON TABLE / COMMENT ON COLUMN statements (PostgreSQL).
+	CommentOn
+	// EnumType enables CREATE TYPE ... AS ENUM and ALTER TYPE ... ADD VALUE statements (PostgreSQL).
+	EnumType
+	// MaterializedView enables CREATE MATERIALIZED VIEW and REFRESH MATERIALIZED VIEW statements (PostgreSQL).
+	MaterializedView
 
 	// Column definition features.
 
@@ -103,6 +111,8 @@ const (
 	Identity
 	// GeneratedIdentity enables GENERATED ALWAYS AS IDENTITY syntax (PostgreSQL).
 	GeneratedIdentity
+	// InlineComment enables COMMENT clauses in column and table definitions (MySQL).
+	InlineComment
 
 	// Dialect-specific features.
 
@@ -143,8 +153,9 @@ var flag2str = map[Feature]string{
 	CompositeIn:        "CompositeIn",
 
 	// SELECT features.
-	OffsetFetch:  "OffsetFetch",
-	SelectExists: "SelectExists",
+	OffsetFetch:     "OffsetFetch",
+	SelectExists:    "SelectExists",
+	AggregateFilter: "AggregateFilter",
 
 	// INSERT features.
 	InsertReturning:      "InsertReturning",
@@ -175,11 +186,15 @@ var flag2str = map[Feature]string{
 	TableNotExists:         "TableNotExists",
 	AlterColumnExists:      "AlterColumnExists",
 	CreateIndexIfNotExists: "CreateIndexIfNotExists",
+	CommentOn:              "CommentOn",
+	EnumType:               "EnumType",
+	MaterializedView:       "MaterializedView",
 
 	// Column definition features.
 	AutoIncrement:     "AutoIncrement",
 	Identity:          "Identity",
 	GeneratedIdentity: "GeneratedIdentity",
+	InlineComment:     "InlineComment",
 
 	// Dialect-specific features.
 	FKDefaultOnAction: "FKDefaultOnAction",
@@ -0,0 +1,377 @@
+package mysqldialect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+	"github.com/uptrace/bun/migrate/sqlschema"
+	"github.com/uptrace/bun/schema"
+)
+
+func (d *Dialect) NewMigrator(db *bun.DB, schemaName string) sqlschema.Migrator {
+	return &migrator{db: db, schemaName: schemaName, BaseMigrator: sqlschema.NewBaseMigrator(db)}
+}
+
+type migrator struct {
+	*sqlschema.BaseMigrator
+
+	db         *bun.DB
+	schemaName string
+}
+
+var _ sqlschema.Migrator = (*migrator)(nil)
+
+func (m *migrator) AppendSQL(b []byte, operation any) (_ []byte, err error) {
+	gen := m.db.QueryGen()
+
+	// Append ALTER TABLE statement to the enclosed query bytes []byte.
+	appendAlterTable := func(query []byte, tableName string) []byte {
+		query = append(query, "ALTER TABLE "...)
+		query = m.appendFQN(gen, query, tableName)
+		return append(query, " "...)
+	}
+
+	switch change := operation.(type) {
+	case *migrate.CreateTableOp:
+		return m.AppendCreateTable(b, change.Model)
+	case *migrate.DropTableOp:
+		b = append(b, "DROP TABLE "...)
+		return m.appendFQN(gen, b, change.TableName), nil
+	case *migrate.RenameTableOp:
+		b, err = m.renameTable(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.RenameColumnOp:
+		b, err = m.renameColumn(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.AddColumnOp:
+		b, err = m.addColumn(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.DropColumnOp:
+		b, err = m.dropColumn(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.AddPrimaryKeyOp:
+		b, err = m.addPrimaryKey(gen, appendAlterTable(b, change.TableName), change.PrimaryKey)
+	case *migrate.ChangePrimaryKeyOp:
+		b, err = m.changePrimaryKey(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.DropPrimaryKeyOp:
+		b, err = m.dropPrimaryKey(gen, appendAlterTable(b, change.TableName))
+	case *migrate.AddUniqueConstraintOp:
+		b, err = m.addUnique(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.DropUniqueConstraintOp:
+		b, err = m.dropIndex(gen, appendAlterTable(b, change.TableName), change.Unique.Name)
+	case *migrate.ChangeColumnTypeOp:
+		b, err = m.changeColumnType(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.AddForeignKeyOp:
+		b, err = m.addForeignKey(gen, appendAlterTable(b, change.TableName()), change)
+	case *migrate.DropForeignKeyOp:
+		b, err = m.dropForeignKey(gen, appendAlterTable(b, change.TableName()), change.ConstraintName)
+	case *migrate.ChangeCommentOp:
+		b, err = m.changeComment(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.AddCheckConstraintOp:
+		b, err = m.addCheck(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.DropCheckConstraintOp:
+		b, err = m.dropCheck(gen, appendAlterTable(b, change.TableName), change.Check.Name)
+	case *migrate.CreateIndexOp:
+		b, err = m.createIndex(gen, b, change)
+	case *migrate.DropIndexOp:
+		if b, err = m.dropIndex(gen, b, change.Index.Name); err != nil {
+			break
+		}
+		b = append(b, " ON "...)
+		b = m.appendFQN(gen, b, change.TableName)
+	default:
+		return nil, fmt.Errorf("append sql: unknown operation %T", change)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("append sql: %w", err)
+	}
+	return b, nil
+}
+
+// appendFQN appends the table name qualified with the database name.
+// Tables in the dialect's default schema are left unqualified to refer to the current database.
+func (m *migrator) appendFQN(gen schema.QueryGen, b []byte, tableName string) []byte {
+	if m.schemaName == "" || m.schemaName == m.db.Dialect().DefaultSchema() {
+		return gen.AppendName(b, tableName)
+	}
+	return gen.AppendQuery(b, "?.?", bun.Ident(m.schemaName), bun.Ident(tableName))
+}
+
+func (m *migrator) renameTable(gen schema.QueryGen, b []byte, rename *migrate.RenameTableOp) (_ []byte, err error) {
+	b = append(b, "RENAME TO "...)
+	b = m.appendFQN(gen, b, rename.NewName)
+	return b, nil
+}
+
+// renameColumn requires MySQL 8.0 or MariaDB 10.5.2. Older versions can only rename
+// a column with CHANGE COLUMN, which needs the complete column definition.
+func (m *migrator) renameColumn(gen schema.QueryGen, b []byte, rename *migrate.RenameColumnOp) (_ []byte, err error) {
+	b = append(b, "RENAME COLUMN "...)
+	b = gen.AppendName(b, rename.OldName)
+
+	b = append(b, " TO "...)
+	b = gen.AppendName(b, rename.NewName)
+
+	return b, nil
+}
+
+func (m *migrator) addColumn(gen schema.QueryGen, b []byte, add *migrate.AddColumnOp) (_ []byte, err error) {
+	b = append(b, "ADD COLUMN "...)
+	b = gen.AppendName(b, add.ColumnName)
+	b = append(b, " "...)
+
+	return m.appendColumnDefinition(gen, b, add.Column)
+}
+
+func (m *migrator) dropColumn(gen schema.QueryGen, b []byte, drop *migrate.DropColumnOp) (_ []byte, err error) {
+	b = append(b, "DROP COLUMN "...)
+	b = gen.AppendName(b, drop.ColumnName)
+
+	return b, nil
+}
+
+func (m *migrator) addPrimaryKey(gen schema.QueryGen, b []byte, pk sqlschema.PrimaryKey) (_ []byte, err error) {
+	b = append(b, "ADD PRIMARY KEY ("...)
+	b, _ = pk.Columns.AppendQuery(gen, b)
+	b = append(b, ")"...)
+
+	return b, nil
+}
+
+func (m *migrator) dropPrimaryKey(_ schema.QueryGen, b []byte) (_ []byte, err error) {
+	return append(b, "DROP PRIMARY KEY"...), nil
+}
+
+func (m *migrator) changePrimaryKey(gen schema.QueryGen, b []byte, change *migrate.ChangePrimaryKeyOp) (_ []byte, err error) {
+	b, _ = m.dropPrimaryKey(gen, b)
+	b = append(b, ", "...)
+	b, _ = m.addPrimaryKey(gen, b, change.New)
+	return b, nil
+}
+
+func (m *migrator) addUnique(gen schema.QueryGen, b []byte, change *migrate.AddUniqueConstraintOp) (_ []byte, err error) {
+	b = append(b, "ADD CONSTRAINT "...)
+	if change.Unique.Name != "" {
+		b = gen.AppendName(b, change.Unique.Name)
+	} else {
+		// MySQL would name the index after its first column, which is ambiguous for composite keys.
+		// Follow the same naming scheme as pgdialect instead: <table>_<column>_key
+		b = gen.AppendName(b, fmt.Sprintf("%s_%s_key", change.TableName, strings.Join(change.Unique.Columns.Split(), "_")))
+	}
+	b = append(b, " UNIQUE ("...)
+	b, _ = change.Unique.Columns.AppendQuery(gen, b)
+	b = append(b, ")"...)
+
+	return b, nil
+}
+
+// dropIndex drops the index which backs a UNIQUE constraint or, when followed by ON <table>, a secondary index.
+// DROP CONSTRAINT is only supported since MySQL 8.0.19, while DROP INDEX works in all versions.
+func (m *migrator) dropIndex(gen schema.QueryGen, b []byte, name string) (_ []byte, err error) {
+	b = append(b, "DROP INDEX "...)
+	b = gen.AppendName(b, name)
+
+	return b, nil
+}
+
+// createIndex creates a secondary index. Unlike CREATE INDEX in other dialects,
+// the index type is specified after the key parts, and MySQL does not support partial indexes or INCLUDE columns.
+func (m *migrator) createIndex(gen schema.QueryGen, b []byte, create *migrate.CreateIndexOp) (_ []byte, err error) {
+	idx := create.Index
+	if idx.Where != "" {
+		return nil, fmt.Errorf("index %q: mysql does not support partial indexes", idx.Name)
+	}
+	if len(idx.Include) > 0 {
+		return nil, fmt.Errorf("index %q: mysql does not support INCLUDE columns", idx.Name)
+	}
+
+	b = append(b, "CREATE "...)
+	switch method := strings.ToUpper(idx.Method); {
+	case idx.Unique:
+		b = append(b, "UNIQUE "...)
+	case method == "FULLTEXT", method == "SPATIAL":
+		b = append(b, method...)
+		b = append(b, " "...)
+	}
+	b = append(b, "INDEX "...)
+	b = gen.AppendName(b, idx.Name)
+	b = append(b, " ON "...)
+	b = m.appendFQN(gen, b, create.TableName)
+
+	b = append(b, " ("...)
+	for i, column := range idx.Columns {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if strings.HasPrefix(column, "(") {
+			b = append(b, column...)
+		} else {
+			b = gen.AppendName(b, column)
+		}
+	}
+	b = append(b, ")"...)
+
+	switch method := strings.ToUpper(idx.Method); method {
+	case "", "FULLTEXT", "SPATIAL":
+	default:
+		b = append(b, " USING "...)
+		b = append(b, method...)
+	}
+	return b, nil
+}
+
+func (m *migrator) addForeignKey(gen schema.QueryGen, b []byte, add *migrate.AddForeignKeyOp) (_ []byte, err error) {
+	b = append(b, "ADD CONSTRAINT "...)
+
+	name := add.ConstraintName
+	if name == "" {
+		colRef := add.ForeignKey.From
+		columns := strings.Join(colRef.Column.Split(), "_")
+		name = fmt.Sprintf("%s_%s_fkey", colRef.TableName, columns)
+	}
+	b = gen.AppendName(b, name)
+
+	b = append(b, " FOREIGN KEY ("...)
+	if b, err = add.ForeignKey.From.Column.AppendQuery(gen, b); err != nil {
+		return b, err
+	}
+	b = append(b, ")"...)
+
+	b = append(b, " REFERENCES "...)
+	b = m.appendFQN(gen, b, add.ForeignKey.To.TableName)
+
+	b = append(b, " ("...)
+	if b, err = add.ForeignKey.To.Column.AppendQuery(gen, b); err != nil {
+		return b, err
+	}
+	b = append(b, ")"...)
+
+	return b, nil
+}
+
+// addCheck adds a CHECK constraint. MySQL parses but ignores CHECK constraints before 8.0.16.
+func (m *migrator) addCheck(gen schema.QueryGen, b []byte, add *migrate.AddCheckConstraintOp) (_ []byte, err error) {
+	b = append(b, "ADD "...)
+	if add.Check.Name != "" {
+		b = append(b, "CONSTRAINT "...)
+		b = gen.AppendName(b, add.Check.Name)
+		b = append(b, " "...)
+	}
+	b = append(b, "CHECK ("...)
+	b = append(b, add.Check.Expr...)
+	b = append(b, ")"...)
+	return b, nil
+}
+
+// dropCheck drops a CHECK constraint. DROP CONSTRAINT is understood by MySQL 8.0.19+ and MariaDB,
+// while DROP CHECK is MySQL-specific.
+func (m *migrator) dropCheck(gen schema.QueryGen, b []byte, name string) (_ []byte, err error) {
+	b = append(b, "DROP CONSTRAINT "...)
+	b = gen.AppendName(b, name)
+
+	return b, nil
+}
+
+func (m *migrator) dropForeignKey(gen schema.QueryGen, b []byte, name string) (_ []byte, err error) {
+	b = append(b, "DROP FOREIGN KEY "...)
+	b = gen.AppendName(b, name)
+
+	return b, nil
+}
+
+// changeColumnType re-defines the column with MODIFY COLUMN.
+// Unlike ALTER COLUMN in other dialects, MODIFY COLUMN replaces the entire column definition,
+// so the new definition must include all attributes of the column, not just the changed ones.
+func (m *migrator) changeColumnType(gen schema.QueryGen, b []byte, colDef *migrate.ChangeColumnTypeOp) (_ []byte, err error) {
+	b = append(b, "MODIFY COLUMN "...)
+	b = gen.AppendName(b, colDef.Column)
+	b = append(b, " "...)
+
+	return m.appendColumnDefinition(gen, b, colDef.To)
+}
+
+// appendColumnDefinition appends the data type and the attributes of the column.
+func (m *migrator) appendColumnDefinition(gen schema.QueryGen, b []byte, col sqlschema.Column) (_ []byte, err error) {
+	if b, err = col.AppendQuery(gen, b); err != nil {
+		return b, err
+	}
+
+	// The generated column clause must immediately follow the data type.
+	if col.GetIsGenerated() {
+		b = append(b, " GENERATED ALWAYS AS ("...)
+		b = append(b, col.GetGeneratedExpr()...)
+		b = append(b, ")"...)
+		if col.GetIsVirtual() {
+			b = append(b, " VIRTUAL"...)
+		} else {
+			b = append(b, " STORED"...)
+		}
+	}
+
+	if col.GetIsNullable() {
+		b = append(b, " NULL"...)
+	} else {
+		b = append(b, " NOT NULL"...)
+	}
+
+	if col.GetDefaultValue() != "" && !col.GetIsGenerated() {
+		b = append(b, " DEFAULT "...)
+		b = m.appendDefault(b, col.GetDefaultValue())
+	}
+
+	if col.GetIsAutoIncrement() {
+		b = append(b, " AUTO_INCREMENT"...)
+	}
+
+	if col.GetComment() != "" {
+		b = gen.AppendQuery(b, " COMMENT ?", col.GetComment())
+	}
+
+	return b, nil
+}
+
+// changeComment sets the table comment with a table option or re-defines the column with the new comment,
+// because MySQL does not have a statement to change only the comment on a column.
+func (m *migrator) changeComment(gen schema.QueryGen, b []byte, change *migrate.ChangeCommentOp) (_ []byte, err error) {
+	if change.ColumnName == "" {
+		return gen.AppendQuery(b, "COMMENT = ?", change.To), nil
+	}
+	if change.Column == nil {
+		return nil, fmt.Errorf("change comment on column %q: column definition is required", change.ColumnName)
+	}
+
+	col := &sqlschema.BaseColumn{
+		Name:            change.ColumnName,
+		SQLType:         change.Column.GetSQLType(),
+		VarcharLen:      change.Column.GetVarcharLen(),
+		DefaultValue:    change.Column.GetDefaultValue(),
+		IsNullable:      change.Column.GetIsNullable(),
+		IsAutoIncrement: change.Column.GetIsAutoIncrement(),
+		IsGenerated:     change.Column.GetIsGenerated(),
+		GeneratedExpr:   change.Column.GetGeneratedExpr(),
+		IsVirtual:       change.Column.GetIsVirtual(),
+		Comment:         change.To,
+	}
+	b = append(b, "MODIFY COLUMN "...)
+	b = gen.AppendName(b, change.ColumnName)
+	b = append(b, " "...)
+	return m.appendColumnDefinition(gen, b, col)
+}
+
+// appendDefault converts the column's default value back to an SQL expression.
+// sqlschema.Column stores string literals without quotes, so the value is quoted
+// unless it is a number, a keyword, or an expression.
+// Expressions other than CURRENT_TIMESTAMP must be enclosed in parentheses.
+func (m *migrator) appendDefault(b []byte, s string) []byte {
+	switch upper := strings.ToUpper(s); {
+	case strings.HasPrefix(upper, "CURRENT_TIMESTAMP"), upper == "NULL", upper == "TRUE", upper == "FALSE":
+		return append(b, s...)
+	case strings.HasPrefix(s, "'"):
+		return append(b, s...)
+	case strings.Contains(s, "("):
+		return append(append(append(b, '('), s...), ')')
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return append(b, s...)
+	}
+	return m.db.Dialect().AppendString(b, s)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/dialect/sqltype"
+	"github.com/uptrace/bun/migrate/sqlschema"
 	"github.com/uptrace/bun/schema"
 )
 
@@ -35,6 +36,10 @@ type Dialect struct {
 	loc      *time.Location
 }
 
+var _ schema.Dialect = (*Dialect)(nil)
+var _ sqlschema.InspectorDialect = (*Dialect)(nil)
+var _ sqlschema.MigratorDialect = (*Dialect)(nil)
+
 func New(opts ...DialectOption) *Dialect {
 	d := new(Dialect)
 	d.tables = schema.NewTables(d)
@@ -50,7 +55,8 @@ func New(opts ...DialectOption) *Dialect {
 		feature.CompositeIn |
 		feature.FKDefaultOnAction |
 		feature.UpdateOrderLimit |
-		feature.DeleteOrderLimit
+		feature.DeleteOrderLimit |
+		feature.InlineComment
 
 	for _, opt := range opts {
 		opt(d)
@@ -0,0 +1,531 @@
+package mysqldialect
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate/sqlschema"
+)
+
+type (
+	Schema = sqlschema.BaseDatabase
+	Table  = sqlschema.BaseTable
+	Column = sqlschema.BaseColumn
+)
+
+func (d *Dialect) NewInspector(db *bun.DB, options ...sqlschema.InspectorOption) sqlschema.Inspector {
+	return newInspector(db, options...)
+}
+
+type Inspector struct {
+	sqlschema.InspectorConfig
+	db *bun.DB
+}
+
+var _ sqlschema.Inspector = (*Inspector)(nil)
+
+func newInspector(db *bun.DB, options ...sqlschema.InspectorOption) *Inspector {
+	i := &Inspector{db: db}
+	i.SchemaName = db.Dialect().DefaultSchema()
+	sqlschema.ApplyInspectorOptions(&i.InspectorConfig, options...)
+	return i
+}
+
+func (in *Inspector) Inspect(ctx context.Context) (sqlschema.Database, error) {
+	dbSchema := Schema{
+		ForeignKeys: make(map[sqlschema.ForeignKey]string),
+	}
+
+	schemaName := schemaNameArg(in.db, in.SchemaName)
+
+	var tables []*InformationSchemaTable
+	if err := in.db.NewRaw(sqlInspectTables, schemaName).Scan(ctx, &tables); err != nil {
+		return dbSchema, err
+	}
+
+	var columns []*InformationSchemaColumn
+	if err := in.db.NewRaw(sqlInspectColumns, schemaName).Scan(ctx, &columns); err != nil {
+		return dbSchema, err
+	}
+
+	var constraints []*KeyConstraint
+	if err := in.db.NewRaw(sqlInspectKeyConstraints, schemaName).Scan(ctx, &constraints); err != nil {
+		return dbSchema, err
+	}
+
+	var fks []*ForeignKey
+	if err := in.db.NewRaw(sqlInspectForeignKeys, schemaName).Scan(ctx, &fks); err != nil {
+		return dbSchema, err
+	}
+
+	var indexes []*IndexColumn
+	if err := in.db.NewRaw(sqlInspectIndexes, schemaName).Scan(ctx, &indexes); err != nil {
+		return dbSchema, err
+	}
+
+	// CHECK constraints are only supported since MySQL 8.0.16 and MariaDB 10.2.
+	var checks []*CheckConstraint
+	var hasChecks bool
+	if err := in.db.NewRaw(sqlHasCheckConstraints).Scan(ctx, &hasChecks); err != nil {
+		return dbSchema, err
+	}
+	if hasChecks {
+		if err := in.db.NewRaw(sqlInspectCheckConstraints, schemaName).Scan(ctx, &checks); err != nil {
+			return dbSchema, err
+		}
+	}
+
+	byName := make(map[string]*Table, len(tables))
+	for _, t := range tables {
+		if in.isExcluded(t.Name) {
+			continue
+		}
+		table := &Table{
+			Schema:  in.SchemaName,
+			Name:    t.Name,
+			Comment: t.Comment,
+		}
+		byName[t.Name] = table
+		dbSchema.Tables = append(dbSchema.Tables, table)
+	}
+
+	for _, c := range columns {
+		table, ok := byName[c.Table]
+		if !ok {
+			continue
+		}
+		table.Columns = append(table.Columns, &Column{
+			Name:            c.Name,
+			SQLType:         strings.ToLower(c.DataType),
+			VarcharLen:      c.varcharLen(),
+			DefaultValue:    c.defaultValue(),
+			IsNullable:      c.IsNullable,
+			IsAutoIncrement: c.isAutoIncrement(),
+			IsGenerated:     c.GeneratedExpr != "",
+			GeneratedExpr:   c.GeneratedExpr,
+			IsVirtual:       strings.Contains(strings.ToUpper(c.Extra), "VIRTUAL GENERATED"),
+			Comment:         c.Comment,
+		})
+	}
+
+	// Constraints are sorted by table, constraint name and column position,
+	// so the columns of each constraint are in consecutive rows.
+	for i := 0; i < len(constraints); {
+		con := constraints[i]
+
+		var columns []string
+		for ; i < len(constraints) && constraints[i].sameConstraint(con); i++ {
+			columns = append(columns, constraints[i].Column)
+		}
+
+		table, ok := byName[con.Table]
+		if !ok {
+			continue
+		}
+		switch con.Type {
+		case "PRIMARY KEY":
+			table.PrimaryKey = &sqlschema.PrimaryKey{
+				Name:    con.Name,
+				Columns: sqlschema.NewColumns(columns...),
+			}
+		case "UNIQUE":
+			table.UniqueConstraints = append(table.UniqueConstraints, sqlschema.Unique{
+				Name:    con.Name,
+				Columns: sqlschema.NewColumns(columns...),
+			})
+		}
+	}
+
+	for _, check := range checks {
+		table, ok := byName[check.Table]
+		if !ok {
+			continue
+		}
+		table.CheckConstraints = append(table.CheckConstraints, sqlschema.Check{
+			Name: check.Name,
+			Expr: check.Expr,
+		})
+	}
+
+	// fkIndexes holds the indexes MySQL may have created implicitly for foreign keys, which are named
+	// either after the constraint or after its first column. In the latter case the key columns must match too.
+	fkIndexes := make(map[[2]string][]string)
+	for i := 0; i < len(fks); {
+		fk := fks[i]
+
+		var sourceColumns, targetColumns []string
+		for ; i < len(fks) && fks[i].sameConstraint(fk); i++ {
+			sourceColumns = append(sourceColumns, fks[i].SourceColumn)
+			targetColumns = append(targetColumns, fks[i].TargetColumn)
+		}
+		fkIndexes[[2]string{fk.SourceTable, fk.ConstraintName}] = nil
+		fkIndexes[[2]string{fk.SourceTable, sourceColumns[0]}] = sourceColumns
+
+		if in.isExcluded(fk.SourceTable) || in.isExcluded(fk.TargetTable) {
+			continue
+		}
+		dbFK := sqlschema.ForeignKey{
+			From: sqlschema.NewColumnReference(fk.SourceTable, sourceColumns...),
+			To:   sqlschema.NewColumnReference(fk.TargetTable, targetColumns...),
+		}
+		if _, exclude := in.ExcludeForeignKeys[dbFK]; exclude {
+			continue
+		}
+		dbSchema.ForeignKeys[dbFK] = fk.ConstraintName
+	}
+
+	for i := 0; i < len(indexes); {
+		idx := indexes[i]
+
+		var columns []string
+		var functional bool
+		for ; i < len(indexes) && indexes[i].sameIndex(idx); i++ {
+			columns = append(columns, indexes[i].Column)
+			functional = functional || indexes[i].Column == ""
+		}
+
+		table, ok := byName[idx.Table]
+		if !ok || functional {
+			// Key parts which are expressions cannot be compared reliably, because
+			// MySQL does not report them in the same form they were declared in.
+			continue
+		}
+		if fkColumns, ok := fkIndexes[[2]string{idx.Table, idx.Name}]; ok && (fkColumns == nil || slices.Equal(fkColumns, columns)) {
+			continue
+		}
+		table.Indexes = append(table.Indexes, sqlschema.Index{
+			Name:    idx.Name,
+			Columns: columns,
+			Method:  idx.method(),
+		})
+	}
+	return dbSchema, nil
+}
+
+// isExcluded checks the table name against the ExcludeTables patterns
+// using the same semantics as the SQL LIKE operator with a case-insensitive collation.
+func (in *Inspector) isExcluded(tableName string) bool {
+	for _, pattern := range in.ExcludeTables {
+		if matchLike(pattern, tableName) {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaNameArg returns the query argument which selects the schema (database) to inspect.
+// MySQL does not have a notion of a default schema, so the dialect's default schema name
+// stands for the database the connection is using.
+func schemaNameArg(db *bun.DB, schemaName string) any {
+	if schemaName == "" || schemaName == db.Dialect().DefaultSchema() {
+		return bun.Safe("DATABASE()")
+	}
+	return schemaName
+}
+
+type InformationSchemaTable struct {
+	Name    string `bun:"table_name"`
+	Comment string `bun:"table_comment"`
+}
+
+type InformationSchemaColumn struct {
+	Table      string `bun:"table_name"`
+	Name       string `bun:"column_name"`
+	DataType   string `bun:"data_type"`
+	VarcharLen int    `bun:"varchar_len"`
+	Default    string `bun:"column_default"`
+	IsNullable bool   `bun:"is_nullable"`
+	Extra      string `bun:"extra"`
+	Comment    string `bun:"column_comment"`
+
+	// GeneratedExpr is only set for generated columns.
+	GeneratedExpr string `bun:"generation_expr"`
+}
+
+// varcharLen returns the declared length of character and binary string types.
+// information_schema reports the maximum length for all string types, e.g. 65535 for TEXT,
+// but only CHAR, VARCHAR, BINARY and VARBINARY are declared with one.
+func (c *InformationSchemaColumn) varcharLen() int {
+	switch strings.ToUpper(c.DataType) {
+	case "CHAR", "VARCHAR", "BINARY", "VARBINARY":
+		return c.VarcharLen
+	}
+	return 0
+}
+
+// defaultValue normalizes the column default to match the format used by sqlschema.BunModelInspector:
+// string literals are unquoted and expressions are converted to lower case.
+//
+// MySQL reports literals unquoted and marks expressions with DEFAULT_GENERATED in the EXTRA column,
+// while MariaDB quotes string literals and reports a missing default as NULL.
+func (c *InformationSchemaColumn) defaultValue() string {
+	def := c.Default
+	switch {
+	case len(def) >= 2 && def[0] == '\'' && def[len(def)-1] == '\'':
+		return strings.ReplaceAll(def[1:len(def)-1], "''", "'")
+	case strings.EqualFold(def, "NULL"):
+		return ""
+	case strings.Contains(strings.ToUpper(c.Extra), "DEFAULT_GENERATED"),
+		strings.HasPrefix(strings.ToUpper(def), "CURRENT_TIMESTAMP"):
+		return strings.ToLower(def)
+	}
+	return def
+}
+
+func (c *InformationSchemaColumn) isAutoIncrement() bool {
+	return strings.Contains(strings.ToLower(c.Extra), "auto_increment")
+}
+
+// KeyConstraint is a column of a PRIMARY KEY or a UNIQUE constraint.
+type KeyConstraint struct {
+	Table  string `bun:"table_name"`
+	Name   string `bun:"constraint_name"`
+	Type   string `bun:"constraint_type"`
+	Column string `bun:"column_name"`
+}
+
+func (con *KeyConstraint) sameConstraint(other *KeyConstraint) bool {
+	return con.Table == other.Table && con.Name == other.Name
+}
+
+// CheckConstraint is a table or column CHECK constraint.
+type CheckConstraint struct {
+	Table string `bun:"table_name"`
+	Name  string `bun:"constraint_name"`
+	Expr  string `bun:"check_clause"`
+}
+
+// IndexColumn is a key part of a non-unique index. Unique indexes are reported as UNIQUE constraints.
+type IndexColumn struct {
+	Table  string `bun:"table_name"`
+	Name   string `bun:"index_name"`
+	Type   string `bun:"index_type"`
+	Column string `bun:"column_name"` // empty for functional key parts
+}
+
+func (idx *IndexColumn) sameIndex(other *IndexColumn) bool {
+	return idx.Table == other.Table && idx.Name == other.Name
+}
+
+// method returns the index type unless it is the default BTREE.
+func (idx *IndexColumn) method() string {
+	if strings.EqualFold(idx.Type, "BTREE") {
+		return ""
+	}
+	return strings.ToLower(idx.Type)
+}
+
+// ForeignKey is a column of a FOREIGN KEY constraint.
+type ForeignKey struct {
+	ConstraintName string `bun:"constraint_name"`
+	SourceTable    string `bun:"table_name"`
+	SourceColumn   string `bun:"column_name"`
+	TargetTable    string `bun:"target_table"`
+	TargetColumn   string `bun:"target_column"`
+}
+
+func (fk *ForeignKey) sameConstraint(other *ForeignKey) bool {
+	return fk.SourceTable == other.SourceTable && fk.ConstraintName == other.ConstraintName
+}
+
+// matchLike reports whether s matches the pattern in the same way the LIKE operator would:
+// % matches any sequence of characters, _ matches any single character and the match is case-insensitive.
+func matchLike(pattern, s string) bool {
+	p, str := []rune(strings.ToLower(pattern)), []rune(strings.ToLower(s))
+
+	var match func(i, j int) bool
+	match = func(i, j int) bool {
+		for ; i < len(p); i++ {
+			switch p[i] {
+			case '%':
+				for k := j; k <= len(str); k++ {
+					if match(i+1, k) {
+						return true
+					}
+				}
+				return false
+			case '_':
+				if j >= len(str) {
+					return false
+				}
+			default:
+				if j >= len(str) || str[j] != p[i] {
+					return false
+				}
+			}
+			j++
+		}
+		return j == len(str)
+	}
+	return match(0, 0)
+}
+
+// CompareType returns true if col1 and col2 SQL types are equivalent,
+// e.g. BOOLEAN is a synonym for TINYINT(1) and INTEGER for INT.
+func (d *Dialect) CompareType(col1, col2 sqlschema.Column) bool {
+	typ1, typ2 := strings.ToUpper(col1.GetSQLType()), strings.ToUpper(col2.GetSQLType())
+
+	if typ1 == typ2 {
+		return checkVarcharLen(col1, col2, d.DefaultVarcharLen())
+	}
+
+	switch {
+	case char.IsAlias(typ1) && char.IsAlias(typ2),
+		varchar.IsAlias(typ1) && varchar.IsAlias(typ2):
+		return checkVarcharLen(col1, col2, d.DefaultVarcharLen())
+	case boolean.IsAlias(typ1) && boolean.IsAlias(typ2),
+		integer.IsAlias(typ1) && integer.IsAlias(typ2),
+		double.IsAlias(typ1) && double.IsAlias(typ2),
+		decimal.IsAlias(typ1) && decimal.IsAlias(typ2),
+		json.IsAlias(typ1) && json.IsAlias(typ2):
+		return true
+	}
+	return false
+}
+
+// checkVarcharLen returns true if columns have the same VarcharLen, or,
+// if one specifies no VarcharLen and the other one has the default length for mysqldialect.
+func checkVarcharLen(col1, col2 sqlschema.Column, defaultLen int) bool {
+	vl1, vl2 := col1.GetVarcharLen(), col2.GetVarcharLen()
+
+	if vl1 == vl2 {
+		return true
+	}
+
+	if (vl1 == 0 && vl2 == defaultLen) || (vl1 == defaultLen && vl2 == 0) {
+		return true
+	}
+	return false
+}
+
+var (
+	char    = newAliases("CHAR", "CHARACTER")
+	varchar = newAliases("VARCHAR", "CHARACTER VARYING")
+	boolean = newAliases("BOOLEAN", "BOOL", "TINYINT")
+	integer = newAliases("INTEGER", "INT")
+	double  = newAliases("DOUBLE PRECISION", "DOUBLE", "REAL")
+	decimal = newAliases("DECIMAL", "NUMERIC", "DEC", "FIXED")
+	// MariaDB implements JSON as an alias for LONGTEXT.
+	json = newAliases("JSON", "LONGTEXT")
+)
+
+// typeAlias defines aliases for common data types. It is a lightweight string set implementation.
+type typeAlias map[string]struct{}
+
+// IsAlias checks if typ1 and typ2 are aliases of the same data type.
+func (t typeAlias) IsAlias(typ string) bool {
+	_, ok := t[typ]
+	return ok
+}
+
+// newAliases creates a set of aliases.
+func newAliases(aliases ...string) typeAlias {
+	types := make(typeAlias)
+	for _, a := range aliases {
+		types[a] = struct{}{}
+	}
+	return types
+}
+
+const (
+	// sqlInspectTables retrieves all user-defined tables in the selected schema.
+	sqlInspectTables = `
+SELECT t.TABLE_NAME AS table_name, t.TABLE_COMMENT AS table_comment
+FROM information_schema.TABLES AS t
+WHERE t.TABLE_SCHEMA = ?
+	AND t.TABLE_TYPE = 'BASE TABLE'
+ORDER BY t.TABLE_NAME
+`
+
+	// sqlInspectColumns retrieves column definitions for all tables in the selected schema.
+	sqlInspectColumns = `
+SELECT
+	c.TABLE_NAME AS table_name,
+	c.COLUMN_NAME AS column_name,
+	c.DATA_TYPE AS data_type,
+	COALESCE(c.CHARACTER_MAXIMUM_LENGTH, 0) AS varchar_len,
+	COALESCE(c.COLUMN_DEFAULT, '') AS column_default,
+	c.IS_NULLABLE = 'YES' AS is_nullable,
+	c.EXTRA AS extra,
+	c.COLUMN_COMMENT AS column_comment,
+	COALESCE(c.GENERATION_EXPRESSION, '') AS generation_expr
+FROM information_schema.COLUMNS AS c
+WHERE c.TABLE_SCHEMA = ?
+ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION
+`
+
+	// sqlInspectKeyConstraints retrieves the columns of PRIMARY KEY and UNIQUE constraints
+	// for all tables in the selected schema.
+	sqlInspectKeyConstraints = `
+SELECT
+	tc.TABLE_NAME AS table_name,
+	tc.CONSTRAINT_NAME AS constraint_name,
+	tc.CONSTRAINT_TYPE AS constraint_type,
+	kcu.COLUMN_NAME AS column_name
+FROM information_schema.TABLE_CONSTRAINTS AS tc
+	JOIN information_schema.KEY_COLUMN_USAGE AS kcu
+		ON kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+		AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		AND kcu.TABLE_NAME = tc.TABLE_NAME
+WHERE tc.TABLE_SCHEMA = ?
+	AND tc.CONSTRAINT_TYPE IN ('PRIMARY KEY', 'UNIQUE')
+ORDER BY tc.TABLE_NAME, tc.CONSTRAINT_NAME, kcu.ORDINAL_POSITION
+`
+
+	// sqlInspectForeignKeys retrieves the columns of FOREIGN KEY constraints
+	// for all tables in the selected schema.
+	sqlInspectForeignKeys = `
+SELECT
+	kcu.CONSTRAINT_NAME AS constraint_name,
+	kcu.TABLE_NAME AS table_name,
+	kcu.COLUMN_NAME AS column_name,
+	kcu.REFERENCED_TABLE_NAME AS target_table,
+	kcu.REFERENCED_COLUMN_NAME AS target_column
+FROM information_schema.TABLE_CONSTRAINTS AS tc
+	JOIN information_schema.KEY_COLUMN_USAGE AS kcu
+		ON kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+		AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		AND kcu.TABLE_NAME = tc.TABLE_NAME
+WHERE tc.TABLE_SCHEMA = ?
+	AND tc.CONSTRAINT_TYPE = 'FOREIGN KEY'
+ORDER BY kcu.TABLE_NAME, kcu.CONSTRAINT_NAME, kcu.ORDINAL_POSITION
+`
+	// sqlInspectIndexes retrieves the key parts of non-unique indexes for all tables in the selected schema.
+	sqlInspectIndexes = `
+SELECT
+	s.TABLE_NAME AS table_name,
+	s.INDEX_NAME AS index_name,
+	s.INDEX_TYPE AS index_type,
+	COALESCE(s.COLUMN_NAME, '') AS column_name
+FROM information_schema.STATISTICS AS s
+WHERE s.TABLE_SCHEMA = ?
+	AND s.NON_UNIQUE = 1
+ORDER BY s.TABLE_NAME, s.INDEX_NAME, s.SEQ_IN_INDEX
+`
+
+	// sqlHasCheckConstraints reports whether the server exposes CHECK constraints in information_schema.
+	sqlHasCheckConstraints = `
+SELECT COUNT(*) > 0
+FROM information_schema.TABLES AS t
+WHERE t.TABLE_SCHEMA = 'information_schema'
+	AND t.TABLE_NAME = 'CHECK_CONSTRAINTS'
+`
+
+	// sqlInspectCheckConstraints retrieves CHECK constraints in the selected schema.
+	// MySQL does not report the table in CHECK_CONSTRAINTS, so it is taken from TABLE_CONSTRAINTS.
+	sqlInspectCheckConstraints = `
+SELECT
+	tc.TABLE_NAME AS table_name,
+	cc.CONSTRAINT_NAME AS constraint_name,
+	cc.CHECK_CLAUSE AS check_clause
+FROM information_schema.CHECK_CONSTRAINTS AS cc
+	JOIN information_schema.TABLE_CONSTRAINTS AS tc
+		ON tc.CONSTRAINT_SCHEMA = cc.CONSTRAINT_SCHEMA
+		AND tc.CONSTRAINT_NAME = cc.CONSTRAINT_NAME
+		AND tc.CONSTRAINT_TYPE = 'CHECK'
+WHERE cc.CONSTRAINT_SCHEMA = ?
+ORDER BY tc.TABLE_NAME, cc.CONSTRAINT_NAME
+`
+)
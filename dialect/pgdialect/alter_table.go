@@ -68,6 +68,24 @@ func (m *migrator) AppendSQL(b []byte, operation any) (_ []byte, err error) {
 		b, err = m.addForeignKey(gen, appendAlterTable(b, change.TableName()), change)
 	case *migrate.DropForeignKeyOp:
 		b, err = m.dropConstraint(gen, appendAlterTable(b, change.TableName()), change.ConstraintName)
+	case *migrate.ChangeCommentOp:
+		return m.changeComment(gen, b, change), nil
+	case *migrate.AddCheckConstraintOp:
+		b, err = m.addCheck(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.DropCheckConstraintOp:
+		b, err = m.dropConstraint(gen, appendAlterTable(b, change.TableName), change.Check.Name)
+	case *migrate.CreateIndexOp:
+		return m.NewCreateIndex(change.Index).TableExpr("?.?", bun.Ident(m.schemaName), bun.Ident(change.TableName)).AppendQuery(gen, b)
+	case *migrate.DropIndexOp:
+		b = append(b, "DROP INDEX "...)
+		return gen.AppendQuery(b, "?.?", bun.Ident(m.schemaName), bun.Ident(change.Index.Name)), nil
+	case *migrate.CreateEnumOp:
+		return m.createEnum(gen, b, change), nil
+	case *migrate.AddEnumValueOp:
+		return m.addEnumValue(gen, b, change), nil
+	case *migrate.DropEnumOp:
+		b = append(b, "DROP TYPE "...)
+		return m.appendFQN(gen, b, change.Enum.Name), nil
 	default:
 		return nil, fmt.Errorf("append sql: unknown operation %T", change)
 	}
@@ -107,7 +125,16 @@ func (m *migrator) addColumn(gen schema.QueryGen, b []byte, add *migrate.AddColu
 		return nil, err
 	}
 
-	if add.Column.GetDefaultValue() != "" {
+	if add.Column.GetIsGenerated() {
+		b = append(b, " GENERATED ALWAYS AS ("...)
+		b = append(b, add.Column.GetGeneratedExpr()...)
+		b = append(b, ")"...)
+		if add.Column.GetIsVirtual() {
+			b = append(b, " VIRTUAL"...)
+		} else {
+			b = append(b, " STORED"...)
+		}
+	} else if add.Column.GetDefaultValue() != "" {
 		b = append(b, " DEFAULT "...)
 		b = append(b, add.Column.GetDefaultValue()...)
 		b = append(b, " "...)
@@ -117,9 +144,37 @@ func (m *migrator) addColumn(gen schema.QueryGen, b []byte, add *migrate.AddColu
 		b = appendGeneratedAsIdentity(b)
 	}
 
+	if comment := add.Column.GetComment(); comment != "" {
+		b = append(b, "; "...)
+		b = m.changeComment(gen, b, &migrate.ChangeCommentOp{
+			TableName:  add.TableName,
+			ColumnName: add.ColumnName,
+			To:         comment,
+		})
+	}
+
 	return b, nil
 }
 
+// changeComment sets the comment with COMMENT ON, which removes the comment if it is NULL.
+func (m *migrator) changeComment(gen schema.QueryGen, b []byte, change *migrate.ChangeCommentOp) []byte {
+	if change.ColumnName == "" {
+		b = append(b, "COMMENT ON TABLE "...)
+		b = m.appendFQN(gen, b, change.TableName)
+	} else {
+		b = append(b, "COMMENT ON COLUMN "...)
+		b = m.appendFQN(gen, b, change.TableName)
+		b = append(b, "."...)
+		b = gen.AppendName(b, change.ColumnName)
+	}
+
+	b = append(b, " IS "...)
+	if change.To == "" {
+		return append(b, "NULL"...)
+	}
+	return gen.AppendQuery(b, "?", change.To)
+}
+
 func (m *migrator) dropColumn(gen schema.QueryGen, b []byte, drop *migrate.DropColumnOp) (_ []byte, err error) {
 	b = append(b, "DROP COLUMN "...)
 	b = gen.AppendName(b, drop.ColumnName)
@@ -157,6 +212,45 @@ func (m *migrator) addUnique(gen schema.QueryGen, b []byte, change *migrate.AddU
 	return b, nil
 }
 
+func (m *migrator) addCheck(gen schema.QueryGen, b []byte, add *migrate.AddCheckConstraintOp) (_ []byte, err error) {
+	b = append(b, "ADD "...)
+	if add.Check.Name != "" {
+		b = append(b, "CONSTRAINT "...)
+		b = gen.AppendName(b, add.Check.Name)
+		b = append(b, " "...)
+	}
+	b = append(b, "CHECK ("...)
+	b = append(b, add.Check.Expr...)
+	b = append(b, ")"...)
+	return b, nil
+}
+
+func (m *migrator) createEnum(gen schema.QueryGen, b []byte, create *migrate.CreateEnumOp) []byte {
+	b = append(b, "CREATE TYPE "...)
+	b = m.appendFQN(gen, b, create.Enum.Name)
+	b = append(b, " AS ENUM ("...)
+	for i, value := range create.Enum.Values {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = gen.AppendQuery(b, "?", value)
+	}
+	return append(b, ")"...)
+}
+
+func (m *migrator) addEnumValue(gen schema.QueryGen, b []byte, add *migrate.AddEnumValueOp) []byte {
+	b = append(b, "ALTER TYPE "...)
+	b = m.appendFQN(gen, b, add.TypeName)
+	b = gen.AppendQuery(b, " ADD VALUE ?", add.Value)
+	switch {
+	case add.Before != "":
+		b = gen.AppendQuery(b, " BEFORE ?", add.Before)
+	case add.After != "":
+		b = gen.AppendQuery(b, " AFTER ?", add.After)
+	}
+	return b
+}
+
 func (m *migrator) dropConstraint(gen schema.QueryGen, b []byte, name string) (_ []byte, err error) {
 	b = append(b, "DROP CONSTRAINT "...)
 	b = gen.AppendName(b, name)
@@ -53,6 +53,7 @@ func New(opts ...DialectOption) *Dialect {
 		feature.TableNotExists |
 		feature.InsertOnConflict |
 		feature.SelectExists |
+		feature.AggregateFilter |
 		feature.GeneratedIdentity |
 		feature.CompositeIn |
 		feature.FKDefaultOnAction |
@@ -60,7 +61,10 @@ func New(opts ...DialectOption) *Dialect {
 		feature.Merge |
 		feature.MergeReturning |
 		feature.AlterColumnExists |
-		feature.CreateIndexIfNotExists
+		feature.CreateIndexIfNotExists |
+		feature.CommentOn |
+		feature.EnumType |
+		feature.MaterializedView
 
 	for _, opt := range opts {
 		opt(d)
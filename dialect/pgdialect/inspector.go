@@ -53,6 +53,35 @@ func (in *Inspector) Inspect(ctx context.Context) (sqlschema.Database, error) {
 	}
 	dbSchema.ForeignKeys = make(map[sqlschema.ForeignKey]string, len(fks))
 
+	var indexes []*Index
+	if err := in.db.NewRaw(sqlInspectIndexes, in.SchemaName, bun.List(exclude)).Scan(ctx, &indexes); err != nil {
+		return dbSchema, err
+	}
+	tableIndexes := make(map[string][]sqlschema.Index)
+	for _, idx := range indexes {
+		tableIndexes[idx.Table] = append(tableIndexes[idx.Table], idx.index())
+	}
+
+	var checks []*CheckConstraint
+	if err := in.db.NewRaw(sqlInspectCheckConstraints, in.SchemaName, bun.List(exclude)).Scan(ctx, &checks); err != nil {
+		return dbSchema, err
+	}
+	tableChecks := make(map[string][]sqlschema.Check)
+	for _, check := range checks {
+		tableChecks[check.Table] = append(tableChecks[check.Table], sqlschema.Check{
+			Name: check.Name,
+			Expr: check.Expr,
+		})
+	}
+
+	var enums []*Enum
+	if err := in.db.NewRaw(sqlInspectEnums, in.SchemaName).Scan(ctx, &enums); err != nil {
+		return dbSchema, err
+	}
+	for _, enum := range enums {
+		dbSchema.Enums = append(dbSchema.Enums, sqlschema.Enum{Name: enum.Name, Values: enum.Values})
+	}
+
 	for _, table := range tables {
 		var columns []*InformationSchemaColumn
 		if err := in.db.NewRaw(sqlInspectColumnsQuery, table.Schema, table.Name).Scan(ctx, &columns); err != nil {
@@ -78,6 +107,9 @@ func (in *Inspector) Inspect(ctx context.Context) (sqlschema.Database, error) {
 				IsNullable:      c.IsNullable,
 				IsAutoIncrement: c.IsSerial,
 				IsIdentity:      c.IsIdentity,
+				IsGenerated:     c.IsGenerated,
+				GeneratedExpr:   c.GeneratedExpr,
+				Comment:         c.Comment,
 			})
 
 			for _, group := range c.UniqueGroups {
@@ -107,6 +139,9 @@ func (in *Inspector) Inspect(ctx context.Context) (sqlschema.Database, error) {
 			Columns:           colDefs,
 			PrimaryKey:        pk,
 			UniqueConstraints: unique,
+			CheckConstraints:  tableChecks[table.Name],
+			Indexes:           tableIndexes[table.Name],
+			Comment:           table.Comment,
 		})
 	}
 
@@ -127,6 +162,7 @@ type InformationSchemaTable struct {
 	Schema     string     `bun:"table_schema,pk"`
 	Name       string     `bun:"table_name,pk"`
 	PrimaryKey PrimaryKey `bun:"embed:primary_key_"`
+	Comment    string     `bun:"comment"`
 
 	Columns []*InformationSchemaColumn `bun:"rel:has-many,join:table_schema=table_schema,join:table_name=table_name"`
 }
@@ -145,7 +181,10 @@ type InformationSchemaColumn struct {
 	IndentityType    string   `bun:"identity_type"`
 	IsSerial         bool     `bun:"is_serial"`
 	IsNullable       bool     `bun:"is_nullable"`
+	IsGenerated      bool     `bun:"is_generated"`
+	GeneratedExpr    string   `bun:"generation_expr"`
 	UniqueGroups     []string `bun:"unique_groups,array"`
+	Comment          string   `bun:"comment"`
 }
 
 type ForeignKey struct {
@@ -158,6 +197,45 @@ type ForeignKey struct {
 	TargetColumns  []string `bun:"target_columns,array"`
 }
 
+// Index is a secondary index which does not back any PRIMARY KEY, UNIQUE or EXCLUDE constraint.
+type Index struct {
+	Table   string   `bun:"table_name"`
+	Name    string   `bun:"index_name"`
+	Unique  bool     `bun:"is_unique"`
+	Method  string   `bun:"method"`
+	Columns []string `bun:"columns,array"`
+	Include []string `bun:"include,array"`
+	Where   string   `bun:"predicate"`
+}
+
+func (idx *Index) index() sqlschema.Index {
+	method := idx.Method
+	if method == "btree" {
+		method = ""
+	}
+	return sqlschema.Index{
+		Name:    idx.Name,
+		Unique:  idx.Unique,
+		Columns: idx.Columns,
+		Method:  method,
+		Where:   idx.Where,
+		Include: idx.Include,
+	}
+}
+
+// CheckConstraint is a table-level CHECK constraint. NOT NULL constraints are not included.
+type CheckConstraint struct {
+	Table string `bun:"table_name"`
+	Name  string `bun:"constraint_name"`
+	Expr  string `bun:"expr"`
+}
+
+// Enum is an enumerated type created with CREATE TYPE ... AS ENUM.
+type Enum struct {
+	Name   string   `bun:"type_name"`
+	Values []string `bun:"labels,array"`
+}
+
 type PrimaryKey struct {
 	ConstraintName string   `bun:"name"`
 	Columns        []string `bun:"columns,array"`
@@ -171,7 +249,8 @@ SELECT
 	"t".table_schema,
 	"t".table_name,
 	pk.name AS primary_key_name,
-	pk.columns AS primary_key_columns
+	pk.columns AS primary_key_columns,
+	COALESCE(obj_description(format('%I.%I', "t".table_schema, "t".table_name)::regclass, 'pg_class'), '') AS "comment"
 FROM information_schema.tables "t"
 	LEFT JOIN (
 		SELECT i.indrelid, "idx".relname AS "name", ARRAY_AGG("a".attname) AS "columns"
@@ -199,7 +278,7 @@ SELECT
 	"c".table_schema,
 	"c".table_name,
 	"c".column_name,
-	"c".data_type,
+	CASE WHEN "c".data_type = 'USER-DEFINED' THEN "c".udt_name ELSE "c".data_type END AS data_type,
 	"c".character_maximum_length::integer AS varchar_len,
 	"c".data_type = 'ARRAY' AS is_array,
 	COALESCE("c".array_dims, 0) AS array_dims,
@@ -212,17 +291,24 @@ SELECT
 	"c".column_default = format('nextval(''%s_%s_seq''::regclass)', "c".table_name, "c".column_name) AS is_serial,
 	COALESCE("c".identity_type, '') AS identity_type,
 	"c".is_nullable = 'YES' AS is_nullable,
-	"c"."unique_groups" AS unique_groups
+	"c".is_generated = 'ALWAYS' AS is_generated,
+	COALESCE("c".generation_expression, '') AS generation_expr,
+	"c"."unique_groups" AS unique_groups,
+	COALESCE(col_description(format('%I.%I', "c".table_schema, "c".table_name)::regclass, "c".ordinal_position::integer), '') AS "comment"
 FROM (
 	SELECT
 		"table_schema",
 		"table_name",
 		"column_name",
+		"c".ordinal_position,
 		"c".data_type,
+		"c".udt_name,
 		"c".character_maximum_length,
 		"c".column_default,
 		"c".is_identity,
 		"c".is_nullable,
+		"c".is_generated,
+		"c".generation_expression,
 		att.array_dims,
 		att.identity_type,
 		att."unique_groups",
@@ -296,5 +382,72 @@ WHERE co.contype = 'f'
 	AND s.relname NOT LIKE ALL (ARRAY[?])
 	AND "t".relname NOT LIKE ALL (ARRAY[?])
 GROUP BY "constraint_name", "schema_name", "table_name", target_schema, target_table
+`
+	// sqlInspectIndexes retrieves secondary indexes on user-defined tables.
+	// Key columns which are expressions rather than plain column references are enclosed in parentheses.
+	// Pass bun.List([]string{...}) to exclude tables from this inspection or bun.List([]string{''}) to include all results.
+	sqlInspectIndexes = `
+SELECT
+	"t".relname AS "table_name",
+	"i".relname AS "index_name",
+	ix.indisunique AS is_unique,
+	am.amname AS "method",
+	ARRAY(
+		SELECT CASE
+			WHEN ix.indkey[k - 1] = 0 THEN '(' || pg_get_indexdef(ix.indexrelid, k, true) || ')'
+			ELSE pg_get_indexdef(ix.indexrelid, k, true)
+		END
+		FROM generate_series(1, ix.indnkeyatts) k
+		ORDER BY k
+	) AS "columns",
+	ARRAY(
+		SELECT pg_get_indexdef(ix.indexrelid, k, true)
+		FROM generate_series(ix.indnkeyatts + 1, ix.indnatts) k
+		ORDER BY k
+	) AS "include",
+	COALESCE(pg_get_expr(ix.indpred, ix.indrelid, true), '') AS predicate
+FROM pg_index ix
+	JOIN pg_class "i" ON "i".oid = ix.indexrelid
+	JOIN pg_class "t" ON "t".oid = ix.indrelid
+	JOIN pg_namespace s ON s.oid = "t".relnamespace
+	JOIN pg_am am ON am.oid = "i".relam
+WHERE s.nspname = ?
+	AND "t".relkind = 'r'
+	AND "t".relname NOT LIKE ALL (ARRAY[?])
+	AND NOT EXISTS (
+		SELECT 1 FROM pg_constraint con
+		WHERE con.conrelid = ix.indrelid AND con.conindid = ix.indexrelid AND con.contype IN ('p', 'u', 'x')
+	)
+ORDER BY "t".relname, "i".relname
+`
+
+	// sqlInspectCheckConstraints retrieves CHECK constraints on user-defined tables.
+	// Constraints inherited from the parent tables are reported for the parent only.
+	sqlInspectCheckConstraints = `
+SELECT
+	"t".relname AS "table_name",
+	con.conname AS "constraint_name",
+	pg_get_expr(con.conbin, con.conrelid, true) AS "expr"
+FROM pg_constraint con
+	JOIN pg_class "t" ON "t".oid = con.conrelid
+	JOIN pg_namespace s ON s.oid = "t".relnamespace
+WHERE s.nspname = ?
+	AND con.contype = 'c'
+	AND con.conislocal
+	AND "t".relkind = 'r'
+	AND "t".relname NOT LIKE ALL (ARRAY[?])
+ORDER BY "t".relname, con.conname
+`
+	// sqlInspectEnums retrieves enumerated types in the selected schema with their values in the declared order.
+	sqlInspectEnums = `
+SELECT
+	"t".typname AS "type_name",
+	ARRAY_AGG(e.enumlabel ORDER BY e.enumsortorder) AS "labels"
+FROM pg_type "t"
+	JOIN pg_enum e ON e.enumtypid = "t".oid
+	JOIN pg_namespace s ON s.oid = "t".typnamespace
+WHERE s.nspname = ?
+GROUP BY "t".typname
+ORDER BY "t".typname
 `
 )
@@ -0,0 +1,851 @@
+package sqlitedialect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqltype"
+	"github.com/uptrace/bun/migrate"
+	"github.com/uptrace/bun/migrate/sqlschema"
+	"github.com/uptrace/bun/schema"
+)
+
+func (d *Dialect) NewMigrator(db *bun.DB, schemaName string) sqlschema.Migrator {
+	return &migrator{db: db, schemaName: schemaName, BaseMigrator: sqlschema.NewBaseMigrator(db)}
+}
+
+// migrator renders schema changes for SQLite.
+//
+// SQLite can only rename tables and columns and add new columns in place.
+// All other changes are applied by re-creating the table with the new definition:
+// create a new table, copy the data, drop the old table and rename the new one.
+// See https://www.sqlite.org/lang_altertable.html#otheralter for more details.
+//
+// To be able to re-create a table migrator needs its complete definition,
+// so it keeps track of the schema state: the state is inspected in Init and
+// updated with every operation the migrator renders.
+type migrator struct {
+	*sqlschema.BaseMigrator
+
+	db         *bun.DB
+	schemaName string
+
+	// tables is the current schema state, nil if the migrator has not been initialized.
+	tables map[string]*tableDefinition
+
+	// foreignKeys is true if foreign key enforcement is on.
+	foreignKeys bool
+}
+
+var (
+	_ sqlschema.Migrator         = (*migrator)(nil)
+	_ sqlschema.StatefulMigrator = (*migrator)(nil)
+)
+
+// Init inspects the current schema and checks if foreign key enforcement is on for the connection.
+func (m *migrator) Init(ctx context.Context, conn bun.IDB) error {
+	tables, err := newInspector(conn, sqlschema.WithSchemaName(m.schemaName)).inspectTables(ctx)
+	if err != nil {
+		return fmt.Errorf("init migrator: %w", err)
+	}
+
+	var foreignKeys bool
+	if err := conn.NewRaw("PRAGMA foreign_keys").Scan(ctx, &foreignKeys); err != nil {
+		return fmt.Errorf("init migrator: %w", err)
+	}
+
+	m.foreignKeys = foreignKeys
+	m.tables = make(map[string]*tableDefinition, len(tables))
+	for _, t := range tables {
+		m.tables[t.Name] = t
+	}
+	return nil
+}
+
+func (m *migrator) AppendSQL(b []byte, operation any) (_ []byte, err error) {
+	if m.tables == nil {
+		return nil, errors.New("append sql: migrator is not initialized")
+	}
+
+	gen := m.db.QueryGen()
+
+	// Append ALTER TABLE statement to the enclosed query bytes []byte.
+	appendAlterTable := func(query []byte, tableName string) []byte {
+		query = append(query, "ALTER TABLE "...)
+		query = m.appendFQN(gen, query, tableName)
+		return append(query, " "...)
+	}
+
+	switch change := operation.(type) {
+	case *migrate.CreateTableOp:
+		b, err = m.createTable(b, change)
+	case *migrate.DropTableOp:
+		b, err = m.AppendDropTable(b, m.schemaName, change.TableName)
+		if err == nil {
+			delete(m.tables, change.TableName)
+		}
+	case *migrate.RenameTableOp:
+		b, err = m.renameTable(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.RenameColumnOp:
+		b, err = m.renameColumn(gen, appendAlterTable(b, change.TableName), change)
+	case *migrate.AddColumnOp:
+		b, err = m.addColumn(gen, b, change)
+	case *migrate.DropColumnOp:
+		b, err = m.alterTable(gen, b, change.TableName, func(t *tableDefinition) error {
+			return t.dropColumn(change.ColumnName)
+		})
+	case *migrate.AddPrimaryKeyOp:
+		b, err = m.alterTable(gen, b, change.TableName, func(t *tableDefinition) error {
+			return t.setPrimaryKey(change.PrimaryKey.Columns.Split())
+		})
+	case *migrate.ChangePrimaryKeyOp:
+		b, err = m.alterTable(gen, b, change.TableName, func(t *tableDefinition) error {
+			return t.setPrimaryKey(change.New.Columns.Split())
+		})
+	case *migrate.DropPrimaryKeyOp:
+		b, err = m.alterTable(gen, b, change.TableName, func(t *tableDefinition) error {
+			return t.setPrimaryKey(nil)
+		})
+	case *migrate.AddUniqueConstraintOp:
+		b, err = m.alterTable(gen, b, change.TableName, func(t *tableDefinition) error {
+			t.Unique = append(t.Unique, uniqueDefinition{
+				Name:    change.Unique.Name,
+				Columns: change.Unique.Columns.Split(),
+			})
+			return nil
+		})
+	case *migrate.DropUniqueConstraintOp:
+		b, err = m.alterTable(gen, b, change.TableName, func(t *tableDefinition) error {
+			return t.dropUnique(change.Unique.Columns)
+		})
+	case *migrate.ChangeColumnTypeOp:
+		b, err = m.alterTable(gen, b, change.TableName, func(t *tableDefinition) error {
+			if err := t.changeColumn(change.Column, change.To); err != nil {
+				return err
+			}
+			if change.To.GetIsAutoIncrement() {
+				return t.checkAutoIncrement(change.Column)
+			}
+			return nil
+		})
+	case *migrate.AddForeignKeyOp:
+		b, err = m.alterTable(gen, b, change.TableName(), func(t *tableDefinition) error {
+			t.ForeignKeys = append(t.ForeignKeys, &foreignKeyDefinition{
+				Columns:       change.ForeignKey.From.Column.Split(),
+				TargetTable:   change.ForeignKey.To.TableName,
+				TargetColumns: change.ForeignKey.To.Column.Split(),
+			})
+			return nil
+		})
+	case *migrate.DropForeignKeyOp:
+		b, err = m.alterTable(gen, b, change.TableName(), func(t *tableDefinition) error {
+			return t.dropForeignKey(change.ForeignKey)
+		})
+	case *migrate.AddCheckConstraintOp:
+		b, err = m.alterTable(gen, b, change.TableName, func(t *tableDefinition) error {
+			t.Checks = append(t.Checks, checkDefinition{Name: change.Check.Name, Expr: change.Check.Expr})
+			return nil
+		})
+	case *migrate.DropCheckConstraintOp:
+		b, err = m.alterTable(gen, b, change.TableName, func(t *tableDefinition) error {
+			return t.dropCheck(change.Check)
+		})
+	case *migrate.CreateIndexOp:
+		b, err = m.createIndex(gen, b, change)
+	case *migrate.DropIndexOp:
+		b, err = m.dropIndex(gen, b, change)
+	default:
+		return nil, fmt.Errorf("append sql: unknown operation %T", change)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("append sql: %w", err)
+	}
+	return b, nil
+}
+
+func (m *migrator) table(tableName string) (*tableDefinition, error) {
+	t, ok := m.tables[tableName]
+	if !ok {
+		return nil, fmt.Errorf("table %q does not exist", tableName)
+	}
+	return t, nil
+}
+
+func (m *migrator) appendFQN(gen schema.QueryGen, b []byte, tableName string) []byte {
+	return gen.AppendQuery(b, "?.?", bun.Ident(m.schemaName), bun.Ident(tableName))
+}
+
+func (m *migrator) createTable(b []byte, create *migrate.CreateTableOp) (_ []byte, err error) {
+	table := m.db.Table(reflect.TypeOf(create.Model))
+	t := newTableDefinition(table)
+	for _, f := range table.Fields {
+		if f.AutoIncrement {
+			if err := t.checkAutoIncrement(f.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if b, err = m.AppendCreateTable(b, create.Model); err != nil {
+		return nil, err
+	}
+	m.tables[t.Name] = t
+	return b, nil
+}
+
+// createIndex creates the index and records its definition, so that it survives later table rebuilds.
+// SQLite has no index access methods and does not support non-key columns in an index.
+func (m *migrator) createIndex(gen schema.QueryGen, b []byte, create *migrate.CreateIndexOp) (_ []byte, err error) {
+	t, err := m.table(create.TableName)
+	if err != nil {
+		return nil, err
+	}
+	if create.Index.Method != "" {
+		return nil, fmt.Errorf("index %q: sqlite does not support index method %q", create.Index.Name, create.Index.Method)
+	}
+	if len(create.Index.Include) > 0 {
+		return nil, fmt.Errorf("index %q: sqlite does not support INCLUDE columns", create.Index.Name)
+	}
+
+	q := m.NewCreateIndex(create.Index).
+		IndexExpr("?.?", bun.Ident(m.schemaName), bun.Ident(create.Index.Name)).
+		Table(create.TableName)
+	start := len(b)
+	if b, err = q.AppendQuery(gen, b); err != nil {
+		return nil, err
+	}
+
+	def := &indexDefinition{
+		Name:   create.Index.Name,
+		Unique: create.Index.Unique,
+		SQL:    string(b[start:]),
+	}
+	if create.Index.Where == "" && !slices.ContainsFunc(create.Index.Columns, func(col string) bool {
+		return strings.HasPrefix(col, "(")
+	}) {
+		def.Columns = slices.Clone(create.Index.Columns)
+	}
+	t.Indexes = append(t.Indexes, def)
+	return b, nil
+}
+
+func (m *migrator) dropIndex(gen schema.QueryGen, b []byte, drop *migrate.DropIndexOp) (_ []byte, err error) {
+	t, err := m.table(drop.TableName)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(t.Indexes, func(idx *indexDefinition) bool { return idx.Name == drop.Index.Name })
+	if i == -1 {
+		return nil, fmt.Errorf("index %q does not exist on table %q", drop.Index.Name, t.Name)
+	}
+	t.Indexes = slices.Delete(t.Indexes, i, i+1)
+
+	b = append(b, "DROP INDEX "...)
+	return gen.AppendQuery(b, "?.?", bun.Ident(m.schemaName), bun.Ident(drop.Index.Name)), nil
+}
+
+func (m *migrator) renameTable(gen schema.QueryGen, b []byte, rename *migrate.RenameTableOp) (_ []byte, err error) {
+	t, err := m.table(rename.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	b = append(b, "RENAME TO "...)
+	b = gen.AppendName(b, rename.NewName)
+
+	// SQLite updates indexes and foreign keys which reference the renamed table.
+	delete(m.tables, t.Name)
+	t.Name = rename.NewName
+	m.tables[t.Name] = t
+	for _, idx := range t.Indexes {
+		idx.renameTable(rename.NewName)
+	}
+	for _, other := range m.tables {
+		for _, fk := range other.ForeignKeys {
+			if fk.TargetTable == rename.TableName {
+				fk.TargetTable = rename.NewName
+			}
+		}
+	}
+	return b, nil
+}
+
+func (m *migrator) renameColumn(gen schema.QueryGen, b []byte, rename *migrate.RenameColumnOp) (_ []byte, err error) {
+	t, err := m.table(rename.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	c := t.column(rename.OldName)
+	if c == nil {
+		return nil, fmt.Errorf("column %q does not exist in table %q", rename.OldName, rename.TableName)
+	}
+
+	b = append(b, "RENAME COLUMN "...)
+	b = gen.AppendName(b, rename.OldName)
+
+	b = append(b, " TO "...)
+	b = gen.AppendName(b, rename.NewName)
+
+	// SQLite updates all constraints, indexes and foreign keys which reference the renamed column.
+	c.Name = rename.NewName
+	replaceName(t.PrimaryKey, rename.OldName, rename.NewName)
+	for _, u := range t.Unique {
+		replaceName(u.Columns, rename.OldName, rename.NewName)
+	}
+	for _, c := range t.Columns {
+		c.renameGenerated(rename.OldName, rename.NewName)
+	}
+	for i := range t.Checks {
+		t.Checks[i].renameColumn(rename.OldName, rename.NewName)
+	}
+	for _, idx := range t.Indexes {
+		idx.renameColumn(rename.OldName, rename.NewName)
+	}
+	for _, fk := range t.ForeignKeys {
+		replaceName(fk.Columns, rename.OldName, rename.NewName)
+	}
+	for _, other := range m.tables {
+		for _, fk := range other.ForeignKeys {
+			if fk.TargetTable == t.Name {
+				replaceName(fk.TargetColumns, rename.OldName, rename.NewName)
+			}
+		}
+	}
+	return b, nil
+}
+
+func (m *migrator) addColumn(gen schema.QueryGen, b []byte, add *migrate.AddColumnOp) (_ []byte, err error) {
+	t, err := m.table(add.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new column can never be an alias for the ROWID.
+	if add.Column.GetIsAutoIncrement() {
+		return nil, t.checkAutoIncrement(add.ColumnName)
+	}
+
+	// ALTER TABLE ADD COLUMN is only allowed if the new column can be filled
+	// with a constant value for the existing rows, or if it is a virtual generated column.
+	col := newColumnDefinition(add.ColumnName, add.Column)
+	if (col.NotNull && col.Default == "") || !isConstant(col.Default) || (col.Generated != "" && !col.Virtual) {
+		return m.alterTable(gen, b, add.TableName, func(t *tableDefinition) error {
+			t.Columns = append(t.Columns, col)
+			return nil
+		})
+	}
+
+	b = append(b, "ALTER TABLE "...)
+	b = m.appendFQN(gen, b, add.TableName)
+	b = append(b, " ADD COLUMN "...)
+	b = appendColumnDefinition(gen, b, t, col)
+
+	t.Columns = append(t.Columns, col)
+	return b, nil
+}
+
+// alterTable re-creates the table after applying the change to its definition.
+// Any indexes defined on the table are re-created too.
+//
+// If foreign key enforcement is on, it is turned off for the duration of the procedure,
+// because dropping the old table would otherwise fail or cascade to the referencing rows.
+// PRAGMA foreign_keys has no effect inside a transaction, so the procedure starts its own
+// transaction with BEGIN and fails before changing anything if it is executed in a transaction.
+// Before committing, the procedure checks that the new table did not break any foreign keys.
+//
+// Otherwise the procedure is wrapped in a savepoint, which also works inside a transaction.
+func (m *migrator) alterTable(gen schema.QueryGen, b []byte, tableName string, change func(*tableDefinition) error) (_ []byte, err error) {
+	current, err := m.table(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	target := current.clone()
+	if err := change(target); err != nil {
+		return nil, err
+	}
+
+	tmpName := "_bun_tmp_" + tableName
+	if m.foreignKeys {
+		b = append(b, "PRAGMA foreign_keys = OFF;\nBEGIN;\n"...)
+	} else {
+		b = append(b, "SAVEPOINT "...)
+		b = gen.AppendName(b, tmpName)
+		b = append(b, ";\n"...)
+	}
+
+	b = append(b, "CREATE TABLE "...)
+	b = m.appendFQN(gen, b, tmpName)
+	b = append(b, " ("...)
+	b = appendTableDefinition(gen, b, target)
+	b = append(b, ");\n"...)
+
+	// Generated columns are computed again and cannot be inserted.
+	var columns []string
+	for _, c := range target.Columns {
+		if old := current.column(c.Name); old != nil && old.Generated == "" && c.Generated == "" {
+			columns = append(columns, c.Name)
+		}
+	}
+
+	b = append(b, "INSERT INTO "...)
+	b = m.appendFQN(gen, b, tmpName)
+	b = append(b, " ("...)
+	b = appendNames(gen, b, columns)
+	b = append(b, ") SELECT "...)
+	b = appendNames(gen, b, columns)
+	b = append(b, " FROM "...)
+	b = m.appendFQN(gen, b, tableName)
+	b = append(b, ";\n"...)
+
+	b = append(b, "DROP TABLE "...)
+	b = m.appendFQN(gen, b, tableName)
+	b = append(b, ";\n"...)
+
+	b = append(b, "ALTER TABLE "...)
+	b = m.appendFQN(gen, b, tmpName)
+	b = append(b, " RENAME TO "...)
+	b = gen.AppendName(b, tableName)
+	b = append(b, ";\n"...)
+
+	for _, idx := range target.Indexes {
+		b = m.appendCreateIndex(gen, b, tableName, idx)
+		b = append(b, ";\n"...)
+	}
+
+	if m.foreignKeys {
+		b = m.appendForeignKeyCheck(gen, b)
+		b = append(b, "COMMIT;\nPRAGMA foreign_keys = ON"...)
+	} else {
+		b = append(b, "RELEASE "...)
+		b = gen.AppendName(b, tmpName)
+	}
+
+	m.tables[tableName] = target
+	return b, nil
+}
+
+// appendForeignKeyCheck appends statements which fail if PRAGMA foreign_key_check reports any violations.
+// The check is done by inserting its result into a temporary table with a CHECK constraint,
+// because SQLite does not have a way to raise an error from a plain SQL statement.
+func (m *migrator) appendForeignKeyCheck(gen schema.QueryGen, b []byte) []byte {
+	const checkTable = "_bun_foreign_key_check"
+
+	b = append(b, "CREATE TEMP TABLE "...)
+	b = gen.AppendName(b, checkTable)
+	b = append(b, " (\"ok\" BOOLEAN CONSTRAINT \"foreign_key_violation\" CHECK (\"ok\"));\n"...)
+
+	b = append(b, "INSERT INTO "...)
+	b = gen.AppendName(b, checkTable)
+	b = gen.AppendQuery(b, " SELECT NOT EXISTS (SELECT 1 FROM pragma_foreign_key_check(NULL, ?));\n", m.schemaName)
+
+	b = append(b, "DROP TABLE "...)
+	b = gen.AppendName(b, checkTable)
+	b = append(b, ";\n"...)
+	return b
+}
+
+func (m *migrator) appendCreateIndex(gen schema.QueryGen, b []byte, tableName string, idx *indexDefinition) []byte {
+	if len(idx.Columns) == 0 {
+		return append(b, idx.SQL...)
+	}
+
+	b = append(b, "CREATE "...)
+	if idx.Unique {
+		b = append(b, "UNIQUE "...)
+	}
+	b = append(b, "INDEX "...)
+	b = gen.AppendQuery(b, "?.?", bun.Ident(m.schemaName), bun.Ident(idx.Name))
+	b = append(b, " ON "...)
+	b = gen.AppendName(b, tableName)
+	b = append(b, " ("...)
+	b = appendNames(gen, b, idx.Columns)
+	b = append(b, ")"...)
+	return b
+}
+
+// appendTableDefinition appends column definitions and table constraints.
+func appendTableDefinition(gen schema.QueryGen, b []byte, t *tableDefinition) []byte {
+	for i, c := range t.Columns {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = appendColumnDefinition(gen, b, t, c)
+	}
+
+	// INTEGER PRIMARY KEY AUTOINCREMENT must be declared in the column definition.
+	if len(t.PrimaryKey) > 0 && !t.hasInlinePrimaryKey() {
+		b = append(b, ", PRIMARY KEY ("...)
+		b = appendNames(gen, b, t.PrimaryKey)
+		b = append(b, ")"...)
+	}
+
+	for _, u := range t.Unique {
+		if u.Name != "" {
+			b = append(b, ", CONSTRAINT "...)
+			b = gen.AppendName(b, u.Name)
+		} else {
+			b = append(b, ","...)
+		}
+		b = append(b, " UNIQUE ("...)
+		b = appendNames(gen, b, u.Columns)
+		b = append(b, ")"...)
+	}
+
+	for _, fk := range t.ForeignKeys {
+		b = append(b, ", FOREIGN KEY ("...)
+		b = appendNames(gen, b, fk.Columns)
+		b = append(b, ") REFERENCES "...)
+		b = gen.AppendName(b, fk.TargetTable)
+		b = append(b, " ("...)
+		b = appendNames(gen, b, fk.TargetColumns)
+		b = append(b, ")"...)
+		if fk.OnUpdate != "" && fk.OnUpdate != "NO ACTION" {
+			b = append(b, " ON UPDATE "...)
+			b = append(b, fk.OnUpdate...)
+		}
+		if fk.OnDelete != "" && fk.OnDelete != "NO ACTION" {
+			b = append(b, " ON DELETE "...)
+			b = append(b, fk.OnDelete...)
+		}
+	}
+
+	for _, check := range t.Checks {
+		if check.Name != "" {
+			b = append(b, ", CONSTRAINT "...)
+			b = gen.AppendName(b, check.Name)
+		} else {
+			b = append(b, ","...)
+		}
+		b = append(b, " CHECK ("...)
+		b = append(b, check.Expr...)
+		b = append(b, ")"...)
+	}
+	return b
+}
+
+func appendColumnDefinition(gen schema.QueryGen, b []byte, t *tableDefinition, c *columnDefinition) []byte {
+	b = gen.AppendName(b, c.Name)
+	if c.Type != "" {
+		b = append(b, " "...)
+		b = append(b, c.Type...)
+	}
+	if t.hasInlinePrimaryKey() && t.PrimaryKey[0] == c.Name {
+		b = append(b, " PRIMARY KEY AUTOINCREMENT"...)
+	}
+	if c.Generated != "" {
+		b = append(b, " GENERATED ALWAYS AS ("...)
+		b = append(b, c.Generated...)
+		if c.Virtual {
+			b = append(b, ") VIRTUAL"...)
+		} else {
+			b = append(b, ") STORED"...)
+		}
+	}
+	if c.NotNull {
+		b = append(b, " NOT NULL"...)
+	}
+	if c.Default != "" && c.Generated == "" {
+		b = append(b, " DEFAULT "...)
+		b = append(b, c.Default...)
+	}
+	return b
+}
+
+func appendNames(gen schema.QueryGen, b []byte, names []string) []byte {
+	for i, name := range names {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = gen.AppendName(b, name)
+	}
+	return b
+}
+
+// newTableDefinition creates a table definition which matches the CREATE TABLE query for the model.
+func newTableDefinition(table *schema.Table) *tableDefinition {
+	t := &tableDefinition{
+		Name: strings.TrimPrefix(table.Name, table.Schema+"."),
+	}
+
+	for _, f := range table.Fields {
+		t.Columns = append(t.Columns, &columnDefinition{
+			Name:      f.Name,
+			Type:      f.CreateTableSQLType,
+			NotNull:   f.NotNull,
+			Default:   f.SQLDefault,
+			Generated: f.Generated,
+			Virtual:   f.Virtual,
+		})
+		if f.IsPK {
+			t.PrimaryKey = append(t.PrimaryKey, f.Name)
+		}
+	}
+
+	// Dialect.AppendSequence only adds AUTOINCREMENT to INTEGER PRIMARY KEY columns.
+	if len(table.PKs) == 1 && table.PKs[0].AutoIncrement {
+		t.AutoIncrement = t.isRowID(t.column(table.PKs[0].Name))
+	}
+
+	keys := make([]string, 0, len(table.Unique))
+	for key := range table.Unique {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, name := range keys {
+		if name == "" {
+			for _, f := range table.Unique[name] {
+				t.Unique = append(t.Unique, uniqueDefinition{Columns: []string{f.Name}})
+			}
+			continue
+		}
+		var columns []string
+		for _, f := range table.Unique[name] {
+			columns = append(columns, f.Name)
+		}
+		t.Unique = append(t.Unique, uniqueDefinition{Name: name, Columns: columns})
+	}
+
+	for _, check := range table.Checks {
+		t.Checks = append(t.Checks, checkDefinition{Name: check.Name, Expr: check.Expr})
+	}
+	return t
+}
+
+// newColumnDefinition converts the column to its SQLite definition.
+func newColumnDefinition(name string, col sqlschema.Column) *columnDefinition {
+	typ := col.GetSQLType()
+	if col.GetVarcharLen() > 0 {
+		typ += "(" + strconv.Itoa(col.GetVarcharLen()) + ")"
+	}
+	return &columnDefinition{
+		Name:      name,
+		Type:      strings.ToUpper(typ),
+		NotNull:   !col.GetIsNullable(),
+		Default:   defaultExpr(col.GetDefaultValue()),
+		Generated: col.GetGeneratedExpr(),
+		Virtual:   col.GetIsVirtual(),
+	}
+}
+
+func (t *tableDefinition) clone() *tableDefinition {
+	clone := &tableDefinition{
+		Name:          t.Name,
+		PrimaryKey:    slices.Clone(t.PrimaryKey),
+		AutoIncrement: t.AutoIncrement,
+	}
+	for _, c := range t.Columns {
+		c := *c
+		clone.Columns = append(clone.Columns, &c)
+	}
+	for _, u := range t.Unique {
+		clone.Unique = append(clone.Unique, uniqueDefinition{Name: u.Name, Columns: slices.Clone(u.Columns)})
+	}
+	for _, fk := range t.ForeignKeys {
+		fk := *fk
+		fk.Columns = slices.Clone(fk.Columns)
+		fk.TargetColumns = slices.Clone(fk.TargetColumns)
+		clone.ForeignKeys = append(clone.ForeignKeys, &fk)
+	}
+	clone.Checks = slices.Clone(t.Checks)
+	for _, idx := range t.Indexes {
+		idx := *idx
+		idx.Columns = slices.Clone(idx.Columns)
+		clone.Indexes = append(clone.Indexes, &idx)
+	}
+	return clone
+}
+
+// hasInlinePrimaryKey reports whether the table's primary key is declared
+// as INTEGER PRIMARY KEY AUTOINCREMENT in the column definition.
+func (t *tableDefinition) hasInlinePrimaryKey() bool {
+	if !t.AutoIncrement || len(t.PrimaryKey) != 1 {
+		return false
+	}
+	c := t.column(t.PrimaryKey[0])
+	return c != nil && t.isRowID(c)
+}
+
+func (t *tableDefinition) dropColumn(name string) error {
+	i := slices.IndexFunc(t.Columns, func(c *columnDefinition) bool { return c.Name == name })
+	if i == -1 {
+		return fmt.Errorf("column %q does not exist in table %q", name, t.Name)
+	}
+	t.Columns = slices.Delete(t.Columns, i, i+1)
+
+	// Constraints and indexes which include the dropped column cannot be preserved.
+	if slices.Contains(t.PrimaryKey, name) {
+		t.PrimaryKey = nil
+	}
+	t.Unique = slices.DeleteFunc(t.Unique, func(u uniqueDefinition) bool {
+		return slices.Contains(u.Columns, name)
+	})
+	t.ForeignKeys = slices.DeleteFunc(t.ForeignKeys, func(fk *foreignKeyDefinition) bool {
+		return slices.Contains(fk.Columns, name)
+	})
+	t.Checks = slices.DeleteFunc(t.Checks, func(check checkDefinition) bool {
+		return check.references(name)
+	})
+	t.Indexes = slices.DeleteFunc(t.Indexes, func(idx *indexDefinition) bool {
+		return idx.references(name)
+	})
+	return nil
+}
+
+// checkAutoIncrement returns an error if the column cannot be declared AUTOINCREMENT,
+// which is only allowed for the INTEGER PRIMARY KEY.
+func (t *tableDefinition) checkAutoIncrement(name string) error {
+	if t.hasInlinePrimaryKey() && t.PrimaryKey[0] == name {
+		return nil
+	}
+	return fmt.Errorf("column %q in table %q cannot be AUTOINCREMENT: only INTEGER PRIMARY KEY can", name, t.Name)
+}
+
+func (t *tableDefinition) setPrimaryKey(columns []string) error {
+	for _, name := range columns {
+		c := t.column(name)
+		if c == nil {
+			return fmt.Errorf("column %q does not exist in table %q", name, t.Name)
+		}
+		c.NotNull = true
+	}
+	t.PrimaryKey = columns
+	return nil
+}
+
+func (t *tableDefinition) dropUnique(columns sqlschema.Columns) error {
+	i := slices.IndexFunc(t.Unique, func(u uniqueDefinition) bool {
+		return sqlschema.NewColumns(slices.Clone(u.Columns)...) == columns
+	})
+	if i == -1 {
+		return fmt.Errorf("table %q does not have a unique constraint on (%s)", t.Name, columns)
+	}
+	t.Unique = slices.Delete(t.Unique, i, i+1)
+	return nil
+}
+
+// dropCheck removes the constraint with the same name, or with an equivalent expression if it is unnamed.
+func (t *tableDefinition) dropCheck(check sqlschema.Check) error {
+	i := slices.IndexFunc(t.Checks, func(def checkDefinition) bool {
+		if check.Name != "" {
+			return def.Name == check.Name
+		}
+		return def.toCheck().Equals(check)
+	})
+	if i == -1 {
+		return fmt.Errorf("table %q does not have a check constraint %q", t.Name, check.Name)
+	}
+	t.Checks = slices.Delete(t.Checks, i, i+1)
+	return nil
+}
+
+func (t *tableDefinition) changeColumn(name string, to sqlschema.Column) error {
+	i := slices.IndexFunc(t.Columns, func(c *columnDefinition) bool { return c.Name == name })
+	if i == -1 {
+		return fmt.Errorf("column %q does not exist in table %q", name, t.Name)
+	}
+	t.Columns[i] = newColumnDefinition(name, to)
+	if t.isRowID(t.Columns[i]) {
+		t.AutoIncrement = to.GetIsAutoIncrement()
+	}
+	return nil
+}
+
+func (t *tableDefinition) dropForeignKey(fk sqlschema.ForeignKey) error {
+	i := slices.IndexFunc(t.ForeignKeys, func(def *foreignKeyDefinition) bool {
+		return def.TargetTable == fk.To.TableName &&
+			sqlschema.NewColumns(slices.Clone(def.Columns)...) == fk.From.Column &&
+			sqlschema.NewColumns(slices.Clone(def.TargetColumns)...) == fk.To.Column
+	})
+	if i == -1 {
+		return fmt.Errorf("table %q does not have a foreign key (%s) referencing %q (%s)",
+			t.Name, fk.From.Column, fk.To.TableName, fk.To.Column)
+	}
+	t.ForeignKeys = slices.Delete(t.ForeignKeys, i, i+1)
+	return nil
+}
+
+func replaceName(names []string, oldName, newName string) {
+	for i := range names {
+		if names[i] == oldName {
+			names[i] = newName
+		}
+	}
+}
+
+// defaultExpr converts the column's default value back to an SQL expression.
+// sqlschema.Column stores string literals without quotes, so the value is
+// quoted unless it is a number, a keyword, or an expression.
+func defaultExpr(s string) string {
+	if s == "" || !isConstant(s) {
+		return s
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return s
+	}
+	switch strings.ToUpper(s) {
+	case "NULL", "TRUE", "FALSE":
+		return s
+	}
+	if s[0] == '\'' {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// isConstant reports whether the default value is not a time function or an expression.
+// Columns with such default values cannot be added with ALTER TABLE ADD COLUMN.
+func isConstant(s string) bool {
+	switch strings.ToUpper(s) {
+	case "CURRENT_TIME", "CURRENT_DATE", "CURRENT_TIMESTAMP":
+		return false
+	}
+	return !strings.Contains(s, "(")
+}
+
+// CompareType reports whether the two columns have equivalent types.
+//
+// SQLite only uses the declared type to determine the column's affinity,
+// so all integer types are considered equivalent.
+func (d *Dialect) CompareType(col1, col2 sqlschema.Column) bool {
+	typ1, typ2 := strings.ToUpper(col1.GetSQLType()), strings.ToUpper(col2.GetSQLType())
+
+	if typ1 == typ2 {
+		return checkVarcharLen(col1, col2)
+	}
+	return integer.IsAlias(typ1) && integer.IsAlias(typ2)
+}
+
+// checkVarcharLen returns true if columns have the same VarcharLen.
+// Unlike other dialects, sqlitedialect does not have a default length.
+func checkVarcharLen(col1, col2 sqlschema.Column) bool {
+	return col1.GetVarcharLen() == col2.GetVarcharLen()
+}
+
+var integer = newAliases(sqltype.Integer, sqltype.BigInt, sqltype.SmallInt, "INT", "TINYINT", "MEDIUMINT", "INT2", "INT8")
+
+// typeAlias defines aliases for common data types. It is a lightweight string set implementation.
+type typeAlias map[string]struct{}
+
+// IsAlias checks if typ1 and typ2 are aliases of the same data type.
+func (t typeAlias) IsAlias(typ string) bool {
+	_, ok := t[typ]
+	return ok
+}
+
+// newAliases creates a set of aliases.
+func newAliases(aliases ...string) typeAlias {
+	types := make(typeAlias)
+	for _, a := range aliases {
+		types[a] = struct{}{}
+	}
+	return types
+}
@@ -0,0 +1,76 @@
+package sqlitedialect
+
+import (
+	"strings"
+
+	"github.com/uptrace/bun/migrate/sqlschema"
+)
+
+// checkDefinition is a CHECK constraint. Constraints declared on a column
+// are re-created as table constraints, which SQLite treats the same way.
+type checkDefinition struct {
+	Name string // empty if the constraint was declared without a name
+	Expr string
+}
+
+// parseChecks extracts CHECK constraints from the CREATE TABLE statement,
+// because SQLite does not provide a pragma to inspect them.
+func parseChecks(sql string) []checkDefinition {
+	var checks []checkDefinition
+	tokens := tokenize(sql)
+
+	var depth int
+	for i, tok := range tokens {
+		if tok.ident == "" {
+			if tok.end-tok.start == 1 {
+				switch sql[tok.start] {
+				case '(':
+					depth++
+				case ')':
+					depth--
+				}
+			}
+			continue
+		}
+		if depth != 1 || !tok.isKeyword("CHECK") {
+			continue
+		}
+
+		var check checkDefinition
+		if i >= 2 && tokens[i-2].isKeyword("CONSTRAINT") {
+			check.Name = tokens[i-1].ident
+		}
+
+		// Find the parenthesis which closes the expression, tok.call is set for "CHECK (".
+		start := i + 1
+		if !tok.call || start >= len(tokens) {
+			continue
+		}
+		if end := closingParen(sql, tokens, start); end != -1 {
+			check.Expr = strings.TrimSpace(sql[tokens[start].end:tokens[end].start])
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// references reports whether the constraint depends on the column.
+func (c *checkDefinition) references(column string) bool {
+	for _, tok := range tokenize(c.Expr) {
+		if tok.isColumn() && strings.EqualFold(tok.ident, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// renameColumn updates the expression after the column has been renamed.
+func (c *checkDefinition) renameColumn(oldName, newName string) {
+	c.Expr = replaceTokens(c.Expr, tokenize(c.Expr), func(tok sqlToken) bool {
+		return tok.isColumn() && strings.EqualFold(tok.ident, oldName)
+	}, newName)
+}
+
+func (c *checkDefinition) toCheck() sqlschema.Check {
+	return sqlschema.Check{Name: c.Name, Expr: c.Expr}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/dialect/sqltype"
+	"github.com/uptrace/bun/migrate/sqlschema"
 	"github.com/uptrace/bun/schema"
 )
 
@@ -26,6 +27,10 @@ type Dialect struct {
 	features feature.Feature
 }
 
+var _ schema.Dialect = (*Dialect)(nil)
+var _ sqlschema.InspectorDialect = (*Dialect)(nil)
+var _ sqlschema.MigratorDialect = (*Dialect)(nil)
+
 func New(opts ...DialectOption) *Dialect {
 	d := new(Dialect)
 	d.tables = schema.NewTables(d)
@@ -39,6 +44,7 @@ func New(opts ...DialectOption) *Dialect {
 		feature.InsertOnConflict |
 		feature.TableNotExists |
 		feature.SelectExists |
+		feature.AggregateFilter |
 		feature.AutoIncrement |
 		feature.CompositeIn |
 		feature.FKDefaultOnAction |
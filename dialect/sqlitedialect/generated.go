@@ -0,0 +1,99 @@
+package sqlitedialect
+
+import (
+	"strings"
+)
+
+// parseGenerated extracts the expressions of generated columns from the CREATE TABLE statement,
+// because PRAGMA table_xinfo only reports which columns are generated.
+func parseGenerated(sql string) map[string]string {
+	generated := make(map[string]string)
+	tokens := tokenize(sql)
+
+	var depth int
+	var column string // name of the column whose definition is being parsed
+	var next bool     // next token starts a column or a table constraint definition
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.ident == "" {
+			if tok.end-tok.start == 1 {
+				switch sql[tok.start] {
+				case '(':
+					if depth++; depth == 1 {
+						next = true
+					}
+				case ')':
+					depth--
+				case ',':
+					next = depth == 1
+				}
+			}
+			continue
+		}
+		if depth != 1 {
+			continue
+		}
+
+		if next {
+			next = false
+			column = tok.ident
+			if isConstraintKeyword(tok) {
+				column = ""
+			}
+			continue
+		}
+
+		// GENERATED ALWAYS is optional, but AS must be followed by the expression in parentheses.
+		if column == "" || !tok.isKeyword("AS") || !tok.call {
+			continue
+		}
+		end := closingParen(sql, tokens, i+1)
+		if end == -1 {
+			break
+		}
+		generated[column] = strings.TrimSpace(sql[tokens[i+1].end:tokens[end].start])
+		i = end
+	}
+	return generated
+}
+
+// isConstraintKeyword reports whether the token starts a table constraint rather than a column definition.
+func isConstraintKeyword(tok sqlToken) bool {
+	for _, keyword := range []string{"CONSTRAINT", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN"} {
+		if tok.isKeyword(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// closingParen returns the index of the token which closes the parenthesis at tokens[open], or -1 if there is none.
+func closingParen(sql string, tokens []sqlToken, open int) int {
+	var depth int
+	for i := open; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.ident != "" || tok.end-tok.start != 1 {
+			continue
+		}
+		switch sql[tok.start] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// renameGenerated updates the expression of a generated column after another column has been renamed.
+func (c *columnDefinition) renameGenerated(oldName, newName string) {
+	if c.Generated == "" {
+		return
+	}
+	c.Generated = replaceTokens(c.Generated, tokenize(c.Generated), func(tok sqlToken) bool {
+		return tok.isColumn() && strings.EqualFold(tok.ident, oldName)
+	}, newName)
+}
@@ -0,0 +1,267 @@
+package sqlitedialect
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/uptrace/bun/migrate/sqlschema"
+)
+
+// toIndex converts the index definition to the dialect-agnostic representation.
+// Key columns and the predicate of indexes on expressions are recovered from the original SQL.
+func (idx *indexDefinition) toIndex() sqlschema.Index {
+	index := sqlschema.Index{Name: idx.Name, Unique: idx.Unique}
+	if len(idx.Columns) > 0 {
+		index.Columns = slices.Clone(idx.Columns)
+		return index
+	}
+
+	_, tokens := parseCreateIndex(idx.SQL)
+	var depth, start int
+	for i, tok := range tokens {
+		switch {
+		case tok.ident != "":
+			if depth == 0 && tok.isKeyword("WHERE") {
+				index.Where = strings.TrimSpace(idx.SQL[tok.end:])
+				return index
+			}
+			continue
+		case tok.end-tok.start != 1:
+			continue
+		}
+		switch idx.SQL[tok.start] {
+		case '(':
+			if depth++; depth == 1 {
+				start = i + 1
+			}
+		case ',':
+			if depth == 1 {
+				index.Columns = append(index.Columns, indexColumn(idx.SQL, tokens[start:i]))
+				start = i + 1
+			}
+		case ')':
+			if depth == 1 {
+				index.Columns = append(index.Columns, indexColumn(idx.SQL, tokens[start:i]))
+			}
+			depth--
+		}
+	}
+	return index
+}
+
+// indexColumn returns the column name if the key is a plain column reference,
+// otherwise the expression enclosed in parentheses.
+func indexColumn(sql string, tokens []sqlToken) string {
+	if len(tokens) == 1 && tokens[0].isColumn() {
+		return tokens[0].ident
+	}
+	if len(tokens) == 0 {
+		return ""
+	}
+	expr := sql[tokens[0].start:tokens[len(tokens)-1].end]
+	if enclosed(sql, tokens) {
+		return expr
+	}
+	return "(" + expr + ")"
+}
+
+// enclosed reports whether the tokens are enclosed in a single pair of parentheses.
+func enclosed(sql string, tokens []sqlToken) bool {
+	if sql[tokens[0].start:tokens[0].end] != "(" {
+		return false
+	}
+	var depth int
+	for i, tok := range tokens {
+		if tok.ident != "" || tok.end-tok.start != 1 {
+			continue
+		}
+		switch sql[tok.start] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 && i < len(tokens)-1 {
+			return false
+		}
+	}
+	return true
+}
+
+// references reports whether the index depends on the column.
+func (idx *indexDefinition) references(column string) bool {
+	if len(idx.Columns) > 0 {
+		return slices.Contains(idx.Columns, column)
+	}
+	_, tokens := parseCreateIndex(idx.SQL)
+	for _, tok := range tokens {
+		if tok.isColumn() && strings.EqualFold(tok.ident, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// renameColumn updates the index definition after the column has been renamed.
+func (idx *indexDefinition) renameColumn(oldName, newName string) {
+	if len(idx.Columns) > 0 {
+		replaceName(idx.Columns, oldName, newName)
+		return
+	}
+	_, tokens := parseCreateIndex(idx.SQL)
+	idx.SQL = replaceTokens(idx.SQL, tokens, func(tok sqlToken) bool {
+		return tok.isColumn() && strings.EqualFold(tok.ident, oldName)
+	}, newName)
+}
+
+// renameTable updates the index definition after the table has been renamed.
+func (idx *indexDefinition) renameTable(newName string) {
+	if len(idx.Columns) > 0 {
+		return
+	}
+	table, _ := parseCreateIndex(idx.SQL)
+	if table == nil {
+		return
+	}
+	idx.SQL = replaceTokens(idx.SQL, []sqlToken{*table}, func(sqlToken) bool { return true }, newName)
+}
+
+// parseCreateIndex splits the CREATE INDEX statement into the name of the indexed table
+// and the tokens of the indexed expressions and the WHERE clause that follow it.
+func parseCreateIndex(sql string) (*sqlToken, []sqlToken) {
+	tokens := tokenize(sql)
+	for i, tok := range tokens {
+		if tok.isKeyword("ON") && i+1 < len(tokens) {
+			return &tokens[i+1], tokens[i+2:]
+		}
+	}
+	return nil, nil
+}
+
+// replaceTokens replaces the tokens which match with the quoted identifier.
+func replaceTokens(sql string, tokens []sqlToken, match func(sqlToken) bool, ident string) string {
+	var b strings.Builder
+	var pos int
+	for _, tok := range tokens {
+		if !match(tok) {
+			continue
+		}
+		b.WriteString(sql[pos:tok.start])
+		b.WriteString(`"` + strings.ReplaceAll(ident, `"`, `""`) + `"`)
+		pos = tok.end
+	}
+	b.WriteString(sql[pos:])
+	return b.String()
+}
+
+// sqlToken is a lexical token of an SQL statement.
+type sqlToken struct {
+	start, end int
+
+	// ident is the name of the identifier or keyword, empty for other tokens.
+	ident  string
+	quoted bool
+
+	// call is true if the identifier is followed by an opening parenthesis, i.e. it is a function name.
+	call bool
+	// collation is true if the identifier follows the COLLATE keyword.
+	collation bool
+}
+
+func (tok sqlToken) isKeyword(keyword string) bool {
+	return !tok.quoted && strings.EqualFold(tok.ident, keyword)
+}
+
+// isColumn reports whether the token may be a column name.
+func (tok sqlToken) isColumn() bool {
+	return tok.ident != "" && !tok.call && !tok.collation
+}
+
+// tokenize splits the statement into identifiers, literals and punctuation, skipping comments.
+// It only recognizes as much of the SQLite syntax as needed to find column names in a CREATE INDEX statement.
+func tokenize(sql string) []sqlToken {
+	var tokens []sqlToken
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(sql[i:], "--"):
+			if n := strings.IndexByte(sql[i:], '\n'); n != -1 {
+				i += n + 1
+			} else {
+				i = len(sql)
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			if n := strings.Index(sql[i+2:], "*/"); n != -1 {
+				i += n + 4
+			} else {
+				i = len(sql)
+			}
+		case c == '\'':
+			end := quotedEnd(sql, i, '\'')
+			tokens = append(tokens, sqlToken{start: i, end: end})
+			i = end
+		case c == '"' || c == '`' || c == '[':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			end := quotedEnd(sql, i, closing)
+			ident := sql[i+1 : max(i+1, end-1)]
+			ident = strings.ReplaceAll(ident, string([]byte{closing, closing}), string(closing))
+			tokens = append(tokens, sqlToken{start: i, end: end, ident: ident, quoted: true})
+			i = end
+		case isIdentStart(c):
+			end := i + 1
+			for end < len(sql) && isIdentPart(sql[end]) {
+				end++
+			}
+			tokens = append(tokens, sqlToken{start: i, end: end, ident: sql[i:end]})
+			i = end
+		case c >= '0' && c <= '9':
+			end := i + 1
+			for end < len(sql) && (isIdentPart(sql[end]) || sql[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, sqlToken{start: i, end: end})
+			i = end
+		default:
+			if c == '(' && len(tokens) > 0 {
+				if prev := &tokens[len(tokens)-1]; prev.ident != "" {
+					prev.call = true
+				}
+			}
+			tokens = append(tokens, sqlToken{start: i, end: i + 1})
+			i++
+		}
+
+		if n := len(tokens); n >= 2 && tokens[n-1].ident != "" && tokens[n-2].isKeyword("COLLATE") {
+			tokens[n-1].collation = true
+		}
+	}
+	return tokens
+}
+
+// quotedEnd returns the position after the closing quote, treating doubled quotes as escaped.
+func quotedEnd(sql string, start int, quote byte) int {
+	for i := start + 1; i < len(sql); i++ {
+		if sql[i] != quote {
+			continue
+		}
+		if quote != ']' && i+1 < len(sql) && sql[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(sql)
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9' || c == '$'
+}
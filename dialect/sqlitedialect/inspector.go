@@ -0,0 +1,480 @@
+package sqlitedialect
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate/sqlschema"
+)
+
+type (
+	Schema = sqlschema.BaseDatabase
+	Table  = sqlschema.BaseTable
+	Column = sqlschema.BaseColumn
+)
+
+func (d *Dialect) NewInspector(db *bun.DB, options ...sqlschema.InspectorOption) sqlschema.Inspector {
+	return newInspector(db, options...)
+}
+
+type Inspector struct {
+	sqlschema.InspectorConfig
+	db bun.IDB
+}
+
+var _ sqlschema.Inspector = (*Inspector)(nil)
+
+func newInspector(db bun.IDB, options ...sqlschema.InspectorOption) *Inspector {
+	i := &Inspector{db: db}
+	i.SchemaName = db.Dialect().DefaultSchema()
+	sqlschema.ApplyInspectorOptions(&i.InspectorConfig, options...)
+	return i
+}
+
+func (in *Inspector) Inspect(ctx context.Context) (sqlschema.Database, error) {
+	dbSchema := Schema{
+		ForeignKeys: make(map[sqlschema.ForeignKey]string),
+	}
+
+	tables, err := in.inspectTables(ctx)
+	if err != nil {
+		return dbSchema, err
+	}
+
+	for _, table := range tables {
+		dbSchema.Tables = append(dbSchema.Tables, table.toBaseTable(in.SchemaName))
+
+		for _, fk := range table.ForeignKeys {
+			dbFK := sqlschema.ForeignKey{
+				From: sqlschema.NewColumnReference(table.Name, fk.Columns...),
+				To:   sqlschema.NewColumnReference(fk.TargetTable, fk.TargetColumns...),
+			}
+			if _, exclude := in.ExcludeForeignKeys[dbFK]; exclude {
+				continue
+			}
+			if in.isExcluded(fk.TargetTable) {
+				continue
+			}
+			// SQLite does not store names for FOREIGN KEY constraints.
+			dbSchema.ForeignKeys[dbFK] = ""
+		}
+	}
+	return dbSchema, nil
+}
+
+// inspectTables reads complete definitions of all user tables in the inspected schema.
+func (in *Inspector) inspectTables(ctx context.Context) ([]*tableDefinition, error) {
+	var masters []*sqliteMaster
+	if err := in.db.NewRaw(sqlInspectTables, bun.Ident(in.SchemaName)).Scan(ctx, &masters); err != nil {
+		return nil, err
+	}
+
+	var tables []*tableDefinition
+	for _, master := range masters {
+		if in.isExcluded(master.Name) {
+			continue
+		}
+		table, err := in.inspectTable(ctx, master)
+		if err != nil {
+			return nil, fmt.Errorf("inspect table %q: %w", master.Name, err)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// isExcluded checks the table name against the ExcludeTables patterns
+// using the same semantics as the SQL LIKE operator.
+func (in *Inspector) isExcluded(tableName string) bool {
+	for _, pattern := range in.ExcludeTables {
+		if matchLike(pattern, tableName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (in *Inspector) inspectTable(ctx context.Context, master *sqliteMaster) (*tableDefinition, error) {
+	table := &tableDefinition{
+		Name:          master.Name,
+		AutoIncrement: hasAutoIncrement(master.SQL),
+		Checks:        parseChecks(master.SQL),
+	}
+
+	var columns []*tableInfo
+	if err := in.db.NewRaw(sqlInspectColumns, master.Name, in.SchemaName).Scan(ctx, &columns); err != nil {
+		return nil, err
+	}
+
+	generated := parseGenerated(master.SQL)
+
+	var pks []*tableInfo
+	for _, c := range columns {
+		col := &columnDefinition{
+			Name:    c.Name,
+			Type:    c.Type,
+			NotNull: c.NotNull,
+			Default: c.Default,
+		}
+		switch c.Hidden {
+		case hiddenVirtual:
+			col.Generated, col.Virtual = generated[c.Name], true
+		case hiddenStored:
+			col.Generated = generated[c.Name]
+		}
+		table.Columns = append(table.Columns, col)
+		if c.PK > 0 {
+			pks = append(pks, c)
+		}
+	}
+	slices.SortFunc(pks, func(a, b *tableInfo) int { return a.PK - b.PK })
+	for _, c := range pks {
+		table.PrimaryKey = append(table.PrimaryKey, c.Name)
+	}
+
+	var indexes []*indexList
+	if err := in.db.NewRaw(sqlInspectIndexes, master.Name, in.SchemaName, bun.Ident(in.SchemaName)).Scan(ctx, &indexes); err != nil {
+		return nil, err
+	}
+
+	for _, idx := range indexes {
+		var info []*indexInfo
+		if err := in.db.NewRaw(sqlInspectIndexColumns, idx.Name, in.SchemaName).Scan(ctx, &info); err != nil {
+			return nil, err
+		}
+
+		switch idx.Origin {
+		case "pk":
+			// Primary key has already been collected from table_info.
+		case "u":
+			var columns []string
+			for _, c := range info {
+				columns = append(columns, c.Name)
+			}
+			table.Unique = append(table.Unique, uniqueDefinition{Columns: columns})
+		default:
+			def := &indexDefinition{
+				Name:   idx.Name,
+				Unique: idx.Unique,
+				SQL:    idx.SQL,
+			}
+			plain := !idx.Partial
+			for _, c := range info {
+				if c.CID < 0 {
+					plain = false
+					break
+				}
+				def.Columns = append(def.Columns, c.Name)
+			}
+			if !plain {
+				def.Columns = nil
+			}
+			table.Indexes = append(table.Indexes, def)
+		}
+	}
+
+	var fks []*foreignKeyList
+	if err := in.db.NewRaw(sqlInspectForeignKeys, master.Name, in.SchemaName).Scan(ctx, &fks); err != nil {
+		return nil, err
+	}
+
+	for _, fk := range fks {
+		if n := len(table.ForeignKeys); n == 0 || table.ForeignKeys[n-1].ID != fk.ID {
+			table.ForeignKeys = append(table.ForeignKeys, &foreignKeyDefinition{
+				ID:          fk.ID,
+				TargetTable: fk.Table,
+				OnUpdate:    fk.OnUpdate,
+				OnDelete:    fk.OnDelete,
+			})
+		}
+		def := table.ForeignKeys[len(table.ForeignKeys)-1]
+		def.Columns = append(def.Columns, fk.From)
+		def.TargetColumns = append(def.TargetColumns, fk.To)
+	}
+
+	// Foreign keys which reference the primary key implicitly, e.g. REFERENCES "parent",
+	// have NULL target columns. Resolve them to the parent's primary key.
+	for _, fk := range table.ForeignKeys {
+		if !slices.Contains(fk.TargetColumns, "") {
+			continue
+		}
+		var parent []*tableInfo
+		if err := in.db.NewRaw(sqlInspectColumns, fk.TargetTable, in.SchemaName).Scan(ctx, &parent); err != nil {
+			return nil, err
+		}
+		slices.SortFunc(parent, func(a, b *tableInfo) int { return a.PK - b.PK })
+		fk.TargetColumns = fk.TargetColumns[:0]
+		for _, c := range parent {
+			if c.PK > 0 {
+				fk.TargetColumns = append(fk.TargetColumns, c.Name)
+			}
+		}
+	}
+
+	return table, nil
+}
+
+// tableDefinition holds all information about an SQLite table
+// which is necessary to re-create it.
+type tableDefinition struct {
+	Name          string
+	Columns       []*columnDefinition
+	PrimaryKey    []string
+	AutoIncrement bool
+	Unique        []uniqueDefinition
+	ForeignKeys   []*foreignKeyDefinition
+	Checks        []checkDefinition
+	Indexes       []*indexDefinition
+}
+
+type columnDefinition struct {
+	Name      string
+	Type      string // declared type, e.g. VARCHAR(100)
+	NotNull   bool
+	Default   string // default value as SQL expression, e.g. 'john doe'
+	Generated string // expression of a generated column
+	Virtual   bool   // generated column is not stored
+}
+
+type uniqueDefinition struct {
+	Name    string
+	Columns []string
+}
+
+type foreignKeyDefinition struct {
+	ID            int
+	Columns       []string
+	TargetTable   string
+	TargetColumns []string
+	OnUpdate      string
+	OnDelete      string
+}
+
+// indexDefinition describes an index that was created with CREATE INDEX.
+// Columns are only set for indexes on plain columns, which can be re-created
+// from the definition. Other indexes are re-created using the original SQL.
+type indexDefinition struct {
+	Name    string
+	Unique  bool
+	Columns []string
+	SQL     string
+}
+
+func (t *tableDefinition) column(name string) *columnDefinition {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// isRowID reports whether the column is an alias for the ROWID.
+func (t *tableDefinition) isRowID(c *columnDefinition) bool {
+	return len(t.PrimaryKey) == 1 && t.PrimaryKey[0] == c.Name && strings.EqualFold(c.Type, "INTEGER")
+}
+
+func (t *tableDefinition) toBaseTable(schemaName string) *Table {
+	table := &Table{
+		Schema: schemaName,
+		Name:   t.Name,
+	}
+
+	for _, c := range t.Columns {
+		sqlType, length := parseType(c.Type)
+		def, isLiteral := parseDefault(c.Default)
+		if !isLiteral {
+			def = strings.ToLower(def)
+		}
+
+		table.Columns = append(table.Columns, &Column{
+			Name:            c.Name,
+			SQLType:         strings.ToLower(sqlType),
+			VarcharLen:      length,
+			DefaultValue:    def,
+			IsNullable:      !c.NotNull && !slices.Contains(t.PrimaryKey, c.Name),
+			IsAutoIncrement: t.AutoIncrement && t.isRowID(c),
+			IsGenerated:     c.Generated != "",
+			GeneratedExpr:   c.Generated,
+			IsVirtual:       c.Virtual,
+		})
+	}
+
+	if len(t.PrimaryKey) > 0 {
+		table.PrimaryKey = &sqlschema.PrimaryKey{
+			Columns: sqlschema.NewColumns(t.PrimaryKey...),
+		}
+	}
+
+	for _, u := range t.Unique {
+		table.UniqueConstraints = append(table.UniqueConstraints, sqlschema.Unique{
+			Name:    u.Name,
+			Columns: sqlschema.NewColumns(u.Columns...),
+		})
+	}
+
+	for _, check := range t.Checks {
+		table.CheckConstraints = append(table.CheckConstraints, check.toCheck())
+	}
+
+	for _, idx := range t.Indexes {
+		table.Indexes = append(table.Indexes, idx.toIndex())
+	}
+	return table
+}
+
+// parseType splits the declared type into the type name and its length, e.g. VARCHAR(100) -> VARCHAR, 100.
+// Types with several modifiers, like DECIMAL(10,2), are returned unchanged.
+func parseType(typ string) (string, int) {
+	paren := strings.IndexByte(typ, '(')
+	if paren == -1 || !strings.HasSuffix(typ, ")") {
+		return typ, 0
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(typ[paren+1 : len(typ)-1]))
+	if err != nil {
+		return typ, 0
+	}
+	return strings.TrimSpace(typ[:paren]), length
+}
+
+// parseDefault trims the quotes from a string literal and reports if it was one.
+// Parentheses around constant expressions, e.g. DEFAULT (1), are trimmed too.
+func parseDefault(s string) (string, bool) {
+	if len(s) >= 2 && s[0] == '(' && s[len(s)-1] == ')' && !strings.ContainsAny(s[1:len(s)-1], "()") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return s[1 : len(s)-1], true
+	}
+	return s, false
+}
+
+// hasAutoIncrement checks if the CREATE TABLE statement contains the AUTOINCREMENT keyword.
+func hasAutoIncrement(createTable string) bool {
+	return strings.Contains(strings.ToUpper(createTable), "AUTOINCREMENT")
+}
+
+// matchLike reports whether s matches the pattern in the same way SQLite's LIKE operator would:
+// % matches any sequence of characters, _ matches any single character and the match is case-insensitive.
+func matchLike(pattern, s string) bool {
+	p, str := []rune(strings.ToLower(pattern)), []rune(strings.ToLower(s))
+
+	var match func(i, j int) bool
+	match = func(i, j int) bool {
+		for ; i < len(p); i++ {
+			switch p[i] {
+			case '%':
+				for k := j; k <= len(str); k++ {
+					if match(i+1, k) {
+						return true
+					}
+				}
+				return false
+			case '_':
+				if j >= len(str) {
+					return false
+				}
+			default:
+				if j >= len(str) || str[j] != p[i] {
+					return false
+				}
+			}
+			j++
+		}
+		return j == len(str)
+	}
+	return match(0, 0)
+}
+
+type sqliteMaster struct {
+	Name string `bun:"name"`
+	SQL  string `bun:"sql"`
+}
+
+type tableInfo struct {
+	CID     int    `bun:"cid"`
+	Name    string `bun:"name"`
+	Type    string `bun:"type"`
+	NotNull bool   `bun:"column:notnull"`
+	Default string `bun:"dflt_value"`
+	PK      int    `bun:"pk"`
+	Hidden  int    `bun:"hidden"`
+}
+
+// Values of the "hidden" column in PRAGMA table_xinfo for generated columns.
+const (
+	hiddenVirtual = 2
+	hiddenStored  = 3
+)
+
+type indexList struct {
+	Name    string `bun:"name"`
+	Unique  bool   `bun:"column:unique"`
+	Origin  string `bun:"origin"`
+	Partial bool   `bun:"partial"`
+	SQL     string `bun:"sql"`
+}
+
+type indexInfo struct {
+	CID  int    `bun:"cid"`
+	Name string `bun:"name"`
+}
+
+type foreignKeyList struct {
+	ID       int    `bun:"id"`
+	Seq      int    `bun:"seq"`
+	Table    string `bun:"table"`
+	From     string `bun:"from"`
+	To       string `bun:"to"`
+	OnUpdate string `bun:"on_update"`
+	OnDelete string `bun:"on_delete"`
+}
+
+const (
+	// sqlInspectTables retrieves all user-defined tables in the selected schema.
+	sqlInspectTables = `
+SELECT "name", "sql"
+FROM ?.sqlite_master
+WHERE "type" = 'table'
+	AND "name" NOT LIKE 'sqlite_%'
+ORDER BY "name"
+`
+
+	// sqlInspectColumns retrieves column definitions for the table, including generated columns.
+	// It should be passed the table name and the schema name.
+	sqlInspectColumns = `
+SELECT "cid", "name", "type", "notnull", COALESCE("dflt_value", '') AS "dflt_value", "pk", "hidden"
+FROM pragma_table_xinfo(?, ?)
+ORDER BY "cid"
+`
+
+	// sqlInspectIndexes retrieves all indexes defined on the table, including those
+	// which back PRIMARY KEY and UNIQUE constraints.
+	// It should be passed the table name, the schema name and the schema identifier.
+	sqlInspectIndexes = `
+SELECT "il"."name", "il"."unique", "il"."origin", "il"."partial", COALESCE("m"."sql", '') AS "sql"
+FROM pragma_index_list(?, ?) AS "il"
+	LEFT JOIN ?.sqlite_master AS "m" ON "m"."type" = 'index' AND "m"."name" = "il"."name"
+ORDER BY "il"."seq"
+`
+
+	// sqlInspectIndexColumns retrieves the columns of the index in the order they are indexed.
+	// It should be passed the index name and the schema name.
+	sqlInspectIndexColumns = `
+SELECT "cid", COALESCE("name", '') AS "name"
+FROM pragma_index_info(?, ?)
+ORDER BY "seqno"
+`
+
+	// sqlInspectForeignKeys retrieves FOREIGN KEY constraints defined on the table.
+	// It should be passed the table name and the schema name.
+	sqlInspectForeignKeys = `
+SELECT "id", "seq", "table", "from", COALESCE("to", '') AS "to", "on_update", "on_delete"
+FROM pragma_foreign_key_list(?, ?)
+ORDER BY "id", "seq"
+`
+)
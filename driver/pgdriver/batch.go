@@ -0,0 +1,364 @@
+package pgdriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/uptrace/bun"
+)
+
+// Batch sends queries to the server together over a single connection, so they take
+// one round trip instead of one round trip per query.
+//
+// The queries are sent in pipeline mode using the extended protocol: each query is
+// followed by Sync, so it runs in its own implicit transaction and a failed query
+// does not affect the other queries. The results are scanned into the query model
+// or the dest passed to Queue:
+//
+//	batch := pgdriver.NewBatch(db)
+//	insert := batch.Queue(db.NewInsert().Model(user))
+//	sel := batch.Queue(db.NewSelect().Model(&users).Limit(10))
+//	if err := batch.Run(ctx); err != nil {
+//		// insert.Err and sel.Err report the error of each query.
+//	}
+//
+// Queries are formatted with their arguments inlined and results are returned
+// in the text format. Unlike executing the queries directly, Batch does not call
+// the model and query hooks and does not load relations which require separate queries.
+type Batch struct {
+	db      *bun.DB
+	queries []*BatchQuery
+}
+
+// BatchQuery is a query queued in a Batch. Result and Err are set by Batch.Run.
+type BatchQuery struct {
+	query bun.Query
+	dest  []any
+
+	// Result is the number of rows changed or returned by the query.
+	Result sql.Result
+	// Err is the error returned by the query.
+	Err error
+}
+
+// NewBatch creates a batch which runs the queries on a connection of db.
+func NewBatch(db *bun.DB) *Batch {
+	return &Batch{db: db}
+}
+
+// Queue adds the query to the batch. The rows returned by the query are scanned into
+// dest or, without dest, into the query model.
+func (b *Batch) Queue(q bun.Query, dest ...any) *BatchQuery {
+	bq := &BatchQuery{
+		query: q,
+		dest:  dest,
+	}
+	b.queries = append(b.queries, bq)
+	return bq
+}
+
+// Len returns the number of queued queries.
+func (b *Batch) Len() int {
+	return len(b.queries)
+}
+
+// Run sends the queued queries and scans their results. It returns the first error
+// of the queries; the error of each query is reported in BatchQuery.Err.
+func (b *Batch) Run(ctx context.Context) error {
+	queries := make([]string, 0, len(b.queries))
+	sent := make([]*BatchQuery, 0, len(b.queries))
+	for _, bq := range b.queries {
+		bq.Result, bq.Err = nil, nil
+
+		query, err := bq.query.AppendQuery(b.db.QueryGen(), nil)
+		if err != nil {
+			bq.Err = err
+			continue
+		}
+		queries = append(queries, string(query))
+		sent = append(sent, bq)
+	}
+
+	if len(sent) > 0 {
+		if err := b.run(ctx, queries, sent); err != nil {
+			for _, bq := range sent {
+				bq.Err = err
+			}
+		}
+	}
+
+	for _, bq := range b.queries {
+		if bq.Err != nil {
+			return bq.Err
+		}
+	}
+	return nil
+}
+
+func (b *Batch) run(ctx context.Context, queries []string, sent []*BatchQuery) error {
+	conn, err := b.db.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var p *pipeline
+	if err := conn.Raw(func(driverConn any) error {
+		cn, ok := driverConn.(*Conn)
+		if !ok {
+			return fmt.Errorf("pgdriver: Batch requires pgdriver, got %T", driverConn)
+		}
+		p, err = cn.startPipeline(ctx, queries)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	// The connection returns the results in order while the pipeline is in progress.
+	for i, bq := range sent {
+		rows, err := conn.QueryContext(ctx, queries[i])
+		if err != nil {
+			bq.Err = err
+			continue
+		}
+
+		if err := b.scan(ctx, bq, rows); err != nil {
+			bq.Err = err
+			continue
+		}
+
+		// The command tag has the number of rows returned by the query.
+		affected := p.affected[i]
+		if affected == 0 && wantsRow(bq) {
+			bq.Err = sql.ErrNoRows
+			continue
+		}
+		bq.Result = affected
+	}
+	return nil
+}
+
+// scan scans the rows into dest or the query model, or discards them.
+func (b *Batch) scan(ctx context.Context, bq *BatchQuery, rows *sql.Rows) error {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	if len(columns) > 0 {
+		if len(bq.dest) > 0 {
+			return b.db.ScanRows(ctx, rows, bq.dest...)
+		}
+		if model := bq.query.GetModel(); model != nil {
+			if _, err := model.ScanRows(ctx, rows); err != nil {
+				return err
+			}
+			return rows.Err()
+		}
+	}
+
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+// wantsRow reports whether the query fails with sql.ErrNoRows without rows,
+// like SelectQuery.Scan and scanning into dest.
+func wantsRow(bq *BatchQuery) bool {
+	if len(bq.dest) > 0 {
+		return isSingleRow(bq.dest)
+	}
+	return bq.query.Operation() == "SELECT" && isSingleRowModel(bq.query.GetModel())
+}
+
+func isSingleRow(dest []any) bool {
+	if len(dest) > 1 {
+		return true
+	}
+	return !isSliceValue(dest[0])
+}
+
+func isSingleRowModel(model bun.Model) bool {
+	return model != nil && !isSliceValue(model.Value())
+}
+
+func isSliceValue(v any) bool {
+	typ := reflect.TypeOf(v)
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ != nil && typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8
+}
+
+//------------------------------------------------------------------------------
+
+// pipeline tracks the results of the queries sent in pipeline mode which are not read yet.
+type pipeline struct {
+	affected []driver.RowsAffected
+	next     int
+	written  chan error
+}
+
+// startPipeline sends the queries, each followed by Sync. The queries are written
+// in the background, because the server may block on sending the results
+// of a large pipeline until they are read.
+func (cn *Conn) startPipeline(ctx context.Context, queries []string) (*pipeline, error) {
+	if cn.pipeline != nil {
+		return nil, errors.New("pgdriver: pipeline is already in progress")
+	}
+
+	wb := getWriteBuffer()
+	for _, query := range queries {
+		wb.StartMessage(parseMsg)
+		wb.WriteString("")
+		wb.WriteString(query)
+		wb.WriteInt16(0)
+		wb.FinishMessage()
+
+		wb.StartMessage(bindMsg)
+		wb.WriteString("")
+		wb.WriteString("")
+		wb.WriteInt16(0)
+		wb.WriteInt16(0)
+		wb.WriteInt16(0)
+		wb.FinishMessage()
+
+		wb.StartMessage(describeMsg)
+		wb.WriteByte('P')
+		wb.WriteString("")
+		wb.FinishMessage()
+
+		wb.StartMessage(executeMsg)
+		wb.WriteString("")
+		wb.WriteInt32(0)
+		wb.FinishMessage()
+
+		wb.StartMessage(syncMsg)
+		wb.FinishMessage()
+	}
+
+	p := &pipeline{
+		affected: make([]driver.RowsAffected, len(queries)),
+		written:  make(chan error, 1),
+	}
+	cn.pipeline = p
+
+	go func() {
+		defer putWriteBuffer(wb)
+
+		err := cn.write(ctx, wb)
+		if err != nil {
+			// Unblock the reader, because the server waits for the rest of the pipeline.
+			_ = cn.Close()
+		}
+		p.written <- err
+	}()
+
+	return p, nil
+}
+
+// readPipelineResult reads the result of the next query in the pipeline.
+func (cn *Conn) readPipelineResult(ctx context.Context) (*rows, error) {
+	p := cn.pipeline
+	rd := cn.reader(ctx, -1)
+	var firstErr error
+	for {
+		c, msgLen, err := readMessageType(rd)
+		if err != nil {
+			return nil, err
+		}
+
+		switch c {
+		case parseCompleteMsg, bindCompleteMsg, noDataMsg:
+			if err := rd.Discard(msgLen); err != nil {
+				return nil, err
+			}
+		case rowDescriptionMsg: // response to DESCRIBE message.
+			rowDesc, err := readRowDescription(rd)
+			if err != nil {
+				return nil, err
+			}
+			rows := newRows(cn, rowDesc, true)
+			rows.pipeline = p
+			return rows, nil
+		case commandCompleteMsg: // response to EXECUTE message.
+			tmp, err := rd.ReadTemp(msgLen)
+			if err != nil {
+				return nil, err
+			}
+			p.complete(tmp)
+		case readyForQueryMsg: // response to SYNC message.
+			if err := rd.Discard(msgLen); err != nil {
+				return nil, err
+			}
+			if err := p.advance(cn); err != nil {
+				return nil, err
+			}
+			if firstErr != nil {
+				return nil, firstErr
+			}
+			return &rows{closed: true}, nil
+		case errorResponseMsg:
+			e, err := readError(rd)
+			if err != nil {
+				return nil, err
+			}
+			if firstErr == nil {
+				firstErr = e
+			}
+		case emptyQueryResponseMsg:
+			if firstErr == nil {
+				firstErr = errEmptyQuery
+			}
+		case noticeResponseMsg, parameterStatusMsg:
+			if err := rd.Discard(msgLen); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("pgdriver: readPipelineResult: unexpected message %q", c)
+		}
+	}
+}
+
+// execPipelineResult reads the result of the next query in the pipeline and discards the rows.
+func (cn *Conn) execPipelineResult(ctx context.Context) (driver.Result, error) {
+	p := cn.pipeline
+	i := p.next
+
+	rows, err := cn.readPipelineResult(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch err := rows.Next(nil); err {
+		case nil:
+		case io.EOF:
+			return p.affected[i], nil
+		default:
+			return nil, err
+		}
+	}
+}
+
+func (p *pipeline) complete(tag []byte) {
+	if affected, err := parseResult(tag); err == nil {
+		p.affected[p.next] = affected
+	}
+}
+
+// advance moves to the next query after its result is read.
+func (p *pipeline) advance(cn *Conn) error {
+	p.next++
+	if p.next < len(p.affected) {
+		return nil
+	}
+	cn.pipeline = nil
+	return <-p.written
+}
@@ -0,0 +1,159 @@
+package pgdriver
+
+import (
+	"bufio"
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+	ctx := context.Background()
+
+	client, server := net.Pipe()
+	defer server.Close()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- fakePipelineServer(server)
+	}()
+
+	conf := newDefaultConfig()
+	conf.User = "postgres"
+	conf.TLSConfig = nil
+	conf.Dialer = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return client, nil
+	}
+	driverConn, err := NewConnector(WithConfig(conf)).Connect(ctx)
+	require.NoError(t, err)
+	cn := driverConn.(*Conn)
+
+	p, err := cn.startPipeline(ctx, []string{"SELECT 1", "INSERT", "SELECT 2", "INVALID"})
+	require.NoError(t, err)
+	require.False(t, cn.IsValid())
+
+	// The rows of the first query.
+	rows, err := cn.QueryContext(ctx, "SELECT 1", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"n"}, rows.Columns())
+	dest := make([]driver.Value, 1)
+	require.NoError(t, rows.Next(dest))
+	require.Equal(t, int64(1), dest[0])
+	require.Equal(t, io.EOF, rows.Next(dest))
+	require.Equal(t, driver.RowsAffected(1), p.affected[0])
+
+	res, err := cn.ExecContext(ctx, "INSERT", nil)
+	require.NoError(t, err)
+	require.Equal(t, driver.RowsAffected(2), res)
+
+	// The rows which are not read are discarded.
+	rows, err = cn.QueryContext(ctx, "SELECT 2", nil)
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+
+	_, err = cn.QueryContext(ctx, "INVALID", nil)
+	require.Error(t, err)
+	require.Equal(t, "42601", err.(Error).Field('C'))
+
+	require.Nil(t, cn.pipeline)
+	require.True(t, cn.IsValid())
+
+	require.NoError(t, cn.Close())
+	require.NoError(t, <-errc)
+}
+
+// fakePipelineServer accepts the startup and answers a pipeline of 4 queries.
+func fakePipelineServer(conn net.Conn) error {
+	rd := bufio.NewReader(conn)
+	var buf []byte
+	msg := func(c byte, body ...[]byte) {
+		n := 4
+		for _, b := range body {
+			n += len(b)
+		}
+		buf = append(buf, c)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(n))
+		for _, b := range body {
+			buf = append(buf, b...)
+		}
+	}
+	cstr := func(s string) []byte { return append([]byte(s), 0) }
+	be16 := func(n uint16) []byte { return binary.BigEndian.AppendUint16(nil, n) }
+	be32 := func(n uint32) []byte { return binary.BigEndian.AppendUint32(nil, n) }
+	flush := func() error {
+		_, err := conn.Write(buf)
+		buf = buf[:0]
+		return err
+	}
+
+	// Startup message without the type.
+	var size [4]byte
+	if _, err := io.ReadFull(rd, size[:]); err != nil {
+		return err
+	}
+	if _, err := rd.Discard(int(binary.BigEndian.Uint32(size[:])) - 4); err != nil {
+		return err
+	}
+	msg('R', be32(0))
+	msg('Z', []byte{'I'})
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// Read the whole pipeline before answering to check that it is sent at once.
+	for syncs := 0; syncs < 4; {
+		c, err := rd.ReadByte()
+		if err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(rd, size[:]); err != nil {
+			return err
+		}
+		if _, err := rd.Discard(int(binary.BigEndian.Uint32(size[:])) - 4); err != nil {
+			return err
+		}
+		if c == syncMsg {
+			syncs++
+		}
+	}
+
+	rowDesc := func(name string) {
+		msg('T', be16(1), cstr(name), be32(0), be16(0), be32(pgInt4), be16(4), be32(0), be16(0))
+	}
+
+	msg('1')
+	msg('2')
+	rowDesc("n")
+	msg('D', be16(1), be32(1), []byte("1"))
+	msg('C', cstr("SELECT 1"))
+	msg('Z', []byte{'I'})
+
+	msg('1')
+	msg('2')
+	msg('n')
+	msg('C', cstr("INSERT 0 2"))
+	msg('Z', []byte{'I'})
+
+	msg('1')
+	msg('2')
+	rowDesc("n")
+	msg('D', be16(1), be32(1), []byte("2"))
+	msg('D', be16(1), be32(1), []byte("3"))
+	msg('C', cstr("SELECT 2"))
+	msg('Z', []byte{'I'})
+
+	msg('E', []byte{'S'}, cstr("ERROR"), []byte{'C'}, cstr("42601"), []byte{'M'}, cstr("syntax error"), []byte{0})
+	msg('Z', []byte{'I'})
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// Wait until the client closes the connection.
+	_, _ = io.Copy(io.Discard, rd)
+	return nil
+}
@@ -0,0 +1,432 @@
+package pgdriver
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	pgUUID    = 2950
+	pgNumeric = 1700
+
+	pgBoolArray        = 1000
+	pgByteaArray       = 1001
+	pgInt2Array        = 1005
+	pgInt4Array        = 1007
+	pgInt8Array        = 1016
+	pgFloat4Array      = 1021
+	pgFloat8Array      = 1022
+	pgTimestampArray   = 1115
+	pgDateArray        = 1182
+	pgTimestamptzArray = 1185
+	pgNumericArray     = 1231
+	pgUUIDArray        = 2951
+)
+
+type binaryResultsCtxKey struct{}
+
+// ContextWithBinaryResults overrides Config.BinaryResults for the queries which use the context.
+func ContextWithBinaryResults(ctx context.Context, on bool) context.Context {
+	return context.WithValue(ctx, binaryResultsCtxKey{}, on)
+}
+
+func (cn *Conn) binaryResults(ctx context.Context) bool {
+	if on, ok := ctx.Value(binaryResultsCtxKey{}).(bool); ok {
+		return on
+	}
+	return cn.conf.BinaryResults
+}
+
+// binaryColumns reports which columns are requested in the binary format.
+// It returns nil when all columns use the text format.
+func binaryColumns(rowDesc *rowDescription) []bool {
+	if rowDesc == nil {
+		return nil
+	}
+
+	var binary []bool
+	for i, dataType := range rowDesc.types {
+		if !hasBinaryDecoder(dataType) {
+			continue
+		}
+		if binary == nil {
+			binary = make([]bool, len(rowDesc.types))
+		}
+		binary[i] = true
+	}
+	return binary
+}
+
+func hasBinaryDecoder(dataType int32) bool {
+	switch dataType {
+	case pgBool, pgInt2, pgInt4, pgInt8, pgFloat4, pgFloat8,
+		pgTimestamp, pgTimestamptz, pgDate, pgUUID, pgBytea, pgNumeric:
+		return true
+	}
+	_, ok := arrayElemType(dataType)
+	return ok
+}
+
+func arrayElemType(dataType int32) (int32, bool) {
+	switch dataType {
+	case pgBoolArray:
+		return pgBool, true
+	case pgByteaArray:
+		return pgBytea, true
+	case pgInt2Array:
+		return pgInt2, true
+	case pgInt4Array:
+		return pgInt4, true
+	case pgInt8Array:
+		return pgInt8, true
+	case pgFloat4Array:
+		return pgFloat4, true
+	case pgFloat8Array:
+		return pgFloat8, true
+	case pgTimestampArray:
+		return pgTimestamp, true
+	case pgDateArray:
+		return pgDate, true
+	case pgTimestamptzArray:
+		return pgTimestamptz, true
+	case pgNumericArray:
+		return pgNumeric, true
+	case pgUUIDArray:
+		return pgUUID, true
+	}
+	return 0, false
+}
+
+// readBinaryColumnValue decodes the column in the binary format into the same value
+// readColumnValue returns for the text format. Arrays, uuid and numeric are returned
+// as their text representation.
+func readBinaryColumnValue(rd *reader, dataType int32, dataLen int) (any, error) {
+	if dataLen == -1 {
+		return nil, nil
+	}
+
+	b := make([]byte, dataLen)
+	if _, err := io.ReadFull(rd, b); err != nil {
+		return nil, err
+	}
+
+	if elemType, ok := arrayElemType(dataType); ok {
+		return appendBinaryArray(nil, b, elemType)
+	}
+
+	switch dataType {
+	case pgBool:
+		return len(b) == 1 && b[0] == 1, nil
+	case pgInt2, pgInt4, pgInt8:
+		return decodeBinaryInt(b)
+	case pgFloat4, pgFloat8:
+		return decodeBinaryFloat(b)
+	case pgTimestamp, pgTimestamptz:
+		tm, ok, err := decodeBinaryTime(b)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("pgdriver: can't parse time=%q", appendInfinity(nil, b[0]&0x80 != 0))
+		}
+		return tm, nil
+	case pgDate:
+		s, err := appendBinaryDate(nil, b)
+		if err != nil {
+			return nil, err
+		}
+		return string(s), nil
+	case pgBytea:
+		return b, nil
+	case pgUUID:
+		return appendBinaryUUID(nil, b)
+	case pgNumeric:
+		return appendBinaryNumeric(nil, b)
+	}
+
+	return nil, fmt.Errorf("pgdriver: can't decode binary value of type %d", dataType)
+}
+
+func decodeBinaryInt(b []byte) (int64, error) {
+	switch len(b) {
+	case 2:
+		return int64(int16(binary.BigEndian.Uint16(b))), nil
+	case 4:
+		return int64(int32(binary.BigEndian.Uint32(b))), nil
+	case 8:
+		return int64(binary.BigEndian.Uint64(b)), nil
+	}
+	return 0, fmt.Errorf("pgdriver: invalid binary int length: %d", len(b))
+}
+
+func decodeBinaryFloat(b []byte) (float64, error) {
+	switch len(b) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	}
+	return 0, fmt.Errorf("pgdriver: invalid binary float length: %d", len(b))
+}
+
+// pgEpoch is the origin of binary dates and timestamps.
+var pgEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// pgEpochUnix is pgEpoch in seconds since the Unix epoch.
+const pgEpochUnix = 946684800
+
+// decodeBinaryTime decodes the microseconds since pgEpoch.
+// It returns false for infinite timestamps.
+func decodeBinaryTime(b []byte) (time.Time, bool, error) {
+	if len(b) != 8 {
+		return time.Time{}, false, fmt.Errorf("pgdriver: invalid binary timestamp length: %d", len(b))
+	}
+	usec := int64(binary.BigEndian.Uint64(b))
+	if usec == math.MaxInt64 || usec == math.MinInt64 {
+		return time.Time{}, false, nil
+	}
+	// time.Duration overflows for timestamps more than 292 years away from pgEpoch.
+	sec, usec := usec/1e6, usec%1e6
+	return time.Unix(pgEpochUnix+sec, usec*1000).UTC(), true, nil
+}
+
+func appendInfinity(b []byte, negative bool) []byte {
+	if negative {
+		b = append(b, '-')
+	}
+	return append(b, "infinity"...)
+}
+
+func appendBinaryDate(b, src []byte) ([]byte, error) {
+	if len(src) != 4 {
+		return nil, fmt.Errorf("pgdriver: invalid binary date length: %d", len(src))
+	}
+	days := int32(binary.BigEndian.Uint32(src))
+	if days == math.MaxInt32 || days == math.MinInt32 {
+		return appendInfinity(b, days < 0), nil
+	}
+	return pgEpoch.AddDate(0, 0, int(days)).AppendFormat(b, dateFormat), nil
+}
+
+func appendBinaryUUID(b, src []byte) ([]byte, error) {
+	if len(src) != 16 {
+		return nil, fmt.Errorf("pgdriver: invalid binary uuid length: %d", len(src))
+	}
+	for i, part := range [][]byte{src[:4], src[4:6], src[6:8], src[8:10], src[10:]} {
+		if i > 0 {
+			b = append(b, '-')
+		}
+		b = hex.AppendEncode(b, part)
+	}
+	return b, nil
+}
+
+// appendBinaryNumeric appends the text representation of the numeric which is sent
+// as base 10000 digits: ndigits, weight, sign and dscale followed by the digits.
+func appendBinaryNumeric(b, src []byte) ([]byte, error) {
+	if len(src) < 8 {
+		return nil, fmt.Errorf("pgdriver: invalid binary numeric length: %d", len(src))
+	}
+
+	ndigits := int(binary.BigEndian.Uint16(src))
+	weight := int(int16(binary.BigEndian.Uint16(src[2:])))
+	sign := binary.BigEndian.Uint16(src[4:])
+	dscale := int(binary.BigEndian.Uint16(src[6:]))
+	if len(src) != 8+2*ndigits {
+		return nil, fmt.Errorf("pgdriver: invalid binary numeric length: %d", len(src))
+	}
+
+	switch sign {
+	case 0xc000:
+		return append(b, "NaN"...), nil
+	case 0xd000:
+		return append(b, "Infinity"...), nil
+	case 0xf000:
+		return append(b, "-Infinity"...), nil
+	case 0x4000:
+		b = append(b, '-')
+	}
+
+	digit := func(i int) int {
+		if i < 0 || i >= ndigits {
+			return 0
+		}
+		return int(binary.BigEndian.Uint16(src[8+2*i:]))
+	}
+
+	if weight < 0 {
+		b = append(b, '0')
+	}
+	for i := 0; i <= weight; i++ {
+		if i == 0 {
+			b = strconv.AppendInt(b, int64(digit(i)), 10)
+		} else {
+			b = appendDigits(b, digit(i))
+		}
+	}
+
+	if dscale > 0 {
+		b = append(b, '.')
+		start := len(b)
+		for i := weight + 1; len(b)-start < dscale; i++ {
+			b = appendDigits(b, digit(i))
+		}
+		b = b[:start+dscale]
+	}
+
+	return b, nil
+}
+
+// appendDigits appends the base 10000 digit padded with zeros.
+func appendDigits(b []byte, d int) []byte {
+	return append(b, byte('0'+d/1000), byte('0'+d/100%10), byte('0'+d/10%10), byte('0'+d%10))
+}
+
+// appendBinaryArray appends the text representation of the array, e.g. {1,2,NULL},
+// so it is scanned like an array received in the text format.
+func appendBinaryArray(b, src []byte, elemType int32) ([]byte, error) {
+	if len(src) < 12 {
+		return nil, fmt.Errorf("pgdriver: invalid binary array length: %d", len(src))
+	}
+
+	ndim := int(binary.BigEndian.Uint32(src))
+	src = src[12:] // skip ndim, the null flag and the element type
+
+	if ndim == 0 {
+		return append(b, "{}"...), nil
+	}
+	if len(src) < 8*ndim {
+		return nil, fmt.Errorf("pgdriver: invalid binary array dimensions")
+	}
+
+	dims := make([]int, ndim)
+	lbounds := make([]int, ndim)
+	customBounds := false
+	for i := range dims {
+		dims[i] = int(int32(binary.BigEndian.Uint32(src)))
+		lbounds[i] = int(int32(binary.BigEndian.Uint32(src[4:])))
+		if lbounds[i] != 1 {
+			customBounds = true
+		}
+		src = src[8:]
+	}
+
+	if customBounds {
+		// Prefix the array with the dimensions like PostgreSQL does, e.g. [0:1]={1,2}.
+		for i := range dims {
+			b = append(b, '[')
+			b = strconv.AppendInt(b, int64(lbounds[i]), 10)
+			b = append(b, ':')
+			b = strconv.AppendInt(b, int64(lbounds[i]+dims[i]-1), 10)
+			b = append(b, ']')
+		}
+		b = append(b, '=')
+	}
+
+	return appendArrayDim(b, &src, dims, elemType)
+}
+
+func appendArrayDim(b []byte, src *[]byte, dims []int, elemType int32) ([]byte, error) {
+	b = append(b, '{')
+	for i := 0; i < dims[0]; i++ {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		if len(dims) > 1 {
+			var err error
+			b, err = appendArrayDim(b, src, dims[1:], elemType)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if len(*src) < 4 {
+			return nil, fmt.Errorf("pgdriver: invalid binary array element")
+		}
+		n := int(int32(binary.BigEndian.Uint32(*src)))
+		*src = (*src)[4:]
+		if n == -1 {
+			b = append(b, "NULL"...)
+			continue
+		}
+		if len(*src) < n {
+			return nil, fmt.Errorf("pgdriver: invalid binary array element")
+		}
+
+		var err error
+		b, err = appendBinaryArrayElem(b, (*src)[:n], elemType)
+		if err != nil {
+			return nil, err
+		}
+		*src = (*src)[n:]
+	}
+	return append(b, '}'), nil
+}
+
+func appendBinaryArrayElem(b, src []byte, elemType int32) ([]byte, error) {
+	switch elemType {
+	case pgBool:
+		if len(src) == 1 && src[0] == 1 {
+			return append(b, 't'), nil
+		}
+		return append(b, 'f'), nil
+	case pgInt2, pgInt4, pgInt8:
+		n, err := decodeBinaryInt(src)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.AppendInt(b, n, 10), nil
+	case pgFloat4, pgFloat8:
+		f, err := decodeBinaryFloat(src)
+		if err != nil {
+			return nil, err
+		}
+		return appendFloat(b, f, 8*len(src)), nil
+	case pgTimestamp, pgTimestamptz:
+		tm, ok, err := decodeBinaryTime(src)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return appendInfinity(b, src[0]&0x80 != 0), nil
+		}
+		b = append(b, '"')
+		if elemType == pgTimestamptz {
+			b = tm.AppendFormat(b, "2006-01-02 15:04:05.999999-07")
+		} else {
+			b = tm.AppendFormat(b, "2006-01-02 15:04:05.999999")
+		}
+		return append(b, '"'), nil
+	case pgDate:
+		return appendBinaryDate(b, src)
+	case pgUUID:
+		return appendBinaryUUID(b, src)
+	case pgNumeric:
+		return appendBinaryNumeric(b, src)
+	case pgBytea:
+		b = append(b, `"\\x`...)
+		b = hex.AppendEncode(b, src)
+		return append(b, '"'), nil
+	}
+	return nil, fmt.Errorf("pgdriver: can't decode binary array element of type %d", elemType)
+}
+
+func appendFloat(b []byte, f float64, bitSize int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(b, "NaN"...)
+	case math.IsInf(f, 1):
+		return append(b, "Infinity"...)
+	case math.IsInf(f, -1):
+		return append(b, "-Infinity"...)
+	}
+	return strconv.AppendFloat(b, f, 'g', -1, bitSize)
+}
@@ -0,0 +1,133 @@
+package pgdriver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBinaryColumnValue(t *testing.T) {
+	type Test struct {
+		dataType int32
+		text     string
+		binary   []byte
+	}
+
+	be16 := func(n uint16) []byte { return binary.BigEndian.AppendUint16(nil, n) }
+	be32 := func(n uint32) []byte { return binary.BigEndian.AppendUint32(nil, n) }
+	be64 := func(n uint64) []byte { return binary.BigEndian.AppendUint64(nil, n) }
+	concat := func(bs ...[]byte) []byte { return bytes.Join(bs, nil) }
+	// numeric builds the binary numeric from ndigits, weight, sign, dscale and digits.
+	numeric := func(weight int16, sign, dscale uint16, digits ...uint16) []byte {
+		b := concat(be16(uint16(len(digits))), be16(uint16(weight)), be16(sign), be16(dscale))
+		for _, d := range digits {
+			b = append(b, be16(d)...)
+		}
+		return b
+	}
+	usec := func(tm time.Time) []byte {
+		return be64(uint64((tm.Unix()-pgEpochUnix)*1e6 + int64(tm.Nanosecond()/1e3)))
+	}
+	elem := func(b []byte) []byte { return concat(be32(uint32(len(b))), b) }
+
+	tests := []Test{
+		{pgBool, "t", []byte{1}},
+		{pgBool, "f", []byte{0}},
+		{pgInt2, "-12", be16(uint16(0xfff4))},
+		{pgInt4, "123456", be32(123456)},
+		{pgInt8, "-9223372036854775808", be64(1 << 63)},
+		{pgFloat4, "1.5", be32(math.Float32bits(1.5))},
+		{pgFloat8, "0.1", be64(math.Float64bits(0.1))},
+		{pgTimestamp, "2021-03-04 05:06:07.123456", usec(time.Date(2021, 3, 4, 5, 6, 7, 123456000, time.UTC))},
+		{pgTimestamp, "1999-12-31 23:59:59.5", usec(time.Date(1999, 12, 31, 23, 59, 59, 5e8, time.UTC))},
+		{pgTimestamptz, "2021-03-04 05:06:07+00", usec(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC))},
+		{pgTimestamp, "0001-01-01 00:00:00", usec(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC))},
+		{pgTimestamp, "2500-01-01 00:00:00.000001", usec(time.Date(2500, 1, 1, 0, 0, 0, 1000, time.UTC))},
+		{pgDate, "2021-03-04", be32(uint32(7733))},
+		{pgDate, "infinity", be32(math.MaxInt32)},
+		{pgBytea, `\x0102ff`, []byte{1, 2, 0xff}},
+		{
+			pgUUID, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+			[]byte{0xa0, 0xee, 0xbc, 0x99, 0x9c, 0x0b, 0x4e, 0xf8, 0xbb, 0x6d, 0x6b, 0xb9, 0xbd, 0x38, 0x0a, 0x11},
+		},
+		{pgNumeric, "0", numeric(0, 0, 0)},
+		{pgNumeric, "12345.678", numeric(1, 0, 3, 1, 2345, 6780)},
+		{pgNumeric, "-0.0012", numeric(-1, 0x4000, 4, 12)},
+		{pgNumeric, "100000000.00", numeric(2, 0, 2, 1)},
+		{pgNumeric, "NaN", numeric(0, 0xc000, 0)},
+		{pgInt8Array, "{}", concat(be32(0), be32(0), be32(pgInt8))},
+		{
+			pgInt4Array, "{1,NULL,3}",
+			concat(be32(1), be32(1), be32(pgInt4), be32(3), be32(1),
+				elem(be32(1)), be32(0xffffffff), elem(be32(3))),
+		},
+		{
+			pgInt2Array, "{{1,2},{3,4}}",
+			concat(be32(2), be32(0), be32(pgInt2), be32(2), be32(1), be32(2), be32(1),
+				elem(be16(1)), elem(be16(2)), elem(be16(3)), elem(be16(4))),
+		},
+		{
+			pgInt4Array, "[0:1]={1,2}",
+			concat(be32(1), be32(0), be32(pgInt4), be32(2), be32(0), elem(be32(1)), elem(be32(2))),
+		},
+		{
+			pgFloat8Array, "{1.5,NaN,-Infinity}",
+			concat(be32(1), be32(0), be32(pgFloat8), be32(3), be32(1),
+				elem(be64(math.Float64bits(1.5))), elem(be64(math.Float64bits(math.NaN()))),
+				elem(be64(math.Float64bits(math.Inf(-1))))),
+		},
+		{
+			pgBoolArray, "{t,f}",
+			concat(be32(1), be32(0), be32(pgBool), be32(2), be32(1), elem([]byte{1}), elem([]byte{0})),
+		},
+		{
+			pgTimestampArray, `{"2021-03-04 05:06:07"}`,
+			concat(be32(1), be32(0), be32(pgTimestamp), be32(1), be32(1),
+				elem(usec(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)))),
+		},
+		{
+			pgByteaArray, `{"\\x01ff"}`,
+			concat(be32(1), be32(0), be32(pgBytea), be32(1), be32(1), elem([]byte{1, 0xff})),
+		},
+		{
+			pgNumericArray, "{1.5}",
+			concat(be32(1), be32(0), be32(pgNumeric), be32(1), be32(1), elem(numeric(0, 0, 1, 1, 5000))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			require.True(t, hasBinaryDecoder(test.dataType))
+
+			want, err := readColumnValue(newReader(bytes.NewReader([]byte(test.text)), 4096),
+				test.dataType, len(test.text))
+			require.NoError(t, err)
+
+			got, err := readBinaryColumnValue(newReader(bytes.NewReader(test.binary), 4096),
+				test.dataType, len(test.binary))
+			require.NoError(t, err)
+
+			if wantTime, ok := want.(time.Time); ok {
+				require.True(t, wantTime.Equal(got.(time.Time)), "got %s, wanted %s", got, want)
+				return
+			}
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestBinaryColumns(t *testing.T) {
+	rowDesc := newRowDescription(3)
+	rowDesc.addType(pgText)
+	rowDesc.addType(pgInt8)
+	rowDesc.addType(pgUUIDArray)
+	require.Equal(t, []bool{false, true, true}, binaryColumns(rowDesc))
+
+	rowDesc.reset(1)
+	rowDesc.addType(pgVarchar)
+	require.Nil(t, binaryColumns(rowDesc))
+}
@@ -20,6 +20,16 @@ type Config struct {
 	Network string
 	// TCP host:port or Unix socket depending on Network.
 	Addr string
+	// Addrs are the hosts tried in order until one accepts the connection and
+	// matches TargetSessionAttrs. Addr is used when Addrs is empty.
+	Addrs []string
+	// TargetSessionAttrs is the kind of server to connect to: TargetSessionAny,
+	// TargetSessionReadWrite, TargetSessionReadOnly, TargetSessionPrimary,
+	// TargetSessionStandby or TargetSessionPreferStandby. Default is any.
+	TargetSessionAttrs string
+	// LoadBalanceHosts makes connections try Addrs in random order
+	// to spread them across the hosts.
+	LoadBalanceHosts bool
 	// Dial timeout for establishing new connections.
 	// Default is 5 seconds.
 	DialTimeout time.Duration
@@ -29,6 +39,10 @@ type Config struct {
 
 	// TLS config for secure connections.
 	TLSConfig *tls.Config
+	// ChannelBinding controls SCRAM-SHA-256-PLUS channel binding on TLS connections:
+	// ChannelBindingDisable, ChannelBindingPrefer or ChannelBindingRequire.
+	// Default is prefer.
+	ChannelBinding string
 
 	User     string
 	Password string
@@ -54,8 +68,49 @@ type Config struct {
 
 	// Allow set standard_conforming_strings=off or client_encoding=other character sets
 	UnsafeStrings bool
+
+	// BinaryResults requests query results in the binary format for the common types
+	// which are decoded without parsing text, e.g. integers, floats, timestamps and bytea.
+	// The values are the same as with the text format, except that timestamptz values
+	// are returned in UTC. Queries use the extended protocol and are limited to one
+	// statement. Use ContextWithBinaryResults to override it per query.
+	BinaryResults bool
+
+	// Replication starts the connection in the replication mode. Only ReplicationDatabase
+	// is supported, which connects to the database for logical replication.
+	// Use NewReplicator instead of setting it on the DB connections.
+	Replication string
 }
 
+const (
+	// ChannelBindingDisable never uses channel binding.
+	ChannelBindingDisable = "disable"
+	// ChannelBindingPrefer uses channel binding if the connection uses TLS
+	// and the server offers it.
+	ChannelBindingPrefer = "prefer"
+	// ChannelBindingRequire fails the connection unless the server is authenticated
+	// with channel binding.
+	ChannelBindingRequire = "require"
+)
+
+// ReplicationDatabase connects to the database in the logical replication mode.
+const ReplicationDatabase = "database"
+
+const (
+	// TargetSessionAny accepts any server.
+	TargetSessionAny = "any"
+	// TargetSessionReadWrite accepts servers where transactions are read-write by default.
+	TargetSessionReadWrite = "read-write"
+	// TargetSessionReadOnly accepts servers where transactions are read-only by default.
+	TargetSessionReadOnly = "read-only"
+	// TargetSessionPrimary accepts servers which are not in hot standby mode.
+	TargetSessionPrimary = "primary"
+	// TargetSessionStandby accepts servers in hot standby mode.
+	TargetSessionStandby = "standby"
+	// TargetSessionPreferStandby tries standby servers first and then any server.
+	TargetSessionPreferStandby = "prefer-standby"
+)
+
 func newDefaultConfig() *Config {
 	host := env("PGHOST", "localhost")
 	port := env("PGPORT", "5432")
@@ -113,12 +168,51 @@ func WithNetwork(network string) Option {
 	}
 }
 
+// WithAddr sets the host to connect to, replacing the hosts set with WithAddrs.
 func WithAddr(addr string) Option {
 	if addr == "" {
 		panic("addr is empty")
 	}
 	return func(conf *Config) {
 		conf.Addr = addr
+		conf.Addrs = nil
+	}
+}
+
+// WithAddrs sets the hosts which are tried in order, see Config.Addrs.
+func WithAddrs(addrs ...string) Option {
+	if len(addrs) == 0 {
+		panic("addrs are empty")
+	}
+	return func(conf *Config) {
+		conf.Addr = addrs[0]
+		conf.Addrs = addrs
+	}
+}
+
+// WithTargetSessionAttrs sets the kind of server to connect to, see Config.TargetSessionAttrs.
+func WithTargetSessionAttrs(attrs string) Option {
+	if !isTargetSessionAttrs(attrs) {
+		panic(fmt.Errorf("pgdriver: target_session_attrs %q is not supported", attrs))
+	}
+	return func(conf *Config) {
+		conf.TargetSessionAttrs = attrs
+	}
+}
+
+func isTargetSessionAttrs(attrs string) bool {
+	switch attrs {
+	case TargetSessionAny, TargetSessionReadWrite, TargetSessionReadOnly,
+		TargetSessionPrimary, TargetSessionStandby, TargetSessionPreferStandby:
+		return true
+	}
+	return false
+}
+
+// WithLoadBalanceHosts enables trying the hosts in random order, see Config.LoadBalanceHosts.
+func WithLoadBalanceHosts(on bool) Option {
+	return func(conf *Config) {
+		conf.LoadBalanceHosts = on
 	}
 }
 
@@ -128,6 +222,18 @@ func WithTLSConfig(tlsConfig *tls.Config) Option {
 	}
 }
 
+// WithChannelBinding sets the channel binding mode, see Config.ChannelBinding.
+func WithChannelBinding(mode string) Option {
+	switch mode {
+	case ChannelBindingDisable, ChannelBindingPrefer, ChannelBindingRequire:
+	default:
+		panic(fmt.Errorf("pgdriver: channel_binding %q is not supported", mode))
+	}
+	return func(conf *Config) {
+		conf.ChannelBinding = mode
+	}
+}
+
 func WithInsecure(on bool) Option {
 	return func(conf *Config) {
 		if on {
@@ -239,6 +345,23 @@ func WithUnsafeStrings(allow bool) Option {
 	}
 }
 
+// WithBinaryResults enables binary result format, see Config.BinaryResults.
+func WithBinaryResults(on bool) Option {
+	return func(conf *Config) {
+		conf.BinaryResults = on
+	}
+}
+
+// WithReplication sets the replication mode, see Config.Replication.
+func WithReplication(mode string) Option {
+	if mode != "" && mode != ReplicationDatabase {
+		panic(fmt.Errorf("pgdriver: replication %q is not supported", mode))
+	}
+	return func(conf *Config) {
+		conf.Replication = mode
+	}
+}
+
 func env(key, defValue string) string {
 	if s := os.Getenv(key); s != "" {
 		return s
@@ -249,6 +372,7 @@ func env(key, defValue string) string {
 //------------------------------------------------------------------------------
 
 func parseDSN(dsn string) ([]Option, error) {
+	dsn, multiHost := cutMultiHost(dsn)
 	u, err := url.Parse(dsn)
 	if err != nil {
 		return nil, err
@@ -257,24 +381,42 @@ func parseDSN(dsn string) ([]Option, error) {
 	q := queryOptions{q: u.Query()}
 	var opts []Option
 
+	var serverName string
 	switch u.Scheme {
 	case "postgres", "postgresql":
-		if u.Host != "" {
-			addr := u.Host
-			if !strings.Contains(addr, ":") {
-				addr += ":5432"
+		hosts := u.Host
+		if multiHost != "" {
+			hosts = multiHost
+		}
+		if host := q.string("host"); host != "" {
+			hosts = host
+		}
+
+		if hosts != "" {
+			addrs, err := parseHosts(hosts, q.string("port"))
+			if err != nil {
+				return nil, err
+			}
+
+			if len(addrs) == 1 {
+				opts = append(opts, WithAddr(addrs[0]))
+			} else {
+				opts = append(opts, WithAddrs(addrs...))
+			}
+			if allUnixSockets(addrs) {
+				opts = append(opts, WithNetwork("unix"))
 			}
-			opts = append(opts, WithAddr(addr))
 		}
 
 		if len(u.Path) > 1 {
 			opts = append(opts, WithDatabase(u.Path[1:]))
 		}
 
-		if host := q.string("host"); host != "" {
-			opts = append(opts, WithAddr(host))
-			if host[0] == '/' {
-				opts = append(opts, WithNetwork("unix"))
+		// With several hosts, the server name is set for each host when connecting.
+		if multiHost == "" {
+			serverName = u.Host
+			if host, _, err := net.SplitHostPort(u.Host); err == nil {
+				serverName = host
 			}
 		}
 	case "unix":
@@ -312,10 +454,7 @@ func parseDSN(dsn string) ([]Option, error) {
 		case "require":
 			if sslRootCert == "" {
 				tlsConfig.InsecureSkipVerify = true
-				tlsConfig.ServerName = u.Host
-				if host, _, err := net.SplitHostPort(u.Host); err == nil {
-					tlsConfig.ServerName = host
-				}
+				tlsConfig.ServerName = serverName
 				break
 			}
 			// For backwards compatibility reasons, in the presence of `sslrootcert`,
@@ -330,10 +469,7 @@ func parseDSN(dsn string) ([]Option, error) {
 			// (verify chain, but skip server name).
 			// See https://github.com/golang/go/issues/21971 .
 			tlsConfig.InsecureSkipVerify = true
-			tlsConfig.ServerName = u.Host
-			if host, _, err := net.SplitHostPort(u.Host); err == nil {
-				tlsConfig.ServerName = host
-			}
+			tlsConfig.ServerName = serverName
 			tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
 				certs := make([]*x509.Certificate, 0, len(rawCerts))
 				for _, rawCert := range rawCerts {
@@ -354,10 +490,7 @@ func parseDSN(dsn string) ([]Option, error) {
 				return err
 			}
 		case "verify-full":
-			tlsConfig.ServerName = u.Host
-			if host, _, err := net.SplitHostPort(u.Host); err == nil {
-				tlsConfig.ServerName = host
-			}
+			tlsConfig.ServerName = serverName
 		default:
 			return nil, fmt.Errorf("pgdriver: sslmode '%s' is not supported", sslMode)
 		}
@@ -383,6 +516,38 @@ func parseDSN(dsn string) ([]Option, error) {
 		opts = append(opts, WithTLSConfig(tlsConfig))
 	}
 
+	if channelBinding := q.string("channel_binding"); channelBinding != "" {
+		switch channelBinding {
+		case ChannelBindingDisable, ChannelBindingPrefer, ChannelBindingRequire:
+			opts = append(opts, WithChannelBinding(channelBinding))
+		default:
+			return nil, fmt.Errorf("pgdriver: channel_binding '%s' is not supported", channelBinding)
+		}
+	}
+
+	if attrs := q.string("target_session_attrs"); attrs != "" {
+		if !isTargetSessionAttrs(attrs) {
+			return nil, fmt.Errorf("pgdriver: target_session_attrs '%s' is not supported", attrs)
+		}
+		opts = append(opts, WithTargetSessionAttrs(attrs))
+	}
+
+	switch loadBalance := q.string("load_balance_hosts"); loadBalance {
+	case "", "disable":
+	case "random":
+		opts = append(opts, WithLoadBalanceHosts(true))
+	default:
+		return nil, fmt.Errorf("pgdriver: load_balance_hosts '%s' is not supported", loadBalance)
+	}
+
+	switch replication := q.string("replication"); replication {
+	case "", "false", "off":
+	case ReplicationDatabase:
+		opts = append(opts, WithReplication(replication))
+	default:
+		return nil, fmt.Errorf("pgdriver: replication '%s' is not supported", replication)
+	}
+
 	if d := q.duration("timeout"); d != 0 {
 		opts = append(opts, WithTimeout(d))
 	}
@@ -415,6 +580,79 @@ func parseDSN(dsn string) ([]Option, error) {
 	return opts, nil
 }
 
+// cutMultiHost removes the comma-separated hosts from the DSN authority,
+// e.g. postgres://user@db1:5432,db2:5432/test, because url.Parse rejects them.
+func cutMultiHost(dsn string) (string, string) {
+	i := strings.Index(dsn, "://")
+	if i == -1 {
+		return dsn, ""
+	}
+	start := i + len("://")
+
+	end := len(dsn)
+	if j := strings.IndexAny(dsn[start:], "/?#"); j != -1 {
+		end = start + j
+	}
+	if j := strings.LastIndexByte(dsn[start:end], '@'); j != -1 {
+		start += j + 1
+	}
+
+	hosts := dsn[start:end]
+	if !strings.Contains(hosts, ",") {
+		return dsn, ""
+	}
+	return dsn[:start] + dsn[end:], hosts
+}
+
+// parseHosts parses comma-separated hosts, optionally with ports, and ports
+// which are either one port for all hosts or one port for each host.
+func parseHosts(hosts, ports string) ([]string, error) {
+	hostList := strings.Split(hosts, ",")
+
+	var portList []string
+	if ports != "" {
+		portList = strings.Split(ports, ",")
+		if len(portList) > 1 && len(portList) != len(hostList) {
+			return nil, fmt.Errorf("pgdriver: got %d ports for %d hosts", len(portList), len(hostList))
+		}
+	}
+
+	addrs := make([]string, len(hostList))
+	for i, host := range hostList {
+		if host == "" {
+			return nil, fmt.Errorf("pgdriver: empty host in %q", hosts)
+		}
+		if host[0] == '/' {
+			addrs[i] = host
+			continue
+		}
+
+		port := "5432"
+		switch {
+		case len(portList) == 1:
+			port = portList[0]
+		case len(portList) > 1:
+			port = portList[i]
+		}
+		if h, p, err := net.SplitHostPort(host); err == nil {
+			host, port = h, p
+		} else {
+			host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+		}
+		addrs[i] = net.JoinHostPort(host, port)
+	}
+	return addrs, nil
+}
+
+func allUnixSockets(addrs []string) bool {
+	for _, addr := range addrs {
+		if addr[0] != '/' {
+			return false
+		}
+	}
+	return true
+}
+
 // verify is a method to make sure if the config is legitimate
 // in the case it detects any errors, it returns with a non-nil error
 // it can be extended to check other parameters
@@ -422,6 +660,12 @@ func (c *Config) verify() error {
 	if c.User == "" {
 		return errors.New("pgdriver: User option is empty (to configure, use WithUser).")
 	}
+	if c.TargetSessionAttrs != "" && !isTargetSessionAttrs(c.TargetSessionAttrs) {
+		return fmt.Errorf("pgdriver: target_session_attrs %q is not supported", c.TargetSessionAttrs)
+	}
+	if c.ChannelBinding == ChannelBindingRequire && c.TLSConfig == nil {
+		return errors.New("pgdriver: channel binding is required, but TLS is disabled")
+	}
 	return nil
 }
 
@@ -11,6 +11,7 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -87,7 +88,7 @@ func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
 	if err := c.conf.verify(); err != nil {
 		return nil, err
 	}
-	return newConn(ctx, c.conf)
+	return connect(ctx, c.conf)
 }
 
 func (c *Connector) Driver() driver.Driver {
@@ -102,6 +103,8 @@ func (c *Connector) Config() *Config {
 
 type Conn struct {
 	conf *Config
+	// addr is the address of the server, one of the configured addrs.
+	addr string
 
 	netConn net.Conn
 	rd      *reader
@@ -110,24 +113,37 @@ type Conn struct {
 	secretKey int32
 
 	stmtCount int
+	// pipeline is set while the results of a Batch are not read.
+	pipeline *pipeline
 
 	closed int32
 }
 
-func newConn(ctx context.Context, conf *Config) (*Conn, error) {
-	netConn, err := conf.Dialer(ctx, conf.Network, conf.Addr)
+func newConn(ctx context.Context, conf *Config, addr string) (_ *Conn, err error) {
+	network := conf.Network
+	if strings.HasPrefix(addr, "/") {
+		network = "unix"
+	}
+
+	netConn, err := conf.Dialer(ctx, network, addr)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		if err != nil {
+			_ = netConn.Close()
+		}
+	}()
 
 	cn := &Conn{
 		conf:    conf,
+		addr:    addr,
 		netConn: netConn,
 		rd:      newReader(netConn, conf.BufferSize),
 	}
 
 	if conf.TLSConfig != nil {
-		if err := enableSSL(ctx, cn, conf.TLSConfig); err != nil {
+		if err := enableSSL(ctx, cn, tlsConfigFor(conf.TLSConfig, addr)); err != nil {
 			return nil, err
 		}
 	}
@@ -267,6 +283,14 @@ func (cn *Conn) ExecContext(
 	}
 	cn.trace(ctx)
 
+	if cn.pipeline != nil {
+		res, err := cn.execPipelineResult(ctx)
+		if err != nil {
+			return nil, cn.checkBadConn(err)
+		}
+		return res, nil
+	}
+
 	res, err := cn.exec(ctx, query, args)
 	if err != nil {
 		return nil, cn.checkBadConn(err)
@@ -297,6 +321,14 @@ func (cn *Conn) QueryContext(
 	}
 	cn.trace(ctx)
 
+	if cn.pipeline != nil {
+		rows, err := cn.readPipelineResult(ctx)
+		if err != nil {
+			return nil, cn.checkBadConn(err)
+		}
+		return rows, nil
+	}
+
 	rows, err := cn.query(ctx, query, args)
 	if err != nil {
 		return nil, cn.checkBadConn(err)
@@ -311,12 +343,44 @@ func (cn *Conn) query(
 	if err != nil {
 		return nil, err
 	}
+	if cn.binaryResults(ctx) {
+		return cn.queryBinary(ctx, query)
+	}
 	if err := writeQuery(ctx, cn, query); err != nil {
 		return nil, err
 	}
 	return readQueryData(ctx, cn)
 }
 
+// queryBinary runs the query with the extended protocol, because the simple protocol
+// always returns text. The unnamed statement is described first to choose
+// the format of each column.
+func (cn *Conn) queryBinary(ctx context.Context, query string) (driver.Rows, error) {
+	if err := writeParseDescribeSync(ctx, cn, "", query); err != nil {
+		return nil, err
+	}
+
+	rowDesc, err := readParseDescribeSync(ctx, cn)
+	if err != nil {
+		return nil, err
+	}
+
+	binary := binaryColumns(rowDesc)
+	if err := writeBindExecute(ctx, cn, "", nil, binary); err != nil {
+		return nil, err
+	}
+
+	rows, err := readExtQueryData(ctx, cn, rowDesc)
+	if err != nil {
+		return nil, err
+	}
+	if !rows.closed {
+		rows.reusable = true
+		rows.binary = binary
+	}
+	return rows, nil
+}
+
 var _ driver.Pinger = (*Conn)(nil)
 
 func (cn *Conn) Ping(ctx context.Context) error {
@@ -359,7 +423,8 @@ func (cn *Conn) deadline(ctx context.Context, timeout time.Duration) time.Time {
 var _ driver.Validator = (*Conn)(nil)
 
 func (cn *Conn) IsValid() bool {
-	return !cn.isClosed()
+	// The results of an abandoned pipeline can't be reused.
+	return !cn.isClosed() && cn.pipeline == nil
 }
 
 var _ driver.SessionResetter = (*Conn)(nil)
@@ -393,7 +458,7 @@ func (cn *Conn) trace(ctx context.Context) {
 		span.SetAttributes(
 			semconv.DBUserKey.String(cn.conf.User),
 			semconv.DBNameKey.String(cn.conf.Database),
-			semconv.ServerAddressKey.String(cn.conf.Addr),
+			semconv.ServerAddressKey.String(cn.addr),
 		)
 	}
 }
@@ -405,6 +470,10 @@ type rows struct {
 	rowDesc  *rowDescription
 	reusable bool
 	closed   bool
+	// binary flags the columns received in the binary format.
+	binary []bool
+	// pipeline is set for the results of a Batch.
+	pipeline *pipeline
 }
 
 var _ driver.Rows = (*rows)(nil)
@@ -484,6 +553,14 @@ func (r *rows) next(dest []driver.Value) (eof bool, _ error) {
 		case dataRowMsg:
 			return false, r.readDataRow(rd, dest)
 		case commandCompleteMsg:
+			if r.pipeline != nil {
+				tmp, err := rd.ReadTemp(msgLen)
+				if err != nil {
+					return false, err
+				}
+				r.pipeline.complete(tmp)
+				continue
+			}
 			if err := rd.Discard(msgLen); err != nil {
 				return false, err
 			}
@@ -493,6 +570,11 @@ func (r *rows) next(dest []driver.Value) (eof bool, _ error) {
 			if err := rd.Discard(msgLen); err != nil {
 				return false, err
 			}
+			if r.pipeline != nil {
+				if err := r.pipeline.advance(r.cn); err != nil {
+					return false, err
+				}
+			}
 
 			if firstErr != nil {
 				return false, firstErr
@@ -533,7 +615,12 @@ func (r *rows) readDataRow(rd *reader, dest []driver.Value) error {
 			return err
 		}
 
-		value, err := readColumnValue(rd, r.rowDesc.types[colIdx], int(dataLen))
+		var value any
+		if r.binary != nil && r.binary[colIdx] {
+			value, err = readBinaryColumnValue(rd, r.rowDesc.types[colIdx], int(dataLen))
+		} else {
+			value, err = readColumnValue(rd, r.rowDesc.types[colIdx], int(dataLen))
+		}
 		if err != nil {
 			return err
 		}
@@ -631,7 +718,7 @@ func (stmt *stmt) Exec(args []driver.Value) (driver.Result, error) {
 }
 
 func (stmt *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
-	if err := writeBindExecute(ctx, stmt.cn, stmt.name, args); err != nil {
+	if err := writeBindExecute(ctx, stmt.cn, stmt.name, args, nil); err != nil {
 		return nil, err
 	}
 	return readExtQuery(ctx, stmt.cn)
@@ -642,8 +729,19 @@ func (stmt *stmt) Query(args []driver.Value) (driver.Rows, error) {
 }
 
 func (stmt *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
-	if err := writeBindExecute(ctx, stmt.cn, stmt.name, args); err != nil {
+	var binary []bool
+	if stmt.cn.binaryResults(ctx) {
+		binary = binaryColumns(stmt.rowDesc)
+	}
+
+	if err := writeBindExecute(ctx, stmt.cn, stmt.name, args, binary); err != nil {
 		return nil, err
 	}
-	return readExtQueryData(ctx, stmt.cn, stmt.rowDesc)
+
+	rows, err := readExtQueryData(ctx, stmt.cn, stmt.rowDesc)
+	if err != nil {
+		return nil, err
+	}
+	rows.binary = binary
+	return rows, nil
 }
@@ -243,6 +243,86 @@ func TestFloat64(t *testing.T) {
 	require.Equal(t, 1.1, f)
 }
 
+func TestBinaryResults(t *testing.T) {
+	ctx := context.Background()
+
+	db := sql.OpenDB(pgdriver.NewConnector(
+		pgdriver.WithDSN(dsn()),
+		pgdriver.WithConnParams(map[string]any{"timezone": "UTC"}),
+	))
+	defer db.Close()
+
+	const query = `SELECT true, 1::int2, 2::int4, 3::int8, 1.5::float4, 1.1::float8,
+		'2021-03-04 05:06:07.123456'::timestamp, '2021-03-04 05:06:07+00'::timestamptz,
+		'2021-03-04'::date, 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11'::uuid, '\x01ff'::bytea,
+		-12345.6789::numeric, ARRAY[1, NULL, 3]::int8[], ARRAY[1.5]::numeric[],
+		'text', NULL::int4`
+
+	scan := func(ctx context.Context, t *testing.T) []any {
+		rows, err := db.QueryContext(ctx, query)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		require.NoError(t, err)
+
+		values := make([]any, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(dest...))
+		require.False(t, rows.Next())
+		require.NoError(t, rows.Err())
+		return values
+	}
+
+	text := scan(ctx, t)
+	binary := scan(pgdriver.ContextWithBinaryResults(ctx, true), t)
+	require.Equal(t, text, binary)
+
+	stmt, err := db.PrepareContext(ctx, "SELECT $1::int8, ARRAY[$1::int8]")
+	require.NoError(t, err)
+	defer stmt.Close()
+
+	var n int64
+	var arr string
+	err = stmt.QueryRowContext(pgdriver.ContextWithBinaryResults(ctx, true), 42).Scan(&n, &arr)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), n)
+	require.Equal(t, "{42}", arr)
+}
+
+func TestTargetSessionAttrs(t *testing.T) {
+	withAttrs := func(attrs string) *sql.DB {
+		return sql.OpenDB(pgdriver.NewConnector(
+			pgdriver.WithDSN(dsn()),
+			pgdriver.WithTargetSessionAttrs(attrs),
+		))
+	}
+
+	// The test server is a primary.
+	for _, attrs := range []string{
+		pgdriver.TargetSessionReadWrite,
+		pgdriver.TargetSessionPrimary,
+		pgdriver.TargetSessionPreferStandby,
+	} {
+		db := withAttrs(attrs)
+		require.NoError(t, db.Ping(), attrs)
+		require.NoError(t, db.Close())
+	}
+
+	for _, attrs := range []string{pgdriver.TargetSessionReadOnly, pgdriver.TargetSessionStandby} {
+		db := withAttrs(attrs)
+		err := db.Ping()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not "+attrs)
+		require.NoError(t, db.Close())
+	}
+}
+
 func TestConnParams(t *testing.T) {
 	db := sql.OpenDB(pgdriver.NewConnector(
 		pgdriver.WithDSN(dsn()),
@@ -278,6 +358,46 @@ func TestStatementTimeout(t *testing.T) {
 	require.True(t, pgerr.StatementTimeout())
 }
 
+func TestSerializationFailure(t *testing.T) {
+	ctx := context.Background()
+
+	db := sqlDB()
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, "DROP TABLE IF EXISTS test_serialization")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "CREATE TABLE test_serialization (id int PRIMARY KEY, n int)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO test_serialization VALUES (1, 0)")
+	require.NoError(t, err)
+
+	opts := &sql.TxOptions{Isolation: sql.LevelSerializable}
+	tx1, err := db.BeginTx(ctx, opts)
+	require.NoError(t, err)
+	defer tx1.Rollback()
+
+	tx2, err := db.BeginTx(ctx, opts)
+	require.NoError(t, err)
+	defer tx2.Rollback()
+
+	var n int
+	require.NoError(t, tx1.QueryRowContext(ctx, "SELECT n FROM test_serialization WHERE id = 1").Scan(&n))
+	require.NoError(t, tx2.QueryRowContext(ctx, "SELECT n FROM test_serialization WHERE id = 1").Scan(&n))
+
+	_, err = tx1.ExecContext(ctx, "UPDATE test_serialization SET n = 1 WHERE id = 1")
+	require.NoError(t, err)
+	require.NoError(t, tx1.Commit())
+
+	_, err = tx2.ExecContext(ctx, "UPDATE test_serialization SET n = 2 WHERE id = 1")
+	require.Error(t, err)
+
+	pgerr, ok := err.(pgdriver.Error)
+	require.True(t, ok)
+	require.True(t, pgerr.SerializationFailure())
+	require.False(t, pgerr.DeadlockDetected())
+	require.Equal(t, "40001", pgerr.SQLState())
+}
+
 func TestPartialScan(t *testing.T) {
 	db := sqlDB()
 	defer db.Close()
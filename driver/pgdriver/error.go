@@ -40,6 +40,24 @@ func (err Error) StatementTimeout() bool {
 	return err.Field('C') == "57014"
 }
 
+// SerializationFailure reports whether the error is a serialization failure,
+// which happens when a transaction can't be serialized with the concurrent ones.
+// Such transactions can be retried, see bun.DB.RunInTxWithRetry.
+func (err Error) SerializationFailure() bool {
+	return err.Field('C') == "40001"
+}
+
+// DeadlockDetected reports whether the error is a deadlock error.
+// Such transactions can be retried, see bun.DB.RunInTxWithRetry.
+func (err Error) DeadlockDetected() bool {
+	return err.Field('C') == "40P01"
+}
+
+// SQLState returns the SQLSTATE code of the error.
+func (err Error) SQLState() string {
+	return err.Field('C')
+}
+
 func (err Error) Error() string {
 	return fmt.Sprintf("%s: %s (SQLSTATE=%s)",
 		err.Field('S'), err.Field('M'), err.Field('C'))
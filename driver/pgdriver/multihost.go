@@ -0,0 +1,148 @@
+package pgdriver
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"slices"
+)
+
+// connect connects to the first server in conf.Addrs which accepts the connection
+// and matches conf.TargetSessionAttrs.
+func connect(ctx context.Context, conf *Config) (*Conn, error) {
+	addrs := conf.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{conf.Addr}
+	}
+	if conf.LoadBalanceHosts && len(addrs) > 1 {
+		addrs = slices.Clone(addrs)
+		rand.Shuffle(len(addrs), func(i, j int) {
+			addrs[i], addrs[j] = addrs[j], addrs[i]
+		})
+	}
+
+	attrs := conf.TargetSessionAttrs
+	if attrs == "" {
+		attrs = TargetSessionAny
+	}
+
+	if attrs == TargetSessionPreferStandby {
+		cn, errs := connectAny(ctx, conf, addrs, TargetSessionStandby)
+		if cn != nil {
+			return cn, nil
+		}
+		if ctx.Err() != nil {
+			return nil, joinConnectErrors(errs)
+		}
+		// No standby is available, so fall back to any server.
+		attrs = TargetSessionAny
+	}
+
+	cn, errs := connectAny(ctx, conf, addrs, attrs)
+	if cn != nil {
+		return cn, nil
+	}
+	return nil, joinConnectErrors(errs)
+}
+
+func connectAny(
+	ctx context.Context, conf *Config, addrs []string, attrs string,
+) (*Conn, []error) {
+	var errs []error
+	for _, addr := range addrs {
+		cn, err := connectTarget(ctx, conf, addr, attrs)
+		if err == nil {
+			return cn, nil
+		}
+		errs = append(errs, err)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errs
+}
+
+func connectTarget(ctx context.Context, conf *Config, addr, attrs string) (*Conn, error) {
+	cn, err := newConn(ctx, conf, addr)
+	if err != nil {
+		return nil, err
+	}
+	if attrs == TargetSessionAny {
+		return cn, nil
+	}
+
+	ok, err := cn.matchesSessionAttrs(ctx, attrs)
+	if err == nil && !ok {
+		err = fmt.Errorf("pgdriver: server at %s is not %s", addr, attrs)
+	}
+	if err != nil {
+		_ = cn.Close()
+		return nil, err
+	}
+	return cn, nil
+}
+
+// joinConnectErrors keeps the error of a single host as is.
+func joinConnectErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// matchesSessionAttrs checks whether transactions on the server are read-only by default
+// and whether the server is in hot standby mode. in_hot_standby is only available
+// since PostgreSQL 14, so older servers are checked with pg_is_in_recovery.
+func (cn *Conn) matchesSessionAttrs(ctx context.Context, attrs string) (bool, error) {
+	rows, err := cn.query(ctx, "SELECT pg_catalog.current_setting('transaction_read_only') = 'on', "+
+		"COALESCE(pg_catalog.current_setting('in_hot_standby', true) = 'on', "+
+		"pg_catalog.pg_is_in_recovery())", nil)
+	if err != nil {
+		return false, err
+	}
+
+	dest := make([]driver.Value, 2)
+	if err := rows.Next(dest); err != nil {
+		_ = rows.Close()
+		return false, err
+	}
+	if err := rows.Close(); err != nil {
+		return false, err
+	}
+
+	readOnly, _ := dest[0].(bool)
+	standby, _ := dest[1].(bool)
+
+	switch attrs {
+	case TargetSessionReadWrite:
+		return !readOnly, nil
+	case TargetSessionReadOnly:
+		return readOnly, nil
+	case TargetSessionPrimary:
+		return !standby, nil
+	case TargetSessionStandby:
+		return standby, nil
+	default:
+		return true, nil
+	}
+}
+
+// tlsConfigFor sets the server name to the host of the addr unless the config
+// already has one, so each host is verified against its own name.
+func tlsConfigFor(tlsConf *tls.Config, addr string) *tls.Config {
+	if tlsConf.ServerName != "" || tlsConf.InsecureSkipVerify {
+		return tlsConf
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return tlsConf
+	}
+	tlsConf = tlsConf.Clone()
+	tlsConf.ServerName = host
+	return tlsConf
+}
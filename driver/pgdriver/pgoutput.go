@@ -0,0 +1,307 @@
+package pgdriver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LogicalMessage is a message of the pgoutput logical decoding plugin:
+// *BeginMessage, *CommitMessage, *RelationMessage, *InsertMessage, *UpdateMessage,
+// *DeleteMessage or *RawLogicalMessage for the messages which are not decoded.
+type LogicalMessage interface {
+	logicalMessage()
+}
+
+// BeginMessage starts a transaction.
+type BeginMessage struct {
+	// FinalLSN is the LSN of the commit record of the transaction.
+	FinalLSN   LSN
+	CommitTime time.Time
+	Xid        uint32
+}
+
+// CommitMessage ends a transaction.
+type CommitMessage struct {
+	Flags             uint8
+	CommitLSN         LSN
+	TransactionEndLSN LSN
+	CommitTime        time.Time
+}
+
+// RelationMessage describes a table. It is sent before the first change of the table
+// in a session and whenever the table changes.
+type RelationMessage struct {
+	RelationID   uint32
+	Namespace    string
+	RelationName string
+	// ReplicaIdentity is d (default), n (nothing), f (full) or i (index).
+	ReplicaIdentity byte
+	Columns         []RelationColumn
+}
+
+// RelationColumn describes a column of the table.
+type RelationColumn struct {
+	// Flags is 1 when the column is part of the key.
+	Flags        uint8
+	Name         string
+	DataType     uint32
+	TypeModifier int32
+}
+
+// InsertMessage is a row inserted into the table.
+type InsertMessage struct {
+	RelationID uint32
+	// Relation is the last RelationMessage received for the table.
+	Relation *RelationMessage
+	New      *TupleData
+}
+
+// UpdateMessage is a row updated in the table.
+type UpdateMessage struct {
+	RelationID uint32
+	// Relation is the last RelationMessage received for the table.
+	Relation *RelationMessage
+	// Old is the old key (OldKind is K) or the old row (OldKind is O) depending
+	// on the replica identity of the table. It is nil when the key is not changed.
+	OldKind byte
+	Old     *TupleData
+	New     *TupleData
+}
+
+// DeleteMessage is a row deleted from the table.
+type DeleteMessage struct {
+	RelationID uint32
+	// Relation is the last RelationMessage received for the table.
+	Relation *RelationMessage
+	// Old is the key (OldKind is K) or the row (OldKind is O) depending
+	// on the replica identity of the table.
+	OldKind byte
+	Old     *TupleData
+}
+
+// RawLogicalMessage is a message which is not decoded, e.g. Origin, Type or Truncate.
+type RawLogicalMessage struct {
+	Type byte
+	Data []byte
+}
+
+func (*BeginMessage) logicalMessage()      {}
+func (*CommitMessage) logicalMessage()     {}
+func (*RelationMessage) logicalMessage()   {}
+func (*InsertMessage) logicalMessage()     {}
+func (*UpdateMessage) logicalMessage()     {}
+func (*DeleteMessage) logicalMessage()     {}
+func (*RawLogicalMessage) logicalMessage() {}
+
+// TupleData holds the column values of a row in the text format.
+type TupleData struct {
+	Columns []TupleColumn
+}
+
+// TupleColumn is a column value. Kind is n (null), u (unchanged TOASTed value
+// which is not sent) or t (text).
+type TupleColumn struct {
+	Kind byte
+	Data []byte
+}
+
+// Values returns the column values of the row by column name: the text of the value
+// or nil for NULL. Unchanged TOASTed values are omitted.
+func (t *TupleData) Values(rel *RelationMessage) map[string]any {
+	if t == nil {
+		return nil
+	}
+
+	values := make(map[string]any, len(t.Columns))
+	for i, col := range t.Columns {
+		name := fmt.Sprintf("column%d", i+1)
+		if rel != nil && i < len(rel.Columns) {
+			name = rel.Columns[i].Name
+		}
+
+		switch col.Kind {
+		case 'n':
+			values[name] = nil
+		case 't':
+			values[name] = string(col.Data)
+		}
+	}
+	return values
+}
+
+//------------------------------------------------------------------------------
+
+var errShortLogicalMessage = errors.New("pgdriver: pgoutput message is too short")
+
+// DecodePgOutput decodes a message of the pgoutput plugin (protocol version 1)
+// received in XLogData.
+func DecodePgOutput(data []byte) (LogicalMessage, error) {
+	if len(data) == 0 {
+		return nil, errShortLogicalMessage
+	}
+
+	d := &pgoutputDecoder{b: data[1:]}
+	var msg LogicalMessage
+	switch data[0] {
+	case 'B':
+		msg = &BeginMessage{
+			FinalLSN:   LSN(d.uint64()),
+			CommitTime: d.time(),
+			Xid:        d.uint32(),
+		}
+	case 'C':
+		msg = &CommitMessage{
+			Flags:             d.uint8(),
+			CommitLSN:         LSN(d.uint64()),
+			TransactionEndLSN: LSN(d.uint64()),
+			CommitTime:        d.time(),
+		}
+	case 'R':
+		rel := &RelationMessage{
+			RelationID:      d.uint32(),
+			Namespace:       d.string(),
+			RelationName:    d.string(),
+			ReplicaIdentity: d.uint8(),
+		}
+		n := int(d.uint16())
+		if d.err == nil {
+			rel.Columns = make([]RelationColumn, n)
+		}
+		for i := 0; i < n && d.err == nil; i++ {
+			rel.Columns[i] = RelationColumn{
+				Flags:        d.uint8(),
+				Name:         d.string(),
+				DataType:     d.uint32(),
+				TypeModifier: int32(d.uint32()),
+			}
+		}
+		msg = rel
+	case 'I':
+		insert := &InsertMessage{RelationID: d.uint32()}
+		if kind := d.uint8(); kind != 'N' && d.err == nil {
+			return nil, fmt.Errorf("pgdriver: got tuple %q, wanted N", kind)
+		}
+		insert.New = d.tuple()
+		msg = insert
+	case 'U':
+		update := &UpdateMessage{RelationID: d.uint32()}
+		kind := d.uint8()
+		if kind == 'K' || kind == 'O' {
+			update.OldKind = kind
+			update.Old = d.tuple()
+			kind = d.uint8()
+		}
+		if kind != 'N' && d.err == nil {
+			return nil, fmt.Errorf("pgdriver: got tuple %q, wanted N", kind)
+		}
+		update.New = d.tuple()
+		msg = update
+	case 'D':
+		del := &DeleteMessage{RelationID: d.uint32()}
+		del.OldKind = d.uint8()
+		if del.OldKind != 'K' && del.OldKind != 'O' && d.err == nil {
+			return nil, fmt.Errorf("pgdriver: got tuple %q, wanted K or O", del.OldKind)
+		}
+		del.Old = d.tuple()
+		msg = del
+	default:
+		return &RawLogicalMessage{Type: data[0], Data: data[1:]}, nil
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+	return msg, nil
+}
+
+// pgoutputDecoder reads big-endian values and remembers the first error.
+type pgoutputDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *pgoutputDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.b) < n {
+		d.err = errShortLogicalMessage
+		return nil
+	}
+	b := d.b[:n]
+	d.b = d.b[n:]
+	return b
+}
+
+func (d *pgoutputDecoder) uint8() uint8 {
+	if b := d.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (d *pgoutputDecoder) uint16() uint16 {
+	if b := d.next(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (d *pgoutputDecoder) uint32() uint32 {
+	if b := d.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *pgoutputDecoder) uint64() uint64 {
+	if b := d.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+// time decodes the microseconds since 2000-01-01.
+func (d *pgoutputDecoder) time() time.Time {
+	return pgEpoch.Add(time.Duration(int64(d.uint64())) * time.Microsecond)
+}
+
+func (d *pgoutputDecoder) string() string {
+	if d.err != nil {
+		return ""
+	}
+	i := bytes.IndexByte(d.b, 0)
+	if i == -1 {
+		d.err = errShortLogicalMessage
+		return ""
+	}
+	s := string(d.b[:i])
+	d.b = d.b[i+1:]
+	return s
+}
+
+func (d *pgoutputDecoder) tuple() *TupleData {
+	n := int(d.uint16())
+	if d.err != nil {
+		return nil
+	}
+
+	tuple := &TupleData{Columns: make([]TupleColumn, n)}
+	for i := 0; i < n && d.err == nil; i++ {
+		col := &tuple.Columns[i]
+		col.Kind = d.uint8()
+		switch col.Kind {
+		case 't', 'b':
+			col.Data = d.next(int(d.uint32()))
+		case 'n', 'u':
+		default:
+			if d.err == nil {
+				d.err = fmt.Errorf("pgdriver: unknown tuple column kind %q", col.Kind)
+			}
+		}
+	}
+	return tuple
+}
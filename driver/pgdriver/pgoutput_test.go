@@ -0,0 +1,155 @@
+package pgdriver
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLSN(t *testing.T) {
+	lsn, err := ParseLSN("16/B374D848")
+	require.NoError(t, err)
+	require.Equal(t, LSN(0x16_B374D848), lsn)
+	require.Equal(t, "16/B374D848", lsn.String())
+	require.Equal(t, "0/0", LSN(0).String())
+
+	for _, s := range []string{"", "16", "16/", "G/0", "1/100000000"} {
+		_, err := ParseLSN(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestDecodePgOutput(t *testing.T) {
+	be16 := func(b []byte, n uint16) []byte { return binary.BigEndian.AppendUint16(b, n) }
+	be32 := func(b []byte, n uint32) []byte { return binary.BigEndian.AppendUint32(b, n) }
+	be64 := func(b []byte, n uint64) []byte { return binary.BigEndian.AppendUint64(b, n) }
+	str := func(b []byte, s string) []byte { return append(append(b, s...), 0) }
+
+	commitTime := time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC)
+	micros := uint64(commitTime.Sub(pgEpoch).Microseconds())
+
+	t.Run("begin", func(t *testing.T) {
+		b := be32(be64(be64([]byte{'B'}, 0x1_00000010), micros), 42)
+
+		msg, err := DecodePgOutput(b)
+		require.NoError(t, err)
+		require.Equal(t, &BeginMessage{
+			FinalLSN:   0x1_00000010,
+			CommitTime: commitTime,
+			Xid:        42,
+		}, msg)
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		b := be64(be64(be64([]byte{'C', 0}, 0x10), 0x20), micros)
+
+		msg, err := DecodePgOutput(b)
+		require.NoError(t, err)
+		require.Equal(t, &CommitMessage{
+			CommitLSN:         0x10,
+			TransactionEndLSN: 0x20,
+			CommitTime:        commitTime,
+		}, msg)
+	})
+
+	t.Run("relation", func(t *testing.T) {
+		b := be32([]byte{'R'}, 16384)
+		b = str(b, "public")
+		b = str(b, "users")
+		b = append(b, 'd')
+		b = be16(b, 2)
+		b = be32(str(append(b, 1), "id"), 23)
+		b = be32(b, 0xFFFFFFFF)
+		b = be32(str(append(b, 0), "name"), 25)
+		b = be32(b, 0xFFFFFFFF)
+
+		msg, err := DecodePgOutput(b)
+		require.NoError(t, err)
+		require.Equal(t, &RelationMessage{
+			RelationID:      16384,
+			Namespace:       "public",
+			RelationName:    "users",
+			ReplicaIdentity: 'd',
+			Columns: []RelationColumn{
+				{Flags: 1, Name: "id", DataType: 23, TypeModifier: -1},
+				{Name: "name", DataType: 25, TypeModifier: -1},
+			},
+		}, msg)
+	})
+
+	rel := &RelationMessage{
+		Columns: []RelationColumn{{Name: "id"}, {Name: "name"}, {Name: "bio"}},
+	}
+	tuple := func(b []byte) []byte {
+		b = be16(b, 3)
+		b = append(be32(append(b, 't'), 1), '1')
+		b = append(b, 'n')
+		return append(b, 'u')
+	}
+	wantValues := map[string]any{"id": "1", "name": nil}
+
+	t.Run("insert", func(t *testing.T) {
+		b := tuple(append(be32([]byte{'I'}, 16384), 'N'))
+
+		msg, err := DecodePgOutput(b)
+		require.NoError(t, err)
+		insert := msg.(*InsertMessage)
+		require.Equal(t, uint32(16384), insert.RelationID)
+		require.Equal(t, wantValues, insert.New.Values(rel))
+		require.Equal(t, map[string]any{"column1": "1", "column2": nil}, insert.New.Values(nil))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		b := tuple(append(be32([]byte{'U'}, 16384), 'N'))
+
+		msg, err := DecodePgOutput(b)
+		require.NoError(t, err)
+		update := msg.(*UpdateMessage)
+		require.Nil(t, update.Old)
+		require.Equal(t, wantValues, update.New.Values(rel))
+
+		b = tuple(append(tuple(append(be32([]byte{'U'}, 16384), 'O')), 'N'))
+
+		msg, err = DecodePgOutput(b)
+		require.NoError(t, err)
+		update = msg.(*UpdateMessage)
+		require.Equal(t, byte('O'), update.OldKind)
+		require.Equal(t, wantValues, update.Old.Values(rel))
+		require.Equal(t, wantValues, update.New.Values(rel))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		b := tuple(append(be32([]byte{'D'}, 16384), 'K'))
+
+		msg, err := DecodePgOutput(b)
+		require.NoError(t, err)
+		del := msg.(*DeleteMessage)
+		require.Equal(t, byte('K'), del.OldKind)
+		require.Equal(t, wantValues, del.Old.Values(rel))
+	})
+
+	t.Run("raw", func(t *testing.T) {
+		msg, err := DecodePgOutput([]byte{'T', 1, 2})
+		require.NoError(t, err)
+		require.Equal(t, &RawLogicalMessage{Type: 'T', Data: []byte{1, 2}}, msg)
+	})
+
+	t.Run("short", func(t *testing.T) {
+		for _, b := range [][]byte{
+			nil,
+			{'B', 0, 0},
+			{'R', 0, 0, 0, 1, 'p'},
+			tuple(append(be32([]byte{'I'}, 16384), 'N'))[:10],
+		} {
+			_, err := DecodePgOutput(b)
+			require.Equal(t, errShortLogicalMessage, err)
+		}
+	})
+
+	t.Run("unexpected tuple", func(t *testing.T) {
+		_, err := DecodePgOutput(tuple(append(be32([]byte{'I'}, 16384), 'K')))
+		require.Error(t, err)
+	})
+}
@@ -20,7 +20,6 @@ import (
 	"unicode/utf8"
 
 	"github.com/uptrace/bun/internal"
-	"mellium.im/sasl"
 )
 
 // https://www.postgresql.org/docs/current/protocol-message-formats.html
@@ -223,6 +222,10 @@ func writeStartup(ctx context.Context, cn *Conn) error {
 		wb.WriteString("application_name")
 		wb.WriteString(cn.conf.AppName)
 	}
+	if cn.conf.Replication != "" {
+		wb.WriteString("replication")
+		wb.WriteString(cn.conf.Replication)
+	}
 	wb.WriteString("")
 	wb.FinishMessage()
 
@@ -237,6 +240,12 @@ func auth(ctx context.Context, cn *Conn, rd *reader) error {
 		return err
 	}
 
+	if cn.conf.ChannelBinding == ChannelBindingRequire && num != authenticationSASL {
+		// Fail closed instead of authenticating without channel binding.
+		return errors.New("pgdriver: channel binding is required, " +
+			"but the server requested authentication without SASL")
+	}
+
 	switch num {
 	case authenticationOK:
 		return nil
@@ -323,38 +332,30 @@ func md5s(s string) string {
 //------------------------------------------------------------------------------
 
 func authSASL(ctx context.Context, cn *Conn, rd *reader) error {
-	var saslMech sasl.Mechanism
-
-loop:
+	var offersSCRAM, offersSCRAMPlus bool
 	for {
 		s, err := readString(rd)
 		if err != nil {
 			return err
 		}
+		if s == "" {
+			break
+		}
 
 		switch s {
-		case "":
-			break loop
-		case sasl.ScramSha256.Name:
-			saslMech = sasl.ScramSha256
-		case sasl.ScramSha256Plus.Name:
-			// ignore
-		default:
-			return fmt.Errorf("got %q, wanted %q", s, sasl.ScramSha256.Name)
+		case scramSHA256:
+			offersSCRAM = true
+		case scramSHA256Plus:
+			offersSCRAMPlus = true
 		}
 	}
 
-	creds := sasl.Credentials(func() (Username, Password, Identity []byte) {
-		return []byte(cn.conf.User), []byte(cn.conf.Password), nil
-	})
-	client := sasl.NewClient(saslMech, creds)
-
-	_, resp, err := client.Step(nil)
+	mech, client, err := newSASLClient(cn, offersSCRAM, offersSCRAMPlus)
 	if err != nil {
-		return fmt.Errorf("client.Step 1 failed: %w", err)
+		return err
 	}
 
-	if err := saslWriteInitialResponse(ctx, cn, saslMech, resp); err != nil {
+	if err := saslWriteInitialResponse(ctx, cn, mech, client.clientFirst()); err != nil {
 		return err
 	}
 
@@ -378,9 +379,9 @@ loop:
 			return err
 		}
 
-		_, resp, err = client.Step(b)
+		resp, err := client.clientFinal(b)
 		if err != nil {
-			return fmt.Errorf("client.Step 2 failed: %w", err)
+			return err
 		}
 
 		if err := saslWriteResponse(ctx, cn, resp); err != nil {
@@ -392,15 +393,7 @@ loop:
 			return err
 		}
 
-		if _, _, err := client.Step(resp); err != nil {
-			return fmt.Errorf("client.Step 3 failed: %w", err)
-		}
-
-		if client.State() != sasl.ValidServerResponse {
-			return fmt.Errorf("got state=%q, wanted %q", client.State(), sasl.ValidServerResponse)
-		}
-
-		return nil
+		return client.verifyServerFinal(resp)
 	case errorResponseMsg:
 		e, err := readError(rd)
 		if err != nil {
@@ -412,14 +405,51 @@ loop:
 	}
 }
 
+// newSASLClient chooses the SCRAM mechanism offered by the server,
+// using channel binding on TLS connections unless it is disabled.
+func newSASLClient(
+	cn *Conn, offersSCRAM, offersSCRAMPlus bool,
+) (string, *scramClient, error) {
+	mode := cn.conf.ChannelBinding
+	tlsConn, isTLS := cn.netConn.(*tls.Conn)
+
+	if isTLS && offersSCRAMPlus && mode != ChannelBindingDisable {
+		cbData, err := tlsServerEndPoint(tlsConn.ConnectionState())
+		if err != nil {
+			return "", nil, err
+		}
+		client := newSCRAMClient(cn.conf.User, cn.conf.Password, gs2TLSServerEndPoint, cbData)
+		return scramSHA256Plus, client, nil
+	}
+
+	if mode == ChannelBindingRequire {
+		if !isTLS {
+			return "", nil, errors.New("channel binding is required, but the connection does not use TLS")
+		}
+		return "", nil, fmt.Errorf("channel binding is required, but the server does not offer %s",
+			scramSHA256Plus)
+	}
+
+	if !offersSCRAM {
+		return "", nil, fmt.Errorf("server does not offer %s", scramSHA256)
+	}
+
+	gs2Header := gs2NoChannelBinding
+	if isTLS && mode != ChannelBindingDisable {
+		// Tell the server that the client supports channel binding to detect downgrades.
+		gs2Header = gs2ChannelBindingNotOffered
+	}
+	return scramSHA256, newSCRAMClient(cn.conf.User, cn.conf.Password, gs2Header, nil), nil
+}
+
 func saslWriteInitialResponse(
-	ctx context.Context, cn *Conn, saslMech sasl.Mechanism, resp []byte,
+	ctx context.Context, cn *Conn, mech string, resp []byte,
 ) error {
 	wb := getWriteBuffer()
 	defer putWriteBuffer(wb)
 
 	wb.StartMessage(saslInitialResponseMsg)
-	wb.WriteString(saslMech.Name)
+	wb.WriteString(mech)
 	wb.WriteInt32(int32(len(resp)))
 	if _, err := wb.Write(resp); err != nil {
 		return err
@@ -804,7 +834,11 @@ func readParseDescribeSync(ctx context.Context, cn *Conn) (*rowDescription, erro
 	}
 }
 
-func writeBindExecute(ctx context.Context, cn *Conn, name string, args []driver.NamedValue) error {
+// writeBindExecute binds the args to the prepared statement and executes it.
+// The columns flagged in binary are requested in the binary format.
+func writeBindExecute(
+	ctx context.Context, cn *Conn, name string, args []driver.NamedValue, binary []bool,
+) error {
 	wb := getWriteBuffer()
 	defer putWriteBuffer(wb)
 
@@ -826,7 +860,14 @@ func writeBindExecute(ctx context.Context, cn *Conn, name string, args []driver.
 			wb.FinishNullParam()
 		}
 	}
-	wb.WriteInt16(0)
+	wb.WriteInt16(int16(len(binary)))
+	for _, on := range binary {
+		if on {
+			wb.WriteInt16(1)
+		} else {
+			wb.WriteInt16(0)
+		}
+	}
 	wb.FinishMessage()
 
 	wb.StartMessage(executeMsg)
@@ -0,0 +1,620 @@
+package pgdriver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	copyBothResponseMsg = 'W'
+
+	xlogDataMsg         = 'w'
+	primaryKeepaliveMsg = 'k'
+	standbyStatusMsg    = 'r'
+)
+
+var errReplicatorClosed = errors.New("pgdriver: replicator is closed")
+
+// LSN is a position in the write-ahead log.
+type LSN uint64
+
+// ParseLSN parses the LSN in the X/X format used by PostgreSQL, e.g. 16/B374D848.
+func ParseLSN(s string) (LSN, error) {
+	hi, lo, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, fmt.Errorf("pgdriver: invalid LSN: %q", s)
+	}
+	h, err := strconv.ParseUint(hi, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("pgdriver: invalid LSN: %q", s)
+	}
+	l, err := strconv.ParseUint(lo, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("pgdriver: invalid LSN: %q", s)
+	}
+	return LSN(h<<32 | l), nil
+}
+
+func (lsn LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint32(lsn>>32), uint32(lsn))
+}
+
+// SystemInfo is the result of IDENTIFY_SYSTEM.
+type SystemInfo struct {
+	SystemID string
+	Timeline int32
+	// XLogPos is the current WAL flush location.
+	XLogPos LSN
+	DBName  string
+}
+
+// ReplicationSlot is the result of CREATE_REPLICATION_SLOT.
+type ReplicationSlot struct {
+	Name string
+	// ConsistentPoint is the LSN from which the slot streams changes.
+	ConsistentPoint LSN
+	SnapshotName    string
+	OutputPlugin    string
+}
+
+// XLogData is a WAL record streamed by the server with the decoded pgoutput message.
+type XLogData struct {
+	WALStart     LSN
+	ServerWALEnd LSN
+	ServerTime   time.Time
+	Message      LogicalMessage
+}
+
+//------------------------------------------------------------------------------
+
+type ReplicatorOption func(r *Replicator)
+
+// WithStandbyStatusInterval sets how often the standby status with the acknowledged LSN
+// is sent to the server while receiving messages. Default is 10 seconds.
+// Zero disables the periodic status, so it is only sent when the server requests it.
+func WithStandbyStatusInterval(d time.Duration) ReplicatorOption {
+	return func(r *Replicator) {
+		r.statusInterval = d
+	}
+}
+
+// Replicator streams changes from a logical replication slot using the pgoutput plugin.
+//
+// It uses a dedicated connection opened with replication=database, which is
+// not returned to the pool. Messages are received with Receive or Messages and
+// acknowledged with Ack once they are processed, so the server can remove
+// the WAL which is no longer needed:
+//
+//	r := pgdriver.NewReplicator(db)
+//	defer r.Close()
+//
+//	if err := r.StartReplication(ctx, "slot", 0, "publication"); err != nil {
+//		return err
+//	}
+//	for msg, err := range r.Messages(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		// process msg.Message
+//		r.Ack(msg.WALStart)
+//	}
+//
+// Like Listener, the Replicator is NOT safe for concurrent use except for Ack and Close.
+type Replicator struct {
+	driver *Connector
+
+	statusInterval time.Duration
+
+	mu        sync.Mutex
+	cn        *Conn
+	closed    bool
+	streaming bool
+	relations map[uint32]*RelationMessage
+
+	received   atomic.Uint64
+	acked      atomic.Uint64
+	lastStatus time.Time
+
+	// waiting is set while waiting for the next message, so the read can be
+	// interrupted when the context is canceled without breaking the stream.
+	waitMu  sync.Mutex
+	waiting bool
+}
+
+// NewReplicator creates a replicator which connects to the database of db.
+func NewReplicator(db *bun.DB, opts ...ReplicatorOption) *Replicator {
+	conf := *db.Driver().(Driver).connector.Config()
+	conf.Replication = ReplicationDatabase
+	// Queries use the simple protocol, which is the only protocol supported by walsender.
+	conf.BinaryResults = false
+
+	r := &Replicator{
+		driver:         NewConnector(WithConfig(&conf)),
+		statusInterval: 10 * time.Second,
+		relations:      make(map[uint32]*RelationMessage),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Close closes the replication connection.
+func (r *Replicator) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return errReplicatorClosed
+	}
+	r.closed = true
+
+	if r.cn == nil {
+		return nil
+	}
+	err := r.cn.Close()
+	r.cn = nil
+	return err
+}
+
+func (r *Replicator) conn(ctx context.Context) (*Conn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil, errReplicatorClosed
+	}
+	if r.cn != nil {
+		return r.cn, nil
+	}
+
+	driverConn, err := r.driver.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.cn = driverConn.(*Conn)
+	return r.cn, nil
+}
+
+// command runs the replication command and returns the first row.
+func (r *Replicator) command(ctx context.Context, command string, numCol int) ([]driver.Value, error) {
+	cn, err := r.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.streaming {
+		return nil, errors.New("pgdriver: replication is already started")
+	}
+
+	if err := writeQuery(ctx, cn, command); err != nil {
+		return nil, cn.checkBadConn(err)
+	}
+	rows, err := readQueryData(ctx, cn)
+	if err != nil {
+		return nil, cn.checkBadConn(err)
+	}
+	defer rows.Close()
+
+	if numCol == 0 {
+		return nil, nil
+	}
+
+	dest := make([]driver.Value, numCol)
+	if err := rows.Next(dest); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("pgdriver: %s returned no rows", command)
+		}
+		return nil, err
+	}
+	return dest, nil
+}
+
+// IdentifySystem requests the server to identify itself.
+func (r *Replicator) IdentifySystem(ctx context.Context) (SystemInfo, error) {
+	row, err := r.command(ctx, "IDENTIFY_SYSTEM", 4)
+	if err != nil {
+		return SystemInfo{}, err
+	}
+
+	info := SystemInfo{
+		SystemID: stringValue(row[0]),
+		DBName:   stringValue(row[3]),
+	}
+	if n, ok := row[1].(int64); ok {
+		info.Timeline = int32(n)
+	}
+	if info.XLogPos, err = ParseLSN(stringValue(row[2])); err != nil {
+		return SystemInfo{}, err
+	}
+	return info, nil
+}
+
+// CreateReplicationSlot creates a logical replication slot which uses the pgoutput plugin.
+// A temporary slot is dropped when the connection is closed.
+func (r *Replicator) CreateReplicationSlot(
+	ctx context.Context, name string, temporary bool,
+) (ReplicationSlot, error) {
+	command := "CREATE_REPLICATION_SLOT " + quoteIdent(name)
+	if temporary {
+		command += " TEMPORARY"
+	}
+	command += " LOGICAL pgoutput"
+
+	row, err := r.command(ctx, command, 4)
+	if err != nil {
+		return ReplicationSlot{}, err
+	}
+
+	slot := ReplicationSlot{
+		Name:         stringValue(row[0]),
+		SnapshotName: stringValue(row[2]),
+		OutputPlugin: stringValue(row[3]),
+	}
+	if slot.ConsistentPoint, err = ParseLSN(stringValue(row[1])); err != nil {
+		return ReplicationSlot{}, err
+	}
+	return slot, nil
+}
+
+// DropReplicationSlot drops the replication slot, waiting until it is inactive.
+func (r *Replicator) DropReplicationSlot(ctx context.Context, name string) error {
+	_, err := r.command(ctx, "DROP_REPLICATION_SLOT "+quoteIdent(name)+" WAIT", 0)
+	return err
+}
+
+// StartReplication starts streaming the changes of the publications from the slot.
+// The server streams from the position confirmed by the previous session or from
+// startLSN if it is greater. After it is started, only Receive, Messages, Ack,
+// SendStandbyStatus and Close can be used.
+func (r *Replicator) StartReplication(
+	ctx context.Context, slot string, startLSN LSN, publications ...string,
+) error {
+	if len(publications) == 0 {
+		return errors.New("pgdriver: at least one publication is required")
+	}
+
+	cn, err := r.conn(ctx)
+	if err != nil {
+		return err
+	}
+	if r.streaming {
+		return errors.New("pgdriver: replication is already started")
+	}
+
+	names := make([]string, len(publications))
+	for i, pub := range publications {
+		names[i] = quoteIdent(pub)
+	}
+	command := fmt.Sprintf(
+		"START_REPLICATION SLOT %s LOGICAL %s (proto_version '1', publication_names %s)",
+		quoteIdent(slot), startLSN, quoteLiteral(strings.Join(names, ",")))
+
+	if err := writeQuery(ctx, cn, command); err != nil {
+		return cn.checkBadConn(err)
+	}
+	if err := readCopyBoth(ctx, cn); err != nil {
+		return cn.checkBadConn(err)
+	}
+
+	r.streaming = true
+	r.received.Store(uint64(startLSN))
+	if r.acked.Load() < uint64(startLSN) {
+		r.acked.Store(uint64(startLSN))
+	}
+	r.lastStatus = time.Now()
+	return nil
+}
+
+func readCopyBoth(ctx context.Context, cn *Conn) error {
+	rd := cn.reader(ctx, -1)
+	var firstErr error
+	for {
+		c, msgLen, err := readMessageType(rd)
+		if err != nil {
+			return err
+		}
+
+		switch c {
+		case errorResponseMsg:
+			e, err := readError(rd)
+			if err != nil {
+				return err
+			}
+			if firstErr == nil {
+				firstErr = e
+			}
+		case readyForQueryMsg:
+			if err := rd.Discard(msgLen); err != nil {
+				return err
+			}
+			if firstErr == nil {
+				firstErr = errors.New("pgdriver: replication did not start")
+			}
+			return firstErr
+		case copyBothResponseMsg:
+			if err := rd.Discard(msgLen); err != nil {
+				return err
+			}
+			return nil
+		case noticeResponseMsg, parameterStatusMsg:
+			if err := rd.Discard(msgLen); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("pgdriver: readCopyBoth: unexpected message %q", c)
+		}
+	}
+}
+
+// Ack acknowledges that the changes up to the LSN are processed, so the server can
+// remove the WAL which is no longer needed. It is sent with the next standby status.
+// Ack is safe for concurrent use.
+func (r *Replicator) Ack(lsn LSN) {
+	for {
+		acked := r.acked.Load()
+		if uint64(lsn) <= acked || r.acked.CompareAndSwap(acked, uint64(lsn)) {
+			return
+		}
+	}
+}
+
+// SendStandbyStatus sends the received and acknowledged LSNs to the server.
+func (r *Replicator) SendStandbyStatus(ctx context.Context) error {
+	cn, err := r.conn(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.sendStandbyStatus(ctx, cn); err != nil {
+		return cn.checkBadConn(err)
+	}
+	return nil
+}
+
+func (r *Replicator) sendStandbyStatus(ctx context.Context, cn *Conn) error {
+	acked := r.acked.Load()
+	received := max(r.received.Load(), acked)
+
+	wb := getWriteBuffer()
+	defer putWriteBuffer(wb)
+
+	wb.StartMessage(copyDataMsg)
+	_ = wb.WriteByte(standbyStatusMsg)
+	writeUint64(wb, received)
+	writeUint64(wb, acked) // flushed
+	writeUint64(wb, acked) // applied
+	writeUint64(wb, uint64(time.Since(pgEpoch).Microseconds()))
+	_ = wb.WriteByte(0) // no reply requested
+	wb.FinishMessage()
+
+	if err := cn.write(ctx, wb); err != nil {
+		return err
+	}
+	r.lastStatus = time.Now()
+	return nil
+}
+
+func writeUint64(wb *writeBuffer, n uint64) {
+	wb.WriteInt32(int32(n >> 32))
+	wb.WriteInt32(int32(n))
+}
+
+// Receive waits for the next WAL record. It answers the keepalive messages of
+// the server and periodically sends the standby status while waiting.
+func (r *Replicator) Receive(ctx context.Context) (*XLogData, error) {
+	cn, err := r.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !r.streaming {
+		return nil, errors.New("pgdriver: replication is not started")
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		r.waitMu.Lock()
+		defer r.waitMu.Unlock()
+		if r.waiting {
+			_ = cn.netConn.SetReadDeadline(time.Now())
+		}
+	})
+	defer stop()
+
+	for {
+		msg, err := r.receive(ctx, cn)
+		if err != nil {
+			return nil, cn.checkBadConn(err)
+		}
+		if msg != nil {
+			return msg, nil
+		}
+	}
+}
+
+// receive reads one message. It returns nil without an error for keepalives
+// and when the standby status is due.
+func (r *Replicator) receive(ctx context.Context, cn *Conn) (*XLogData, error) {
+	var statusDeadline time.Time
+	if r.statusInterval > 0 {
+		statusDeadline = r.lastStatus.Add(r.statusInterval)
+	}
+	if !statusDeadline.IsZero() && !time.Now().Before(statusDeadline) {
+		if err := r.sendStandbyStatus(ctx, cn); err != nil {
+			return nil, err
+		}
+		statusDeadline = r.lastStatus.Add(r.statusInterval)
+	}
+
+	rd := cn.rd
+	if rd.Buffered() == 0 {
+		r.setWaiting(cn, true, statusDeadline)
+		if err := ctx.Err(); err != nil {
+			r.setWaiting(cn, false, time.Time{})
+			return nil, err
+		}
+
+		_, err := rd.Peek(1)
+		r.setWaiting(cn, false, time.Time{})
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			if isTimeout(err) {
+				// The standby status is due.
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+
+	cn.setReadDeadline(ctx, -1)
+	c, msgLen, err := readMessageType(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c {
+	case copyDataMsg:
+		b := make([]byte, msgLen)
+		if _, err := io.ReadFull(rd, b); err != nil {
+			return nil, err
+		}
+		return r.handleCopyData(ctx, cn, b)
+	case errorResponseMsg:
+		e, err := readError(rd)
+		if err != nil {
+			return nil, err
+		}
+		return nil, e
+	case copyDoneMsg:
+		r.streaming = false
+		if err := rd.Discard(msgLen); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	case noticeResponseMsg, parameterStatusMsg:
+		if err := rd.Discard(msgLen); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("pgdriver: Replicator.Receive: unexpected message %q", c)
+	}
+}
+
+func (r *Replicator) setWaiting(cn *Conn, waiting bool, deadline time.Time) {
+	r.waitMu.Lock()
+	defer r.waitMu.Unlock()
+
+	r.waiting = waiting
+	_ = cn.netConn.SetReadDeadline(deadline)
+}
+
+func (r *Replicator) handleCopyData(ctx context.Context, cn *Conn, b []byte) (*XLogData, error) {
+	if len(b) == 0 {
+		return nil, errShortLogicalMessage
+	}
+	d := &pgoutputDecoder{b: b[1:]}
+
+	switch b[0] {
+	case xlogDataMsg:
+		msg := &XLogData{
+			WALStart:     LSN(d.uint64()),
+			ServerWALEnd: LSN(d.uint64()),
+			ServerTime:   d.time(),
+		}
+		if d.err != nil {
+			return nil, d.err
+		}
+
+		logical, err := DecodePgOutput(d.b)
+		if err != nil {
+			return nil, err
+		}
+		r.resolveRelation(logical)
+		msg.Message = logical
+
+		if uint64(msg.WALStart) > r.received.Load() {
+			r.received.Store(uint64(msg.WALStart))
+		}
+		return msg, nil
+	case primaryKeepaliveMsg:
+		walEnd := d.uint64()
+		_ = d.uint64() // server time
+		replyRequested := d.uint8() == 1
+		if d.err != nil {
+			return nil, d.err
+		}
+
+		if walEnd > r.received.Load() {
+			r.received.Store(walEnd)
+		}
+		if replyRequested {
+			return nil, r.sendStandbyStatus(ctx, cn)
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("pgdriver: unknown replication message %q", b[0])
+	}
+}
+
+// resolveRelation remembers the relations and sets them on the row changes.
+func (r *Replicator) resolveRelation(msg LogicalMessage) {
+	switch msg := msg.(type) {
+	case *RelationMessage:
+		r.relations[msg.RelationID] = msg
+	case *InsertMessage:
+		msg.Relation = r.relations[msg.RelationID]
+	case *UpdateMessage:
+		msg.Relation = r.relations[msg.RelationID]
+	case *DeleteMessage:
+		msg.Relation = r.relations[msg.RelationID]
+	}
+}
+
+// Messages returns an iterator over the received WAL records. It stops after
+// yielding an error, e.g. when the context is canceled.
+func (r *Replicator) Messages(ctx context.Context) iter.Seq2[*XLogData, error] {
+	return func(yield func(*XLogData, error) bool) {
+		for {
+			msg, err := r.Receive(ctx)
+			if !yield(msg, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func stringValue(v driver.Value) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return ""
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func isTimeout(err error) bool {
+	var netErr interface{ Timeout() bool }
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,182 @@
+package pgdriver
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+const (
+	scramSHA256     = "SCRAM-SHA-256"
+	scramSHA256Plus = "SCRAM-SHA-256-PLUS"
+)
+
+// GS2 headers, see RFC 5802.
+const (
+	// The client does not support channel binding.
+	gs2NoChannelBinding = "n,,"
+	// The client supports channel binding, but thinks the server does not.
+	gs2ChannelBindingNotOffered = "y,,"
+	// The client uses tls-server-end-point channel binding.
+	gs2TLSServerEndPoint = "p=tls-server-end-point,,"
+)
+
+// scramClient implements the client side of SCRAM-SHA-256 and SCRAM-SHA-256-PLUS
+// as described in RFC 5802 and RFC 7677.
+type scramClient struct {
+	user      string
+	password  string
+	gs2Header string
+	// cbData is the channel binding data, empty without channel binding.
+	cbData []byte
+
+	nonce           string
+	clientFirstBare string
+	serverSignature []byte
+}
+
+func newSCRAMClient(user, password, gs2Header string, cbData []byte) *scramClient {
+	b := make([]byte, 18)
+	_, _ = rand.Read(b)
+	return &scramClient{
+		user:      user,
+		password:  password,
+		gs2Header: gs2Header,
+		cbData:    cbData,
+		nonce:     base64.RawStdEncoding.EncodeToString(b),
+	}
+}
+
+// clientFirst returns the client-first-message.
+func (c *scramClient) clientFirst() []byte {
+	c.clientFirstBare = "n=" + escapeSASLName(c.user) + ",r=" + c.nonce
+	return []byte(c.gs2Header + c.clientFirstBare)
+}
+
+// clientFinal returns the client-final-message for the server-first-message.
+func (c *scramClient) clientFinal(serverFirst []byte) ([]byte, error) {
+	var nonce, salt string
+	iter := -1
+	for _, attr := range strings.Split(string(serverFirst), ",") {
+		if len(attr) < 2 || attr[1] != '=' {
+			return nil, fmt.Errorf("invalid server-first-message: %q", serverFirst)
+		}
+		switch attr[0] {
+		case 'r':
+			nonce = attr[2:]
+		case 's':
+			salt = attr[2:]
+		case 'i':
+			n, err := strconv.Atoi(attr[2:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid iteration count: %w", err)
+			}
+			iter = n
+		case 'm':
+			return nil, errors.New("server sent reserved attribute 'm'")
+		}
+	}
+
+	switch {
+	case !strings.HasPrefix(nonce, c.nonce) || len(nonce) == len(c.nonce):
+		return nil, errors.New("server nonce does not match client nonce")
+	case salt == "":
+		return nil, errors.New("server sent empty salt")
+	case iter <= 0:
+		return nil, errors.New("server sent invalid iteration count")
+	}
+
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+
+	saltedPassword, err := pbkdf2.Key(sha256.New, c.password, saltBytes, iter, sha256.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	channelBinding := base64.StdEncoding.EncodeToString(append([]byte(c.gs2Header), c.cbData...))
+	clientFinalWithoutProof := "c=" + channelBinding + ",r=" + nonce
+	authMessage := []byte(c.clientFirstBare + "," + string(serverFirst) + "," + clientFinalWithoutProof)
+
+	clientKey := hmacSHA256(saltedPassword, []byte("Client Key"))
+	storedKey := sha256.Sum256(clientKey)
+	proof := hmacSHA256(storedKey[:], authMessage)
+	subtle.XORBytes(proof, proof, clientKey)
+
+	serverKey := hmacSHA256(saltedPassword, []byte("Server Key"))
+	c.serverSignature = hmacSHA256(serverKey, authMessage)
+
+	return []byte(clientFinalWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof)), nil
+}
+
+// verifyServerFinal checks the server signature in the server-final-message.
+func (c *scramClient) verifyServerFinal(serverFinal []byte) error {
+	switch {
+	case bytes.HasPrefix(serverFinal, []byte("e=")):
+		return fmt.Errorf("server error: %s", serverFinal[2:])
+	case !bytes.HasPrefix(serverFinal, []byte("v=")):
+		return fmt.Errorf("invalid server-final-message: %q", serverFinal)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(string(serverFinal[2:]))
+	if err != nil {
+		return fmt.Errorf("invalid server signature: %w", err)
+	}
+	if !hmac.Equal(sig, c.serverSignature) {
+		return errors.New("server signature does not match")
+	}
+	return nil
+}
+
+func hmacSHA256(key, msg []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(msg)
+	return h.Sum(nil)
+}
+
+func escapeSASLName(s string) string {
+	s = strings.ReplaceAll(s, "=", "=3D")
+	return strings.ReplaceAll(s, ",", "=2C")
+}
+
+// tlsServerEndPoint returns the tls-server-end-point channel binding data,
+// i.e. the hash of the server certificate as described in RFC 5929.
+func tlsServerEndPoint(state tls.ConnectionState) ([]byte, error) {
+	if len(state.PeerCertificates) == 0 {
+		return nil, errors.New("server did not send a certificate")
+	}
+	cert := state.PeerCertificates[0]
+
+	var newHash func() hash.Hash
+	switch cert.SignatureAlgorithm {
+	case x509.MD5WithRSA, x509.SHA1WithRSA, x509.ECDSAWithSHA1,
+		x509.SHA256WithRSA, x509.SHA256WithRSAPSS, x509.ECDSAWithSHA256:
+		// MD5 and SHA-1 are replaced with SHA-256.
+		newHash = sha256.New
+	case x509.SHA384WithRSA, x509.SHA384WithRSAPSS, x509.ECDSAWithSHA384:
+		newHash = sha512.New384
+	case x509.SHA512WithRSA, x509.SHA512WithRSAPSS, x509.ECDSAWithSHA512:
+		newHash = sha512.New
+	default:
+		return nil, fmt.Errorf("channel binding is not supported for %s certificates",
+			cert.SignatureAlgorithm)
+	}
+
+	h := newHash()
+	h.Write(cert.Raw)
+	return h.Sum(nil), nil
+}
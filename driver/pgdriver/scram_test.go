@@ -0,0 +1,81 @@
+package pgdriver
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCRAMClient(t *testing.T) {
+	// Test vector from RFC 7677.
+	client := newSCRAMClient("user", "pencil", gs2NoChannelBinding, nil)
+	client.nonce = "rOprNGfwEbeRWgbNEkqO"
+
+	require.Equal(t, "n,,n=user,r=rOprNGfwEbeRWgbNEkqO", string(client.clientFirst()))
+
+	resp, err := client.clientFinal([]byte(
+		"r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096"))
+	require.NoError(t, err)
+	require.Equal(t,
+		"c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,"+
+			"p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=",
+		string(resp))
+
+	err = client.verifyServerFinal([]byte("v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4="))
+	require.NoError(t, err)
+
+	err = client.verifyServerFinal([]byte("v=AAAATRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4="))
+	require.EqualError(t, err, "server signature does not match")
+
+	err = client.verifyServerFinal([]byte("e=channel-bindings-dont-match"))
+	require.EqualError(t, err, "server error: channel-bindings-dont-match")
+}
+
+func TestSCRAMClientNonce(t *testing.T) {
+	client := newSCRAMClient("user", "pencil", gs2NoChannelBinding, nil)
+	client.nonce = "abc"
+	client.clientFirst()
+
+	_, err := client.clientFinal([]byte("r=xyz123,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096"))
+	require.EqualError(t, err, "server nonce does not match client nonce")
+}
+
+func TestSCRAMClientChannelBinding(t *testing.T) {
+	cert := &x509.Certificate{
+		Raw:                []byte("certificate"),
+		SignatureAlgorithm: x509.SHA256WithRSA,
+	}
+	cbData, err := tlsServerEndPoint(tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}})
+	require.NoError(t, err)
+	sum := sha256.Sum256(cert.Raw)
+	require.Equal(t, sum[:], cbData)
+
+	client := newSCRAMClient("user", "pencil", gs2TLSServerEndPoint, cbData)
+	client.nonce = "abc"
+	require.Equal(t, "p=tls-server-end-point,,n=user,r=abc", string(client.clientFirst()))
+
+	// c= carries the GS2 header followed by the certificate hash.
+	resp, err := client.clientFinal([]byte("r=abcxyz,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096"))
+	require.NoError(t, err)
+	require.Regexp(t, `^c=cD10bHMtc2VydmVyLWVuZC1wb2ludCws[^,]+,r=abcxyz,p=`, string(resp))
+
+	cert.SignatureAlgorithm = x509.PureEd25519
+	_, err = tlsServerEndPoint(tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}})
+	require.Error(t, err)
+}
+
+func TestSASLChannelBindingRequired(t *testing.T) {
+	cn := &Conn{conf: &Config{User: "user", ChannelBinding: ChannelBindingRequire}}
+
+	_, _, err := newSASLClient(cn, true, true)
+	require.EqualError(t, err, "channel binding is required, but the connection does not use TLS")
+
+	cn.conf.ChannelBinding = ChannelBindingPrefer
+	mech, client, err := newSASLClient(cn, true, true)
+	require.NoError(t, err)
+	require.Equal(t, scramSHA256, mech)
+	require.Equal(t, gs2NoChannelBinding, client.gs2Header)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"sort"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/sqlitedialect"
@@ -31,22 +30,22 @@ func main() {
 		panic(err)
 	}
 
-	page1, cursor, err := selectNextPage(ctx, db, 0)
+	page1, cursor, err := selectPage(ctx, db, "")
 	if err != nil {
 		panic(err)
 	}
 
-	page2, cursor, err := selectNextPage(ctx, db, cursor.End)
+	page2, cursor, err := selectPage(ctx, db, cursor.Next)
 	if err != nil {
 		panic(err)
 	}
 
-	page3, cursor, err := selectNextPage(ctx, db, cursor.End)
+	page3, cursor, err := selectPage(ctx, db, cursor.Next)
 	if err != nil {
 		panic(err)
 	}
 
-	prevPage, _, err := selectPrevPage(ctx, db, cursor.Start)
+	prevPage, _, err := selectPage(ctx, db, cursor.Prev)
 	if err != nil {
 		panic(err)
 	}
@@ -66,50 +65,18 @@ func (e Entry) String() string {
 	return fmt.Sprint(e.ID)
 }
 
-// Cursor holds pointers to the first and last items on a page.
-// It is used with cursor-based pagination.
-type Cursor struct {
-	Start int64 // pointer to the first item for the previous page
-	End   int64 // pointer to the last item for the next page
-}
-
-func NewCursor(entries []Entry) Cursor {
-	if len(entries) == 0 {
-		return Cursor{}
-	}
-	return Cursor{
-		Start: entries[0].ID,
-		End:   entries[len(entries)-1].ID,
-	}
-}
-
-func selectNextPage(ctx context.Context, db *bun.DB, cursor int64) ([]Entry, Cursor, error) {
+// selectPage selects the page pointed by the cursor, which is empty for the first page.
+// The returned page holds the cursors for the next and previous pages.
+func selectPage(ctx context.Context, db *bun.DB, cursor string) ([]Entry, bun.KeysetPage, error) {
 	var entries []Entry
-	if err := db.NewSelect().
+	page, err := db.NewSelect().
 		Model(&entries).
-		Where("id > ?", cursor).
-		OrderExpr("id ASC").
-		Limit(10).
-		Scan(ctx); err != nil {
-		return nil, Cursor{}, err
-	}
-	return entries, NewCursor(entries), nil
-}
-
-func selectPrevPage(ctx context.Context, db *bun.DB, cursor int64) ([]Entry, Cursor, error) {
-	var entries []Entry
-	if err := db.NewSelect().
-		Model(&entries).
-		Where("id < ?", cursor).
-		OrderExpr("id DESC").
-		Limit(10).
-		Scan(ctx); err != nil {
-		return nil, Cursor{}, err
+		Keyset(cursor, 10, "id").
+		ScanKeyset(ctx)
+	if err != nil {
+		return nil, bun.KeysetPage{}, err
 	}
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].ID < entries[j].ID
-	})
-	return entries, NewCursor(entries), nil
+	return entries, page, nil
 }
 
 func resetDB(ctx context.Context, db *bun.DB) error {
@@ -23,7 +23,7 @@ func main() {
 	}
 	sqlite.SetMaxOpenConns(1)
 
-	db := bun.NewDB(sqlite, sqlitedialect.New())
+	db := bun.NewDB(sqlite, sqlitedialect.New(), bun.WithTenantFromContext(tenantFromContext))
 	db.AddQueryHook(bundebug.NewQueryHook(bundebug.WithVerbose(true)))
 
 	// Register models for the fixture.
@@ -36,7 +36,7 @@ func main() {
 	}
 
 	{
-		ctx := context.WithValue(ctx, "tenant_id", 1)
+		ctx := context.WithValue(ctx, tenantKey{}, int64(1))
 		stories, err := selectStories(ctx, db)
 		if err != nil {
 			panic(err)
@@ -53,17 +53,16 @@ func selectStories(ctx context.Context, db *bun.DB) ([]*Story, error) {
 	return stories, nil
 }
 
+type tenantKey struct{}
+
+func tenantFromContext(ctx context.Context) (any, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(int64)
+	return tenantID, ok
+}
+
 type Story struct {
 	ID       int64 `bun:",pk,autoincrement"`
+	TenantID int64 `bun:",tenant"`
 	Title    string
 	AuthorID int64
 }
-
-var _ bun.BeforeSelectHook = (*Story)(nil)
-
-func (s *Story) BeforeSelect(ctx context.Context, query *bun.SelectQuery) error {
-	if id := ctx.Value("tenant_id"); id != nil {
-		query.Where("author_id = ?", id)
-	}
-	return nil
-}
@@ -0,0 +1,429 @@
+// Package bunaudit keeps the history of the rows changed by INSERT, UPDATE and DELETE
+// queries in an audit table.
+//
+// Models opt in by embedding Audited, which implements the query hooks:
+//
+//	type User struct {
+//		bunaudit.Audited
+//
+//		ID   int64 `bun:",pk,autoincrement"`
+//		Name string
+//	}
+//
+//	auditor := bunaudit.New(db, bunaudit.WithActor(func(ctx context.Context) string {
+//		return userFromContext(ctx).Email
+//	}))
+//	err := auditor.CreateTable(ctx, db)
+//
+// The audit entries are written with the connection of the query, so the queries
+// that run in a transaction are audited in the same transaction.
+package bunaudit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"time"
+	"weak"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/schema"
+)
+
+const (
+	OpInsert = "INSERT"
+	OpUpdate = "UPDATE"
+	OpDelete = "DELETE"
+)
+
+// Entry is a row of the audit table. Before and After contain the column values
+// of the row: all of them for inserts and deletes and the changed ones for updates.
+type Entry struct {
+	bun.BaseModel `bun:"table:audit_log,alias:audit"`
+
+	ID        int64 `bun:",pk,autoincrement"`
+	TableName string
+	Operation string
+	Key       map[string]any
+	Before    map[string]any
+	After     map[string]any
+	Actor     string
+	CreatedAt time.Time
+}
+
+// Option configures an Auditor.
+type Option func(a *Auditor)
+
+// WithTable sets the name of the audit table, which is audit_log by default.
+func WithTable(name string) Option {
+	return func(a *Auditor) {
+		a.table = name
+	}
+}
+
+// WithActor sets the function which extracts the actor, e.g. the user which
+// runs the query, from the context of the query.
+func WithActor(fn func(ctx context.Context) string) Option {
+	return func(a *Auditor) {
+		a.actor = fn
+	}
+}
+
+// Auditor writes the audit entries of the models which embed Audited.
+type Auditor struct {
+	table string
+	actor func(ctx context.Context) string
+
+	// pending holds the rows captured before the queries run,
+	// keyed by weak pointers to the queries.
+	pending sync.Map
+}
+
+var auditors sync.Map // *sql.DB -> *Auditor
+
+// New returns the auditor of the queries which run on the database.
+func New(db *bun.DB, opts ...Option) *Auditor {
+	a := &Auditor{
+		table: "audit_log",
+		actor: func(context.Context) string { return "" },
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	auditors.Store(db.DB, a)
+	return a
+}
+
+func auditorOf(db *bun.DB) (*Auditor, error) {
+	if a, ok := auditors.Load(db.DB); ok {
+		return a.(*Auditor), nil
+	}
+	return nil, errors.New("bunaudit: the database has no auditor (use bunaudit.New)")
+}
+
+// CreateTable creates the audit table if it does not exist.
+func (a *Auditor) CreateTable(ctx context.Context, db bun.IDB) error {
+	_, err := db.NewCreateTable().
+		Model((*Entry)(nil)).
+		ModelTableExpr("?", bun.Ident(a.table)).
+		IfNotExists().
+		Exec(ctx)
+	return err
+}
+
+type pendingRows struct {
+	rows      []reflect.Value
+	returning bool // the rows are scanned into the model with RETURNING
+}
+
+// setPending stores the rows captured before the query runs. Queries which fail
+// don't run the after hooks, so the rows are dropped when the query is collected.
+func setPending[Q any](a *Auditor, q *Q, pending pendingRows) {
+	key := weak.Make(q)
+	a.pending.Store(key, pending)
+	runtime.AddCleanup(q, func(key weak.Pointer[Q]) { a.pending.Delete(key) }, key)
+}
+
+func takePending[Q any](a *Auditor, q *Q) pendingRows {
+	if v, ok := a.pending.LoadAndDelete(weak.Make(q)); ok {
+		return v.(pendingRows)
+	}
+	return pendingRows{}
+}
+
+func (a *Auditor) afterInsert(ctx context.Context, q *bun.InsertQuery) error {
+	table, rows := modelRows(q.GetModel())
+
+	entries := make([]Entry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, a.newEntry(ctx, q.DB(), table, OpInsert, reflect.Value{}, row))
+	}
+	return a.write(ctx, q.NewInsert(), entries)
+}
+
+func (a *Auditor) beforeUpdate(ctx context.Context, q *bun.UpdateQuery) error {
+	table, rows := modelRows(q.GetModel())
+
+	before, err := selectRows(ctx, q.SelectAffected(), table)
+	if err != nil {
+		return err
+	}
+
+	pending := pendingRows{rows: before}
+	if len(rows) > 0 && q.DB().HasFeature(feature.Returning) {
+		q.Returning(returningColumns(q.DB()))
+		pending.returning = true
+	}
+	setPending(a, q, pending)
+	return nil
+}
+
+func (a *Auditor) afterUpdate(ctx context.Context, q *bun.UpdateQuery) error {
+	pending := takePending(a, q)
+	if len(pending.rows) == 0 {
+		return nil
+	}
+
+	table, after := modelRows(q.GetModel())
+	if !pending.returning {
+		var err error
+		after, err = reselectRows(ctx, q.NewSelect(), table, pending.rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	db := q.DB()
+	afterByKey := make(map[string]reflect.Value, len(after))
+	for _, row := range after {
+		afterByKey[rowKey(db, table, row)] = row
+	}
+
+	entries := make([]Entry, 0, len(pending.rows))
+	for _, before := range pending.rows {
+		after, ok := afterByKey[rowKey(db, table, before)]
+		if !ok {
+			continue
+		}
+		if entry := a.newEntry(ctx, db, table, OpUpdate, before, after); len(entry.After) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+	return a.write(ctx, q.NewInsert(), entries)
+}
+
+func (a *Auditor) beforeDelete(ctx context.Context, q *bun.DeleteQuery) error {
+	table, rows := modelRows(q.GetModel())
+
+	// Soft deletes return the rows after they are updated.
+	if len(rows) > 0 && table.SoftDeleteField == nil && q.DB().HasFeature(feature.DeleteReturning) {
+		q.Returning(returningColumns(q.DB()))
+		setPending(a, q, pendingRows{returning: true})
+		return nil
+	}
+
+	before, err := selectRows(ctx, q.SelectAffected(), table)
+	if err != nil {
+		return err
+	}
+	setPending(a, q, pendingRows{rows: before})
+	return nil
+}
+
+func (a *Auditor) afterDelete(ctx context.Context, q *bun.DeleteQuery) error {
+	pending := takePending(a, q)
+
+	table, rows := modelRows(q.GetModel())
+	if !pending.returning {
+		rows = pending.rows
+	}
+
+	entries := make([]Entry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, a.newEntry(ctx, q.DB(), table, OpDelete, row, reflect.Value{}))
+	}
+	return a.write(ctx, q.NewInsert(), entries)
+}
+
+func (a *Auditor) newEntry(
+	ctx context.Context, db *bun.DB, table *schema.Table, op string, before, after reflect.Value,
+) Entry {
+	entry := Entry{
+		TableName: table.Name,
+		Operation: op,
+		Actor:     a.actor(ctx),
+		CreatedAt: time.Now(),
+	}
+
+	row := after
+	if !row.IsValid() {
+		row = before
+	}
+	entry.Key = make(map[string]any, len(table.PKs))
+	for _, f := range table.PKs {
+		entry.Key[f.Name] = f.Value(row).Interface()
+	}
+
+	switch {
+	case !before.IsValid():
+		entry.After = rowValues(table, after)
+	case !after.IsValid():
+		entry.Before = rowValues(table, before)
+	default:
+		gen := db.QueryGen()
+		for _, f := range table.Fields {
+			if string(f.AppendValue(gen, nil, before)) == string(f.AppendValue(gen, nil, after)) {
+				continue
+			}
+			if entry.Before == nil {
+				entry.Before = make(map[string]any)
+				entry.After = make(map[string]any)
+			}
+			entry.Before[f.Name] = f.Value(before).Interface()
+			entry.After[f.Name] = f.Value(after).Interface()
+		}
+	}
+	return entry
+}
+
+func (a *Auditor) write(ctx context.Context, q *bun.InsertQuery, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if _, err := q.Model(&entries).ModelTableExpr("?", bun.Ident(a.table)).Exec(ctx); err != nil {
+		return fmt.Errorf("bunaudit: %w", err)
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// modelRows returns the table and the structs of the query model.
+// Model((*T)(nil)) has no structs.
+func modelRows(model bun.Model) (*schema.Table, []reflect.Value) {
+	table := model.(bun.TableModel).Table()
+
+	v := reflect.ValueOf(model.Value())
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return table, nil
+	}
+
+	v = v.Elem()
+	if v.Kind() == reflect.Struct {
+		return table, []reflect.Value{v}
+	}
+
+	rows := make([]reflect.Value, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		rows = append(rows, elem)
+	}
+	return table, rows
+}
+
+// selectRows scans the rows selected by the query into new structs of the table.
+func selectRows(ctx context.Context, q *bun.SelectQuery, table *schema.Table) ([]reflect.Value, error) {
+	slice := reflect.New(reflect.SliceOf(table.Type))
+	if err := q.Scan(ctx, slice.Interface()); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("bunaudit: %w", err)
+	}
+
+	slice = slice.Elem()
+	rows := make([]reflect.Value, slice.Len())
+	for i := range rows {
+		rows[i] = slice.Index(i)
+	}
+	return rows, nil
+}
+
+// reselectRows selects the current values of the rows by their primary keys.
+func reselectRows(
+	ctx context.Context, q *bun.SelectQuery, table *schema.Table, rows []reflect.Value,
+) ([]reflect.Value, error) {
+	keys := reflect.MakeSlice(reflect.SliceOf(table.Type), len(rows), len(rows))
+	for i, row := range rows {
+		keys.Index(i).Set(row)
+	}
+	ptr := reflect.New(keys.Type())
+	ptr.Elem().Set(keys)
+
+	q = q.Model(ptr.Interface()).WherePK().Unscoped().WithoutTenantScope()
+	if table.SoftDeleteField != nil {
+		q = q.WhereAllWithDeleted()
+	}
+	return selectRows(ctx, q, table)
+}
+
+// returningColumns returns the columns of the model for RETURNING. They are qualified
+// with the table alias, because bulk updates also select from the _data table,
+// except in SQLite, which doesn't accept the alias in RETURNING.
+func returningColumns(db *bun.DB) string {
+	if db.Dialect().Name() == dialect.SQLite {
+		return "?Columns"
+	}
+	return "?TableColumns"
+}
+
+func rowKey(db *bun.DB, table *schema.Table, row reflect.Value) string {
+	gen := db.QueryGen()
+	var b []byte
+	for _, f := range table.PKs {
+		b = f.AppendValue(gen, b, row)
+		b = append(b, ',')
+	}
+	return string(b)
+}
+
+func rowValues(table *schema.Table, row reflect.Value) map[string]any {
+	values := make(map[string]any, len(table.Fields))
+	for _, f := range table.Fields {
+		values[f.Name] = f.Value(row).Interface()
+	}
+	return values
+}
+
+//------------------------------------------------------------------------------
+
+// Audited is embedded by the models which are audited, see the package documentation.
+// The models must not declare the hooks which Audited implements.
+type Audited struct{}
+
+var (
+	_ bun.AfterInsertHook  = (*Audited)(nil)
+	_ bun.BeforeUpdateHook = (*Audited)(nil)
+	_ bun.AfterUpdateHook  = (*Audited)(nil)
+	_ bun.BeforeDeleteHook = (*Audited)(nil)
+	_ bun.AfterDeleteHook  = (*Audited)(nil)
+)
+
+func (*Audited) AfterInsert(ctx context.Context, q *bun.InsertQuery) error {
+	a, err := auditorOf(q.DB())
+	if err != nil {
+		return err
+	}
+	return a.afterInsert(ctx, q)
+}
+
+func (*Audited) BeforeUpdate(ctx context.Context, q *bun.UpdateQuery) error {
+	a, err := auditorOf(q.DB())
+	if err != nil {
+		return err
+	}
+	return a.beforeUpdate(ctx, q)
+}
+
+func (*Audited) AfterUpdate(ctx context.Context, q *bun.UpdateQuery) error {
+	a, err := auditorOf(q.DB())
+	if err != nil {
+		return err
+	}
+	return a.afterUpdate(ctx, q)
+}
+
+func (*Audited) BeforeDelete(ctx context.Context, q *bun.DeleteQuery) error {
+	a, err := auditorOf(q.DB())
+	if err != nil {
+		return err
+	}
+	return a.beforeDelete(ctx, q)
+}
+
+func (*Audited) AfterDelete(ctx context.Context, q *bun.DeleteQuery) error {
+	a, err := auditorOf(q.DB())
+	if err != nil {
+		return err
+	}
+	return a.afterDelete(ctx, q)
+}
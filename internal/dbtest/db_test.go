@@ -275,6 +275,7 @@ func TestDB(t *testing.T) {
 		{testScanSingleRow},
 		{testScanSingleRowByRow},
 		{testScanRows},
+		{testIter},
 		{testRunInTx},
 		{testJSONInterface},
 		{testJSONValuer},
@@ -303,12 +304,18 @@ func TestDB(t *testing.T) {
 		{testJSONMarshaler},
 		{testNilDriverValue},
 		{testRunInTxAndSavepoint},
+		{testTxCallbacks},
 		{testDriverValuerReturnsItself},
 		{testNoPanicWhenReturningNullColumns},
 		{testNoForeignKeyForPrimaryKey},
 		{testWithPointerPrimaryKeyHasManyWithDriverValuer},
 		{testRelationJoinDataRace},
 		{testCloneDBStatsDataRace},
+		{testVersionLock},
+		{testKeyset},
+		{testWindow},
+		{testTenantScope},
+		{testScopes},
 	}
 
 	testEachDB(t, func(t *testing.T, dbName string, db *bun.DB) {
@@ -705,6 +712,82 @@ func testScanRows(t *testing.T, db *bun.DB) {
 	require.Equal(t, []int{3, 2, 1}, nums)
 }
 
+type IterAuthor struct {
+	ID   int64 `bun:",pk"`
+	Name string
+}
+
+type IterBook struct {
+	ID       int64 `bun:",pk"`
+	Title    string
+	AuthorID int64
+	Author   *IterAuthor `bun:"rel:belongs-to,join:author_id=id"`
+
+	Scanned bool `bun:"-"`
+}
+
+var _ bun.AfterScanRowHook = (*IterBook)(nil)
+
+func (b *IterBook) AfterScanRow(ctx context.Context) error {
+	b.Scanned = true
+	return nil
+}
+
+func testIter(t *testing.T, db *bun.DB) {
+	mustResetModel(t, ctx, db, (*IterAuthor)(nil), (*IterBook)(nil))
+
+	authors := []IterAuthor{{ID: 1, Name: "one"}, {ID: 2, Name: "two"}}
+	_, err := db.NewInsert().Model(&authors).Exec(ctx)
+	require.NoError(t, err)
+
+	books := []IterBook{
+		{ID: 1, Title: "a", AuthorID: 1},
+		{ID: 2, Title: "b", AuthorID: 2},
+		{ID: 3, Title: "c", AuthorID: 1},
+	}
+	_, err = db.NewInsert().Model(&books).Exec(ctx)
+	require.NoError(t, err)
+
+	q := db.NewSelect().Model((*IterBook)(nil)).Relation("Author").Order("iter_book.id")
+
+	var got []*IterBook
+	for book, err := range bun.Iter[IterBook](ctx, q) {
+		require.NoError(t, err)
+		got = append(got, book)
+	}
+	require.Len(t, got, 3)
+	for i, book := range got {
+		require.Equal(t, books[i].Title, book.Title)
+		require.True(t, book.Scanned)
+		require.NotNil(t, book.Author)
+		require.Equal(t, authors[book.AuthorID-1], *book.Author)
+	}
+
+	// Stopping early releases the connection.
+	var n int
+	for _, err := range bun.Iter[IterBook](ctx, db.NewSelect().Order("id")) {
+		require.NoError(t, err)
+		if n++; n == 2 {
+			break
+		}
+	}
+	require.Equal(t, 2, n)
+	require.Equal(t, 0, db.Stats().InUse)
+
+	for _, q := range []*bun.SelectQuery{
+		db.NewSelect().Model((*IterAuthor)(nil)),
+		db.NewSelect().Where("no_such_column = 1"),
+	} {
+		var errs []error
+		for book, err := range bun.Iter[IterBook](ctx, q) {
+			require.Nil(t, book)
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		require.Error(t, errs[0])
+	}
+}
+
 func testRunInTx(t *testing.T, db *bun.DB) {
 	type Counter struct {
 		Count int64
@@ -1808,6 +1891,67 @@ func testRunInTxAndSavepoint(t *testing.T, db *bun.DB) {
 	require.Equal(t, 4, count)
 }
 
+func testTxCallbacks(t *testing.T, db *bun.DB) {
+	var events []string
+	record := func(event string) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			events = append(events, event)
+		}
+	}
+
+	err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		tx.OnCommit(record("commit"))
+		tx.OnRollback(record("rollback"))
+
+		err := tx.RunInTx(ctx, nil, func(ctx context.Context, sp bun.Tx) error {
+			sp.OnCommit(record("released commit"))
+			sp.OnRollback(record("released rollback"))
+			return sp.RunInTx(ctx, nil, func(ctx context.Context, subSp bun.Tx) error {
+				subSp.OnCommit(record("nested commit"))
+				return nil
+			})
+		})
+		require.NoError(t, err)
+
+		_ = tx.RunInTx(ctx, nil, func(ctx context.Context, sp bun.Tx) error {
+			sp.OnCommit(record("discarded commit"))
+			sp.OnRollback(record("discarded rollback"))
+			return errors.New("fake error")
+		})
+
+		// Callbacks run only after the outermost transaction commits.
+		require.Empty(t, events)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"commit", "released commit", "nested commit"}, events)
+
+	events = nil
+	err = db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		tx.OnCommit(record("commit"))
+		tx.OnRollback(record("rollback"))
+
+		err := tx.RunInTx(ctx, nil, func(ctx context.Context, sp bun.Tx) error {
+			sp.OnRollback(record("released rollback"))
+			return nil
+		})
+		require.NoError(t, err)
+
+		return errors.New("fake error")
+	})
+	require.Error(t, err)
+	require.Equal(t, []string{"rollback", "released rollback"}, events)
+
+	events = nil
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	tx.OnRollback(record("rollback"))
+	require.NoError(t, tx.Rollback())
+	// Rolling back the finished transaction does not run the callbacks again.
+	require.Equal(t, sql.ErrTxDone, tx.Rollback())
+	require.Equal(t, []string{"rollback"}, events)
+}
+
 type anotherString string
 
 var _ driver.Valuer = (*anotherString)(nil)
@@ -1917,6 +2061,102 @@ func mustDropTableOnCleanup(tb testing.TB, ctx context.Context, db *bun.DB, mode
 	})
 }
 
+type sqlStateError string
+
+func (err sqlStateError) Error() string    { return "SQLSTATE " + string(err) }
+func (err sqlStateError) SQLState() string { return string(err) }
+
+func TestRunInTxWithRetry(t *testing.T) {
+	type Counter struct {
+		ID int64 `bun:",pk"`
+		N  int
+	}
+
+	db := sqlite(t)
+	mustResetModel(t, ctx, db, (*Counter)(nil))
+	_, err := db.NewInsert().Model(&Counter{ID: 1}).Exec(ctx)
+	require.NoError(t, err)
+
+	noBackoff := bun.WithTxBackoff(func(int) time.Duration { return 0 })
+	increment := func(failures int, fail error) func(ctx context.Context, tx bun.Tx) error {
+		var attempts int
+		return func(ctx context.Context, tx bun.Tx) error {
+			attempts++
+			if _, err := tx.NewUpdate().Model((*Counter)(nil)).Set("n = n + 1").Where("id = 1").Exec(ctx); err != nil {
+				return err
+			}
+			if attempts <= failures {
+				return fmt.Errorf("update counter: %w", fail)
+			}
+			return nil
+		}
+	}
+	counter := func() int {
+		c := new(Counter)
+		require.NoError(t, db.NewSelect().Model(c).Where("id = 1").Scan(ctx))
+		return c.N
+	}
+
+	t.Run("retries serialization failures", func(t *testing.T) {
+		fn := increment(2, sqlStateError("40001"))
+		err := db.RunInTxWithRetry(ctx, nil, fn, noBackoff)
+		require.NoError(t, err)
+		// The failed attempts are rolled back.
+		require.Equal(t, 1, counter())
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		var attempts int
+		err := db.RunInTxWithRetry(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			attempts++
+			return sqlStateError("40P01")
+		}, noBackoff, bun.WithTxMaxAttempts(5))
+		require.Equal(t, sqlStateError("40P01"), err)
+		require.Equal(t, 5, attempts)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		var attempts int
+		errOther := errors.New("other")
+		err := db.RunInTxWithRetry(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			attempts++
+			return errOther
+		}, noBackoff)
+		require.Equal(t, errOther, err)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("custom classifier", func(t *testing.T) {
+		var attempts int
+		errDeadlock := errors.New("Error 1213: Deadlock found when trying to get lock")
+		err := db.RunInTxWithRetry(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			attempts++
+			if attempts == 1 {
+				return errDeadlock
+			}
+			return nil
+		}, noBackoff, bun.WithTxRetryable(func(err error) bool {
+			return errors.Is(err, errDeadlock)
+		}))
+		require.NoError(t, err)
+		require.Equal(t, 2, attempts)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var attempts int
+		err := db.RunInTxWithRetry(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			attempts++
+			cancel()
+			return sqlStateError("40001")
+		}, bun.WithTxBackoff(func(int) time.Duration { return time.Hour }))
+		require.Equal(t, sqlStateError("40001"), err)
+		require.Equal(t, 1, attempts)
+	})
+}
+
 func TestConnResolver(t *testing.T) {
 	dsn := os.Getenv("PG")
 	if dsn == "" {
@@ -2064,3 +2304,449 @@ func testCloneDBStatsDataRace(_ *testing.T, db *bun.DB) {
 			Scan(ctx, &num)
 	}()
 }
+
+func testVersionLock(t *testing.T, db *bun.DB) {
+	type Document struct {
+		bun.BaseModel `bun:"table:versioned_documents"`
+		ID            int64 `bun:",pk,autoincrement"`
+		Title         string
+		Version       int64 `bun:",version"`
+	}
+
+	mustResetModel(t, ctx, db, (*Document)(nil))
+
+	doc := &Document{Title: "draft", Version: 1}
+	_, err := db.NewInsert().Model(doc).Exec(ctx)
+	require.NoError(t, err)
+
+	stale := *doc
+
+	doc.Title = "review"
+	_, err = db.NewUpdate().Model(doc).WherePK().Exec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), doc.Version)
+
+	stale.Title = "lost update"
+	_, err = db.NewUpdate().Model(&stale).WherePK().Exec(ctx)
+	require.ErrorIs(t, err, bun.ErrStaleObject)
+	require.Equal(t, int64(1), stale.Version)
+
+	// RETURNING must not increment the version twice.
+	if db.HasFeature(feature.Returning) {
+		_, err = db.NewUpdate().Model(doc).WherePK().Returning("*").Exec(ctx)
+	} else {
+		_, err = db.NewUpdate().Model(doc).WherePK().Exec(ctx)
+	}
+	require.NoError(t, err)
+	require.Equal(t, int64(3), doc.Version)
+
+	other := &Document{Title: "other", Version: 1}
+	_, err = db.NewInsert().Model(other).Exec(ctx)
+	require.NoError(t, err)
+
+	if db.HasFeature(feature.CTE) {
+		docs := []Document{*doc, *other}
+		docs[0].Title = "final"
+		docs[1].Title = "other final"
+		_, err = db.NewUpdate().Model(&docs).Bulk().Exec(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(4), docs[0].Version)
+		require.Equal(t, int64(2), docs[1].Version)
+
+		// One of the rows is stale.
+		docs[1].Version = 1
+		_, err = db.NewUpdate().Model(&docs).Bulk().Exec(ctx)
+		require.ErrorIs(t, err, bun.ErrStaleObject)
+
+		var versions []int64
+		require.NoError(t, db.NewSelect().Model((*Document)(nil)).Column("version").Order("id").Scan(ctx, &versions))
+		require.Equal(t, []int64{5, 2}, versions)
+	}
+
+	_, err = db.NewDelete().Model(&stale).WherePK().Exec(ctx)
+	require.ErrorIs(t, err, bun.ErrStaleObject)
+
+	err = db.NewSelect().Model(doc).WherePK().Scan(ctx)
+	require.NoError(t, err)
+	_, err = db.NewDelete().Model(doc).WherePK().Exec(ctx)
+	require.NoError(t, err)
+}
+
+func testKeyset(t *testing.T, db *bun.DB) {
+	type Event struct {
+		bun.BaseModel `bun:"table:keyset_events"`
+		ID            int64 `bun:",pk"`
+		Priority      int
+	}
+
+	mustResetModel(t, ctx, db, (*Event)(nil))
+
+	// Ordered by priority DESC, id ASC: 2, 5, 8, 1, 4, 7, 3, 6, 9.
+	var events []Event
+	for i := 1; i <= 9; i++ {
+		events = append(events, Event{ID: int64(i), Priority: i % 3})
+	}
+	_, err := db.NewInsert().Model(&events).Exec(ctx)
+	require.NoError(t, err)
+
+	scan := func(cursor string) ([]int64, bun.KeysetPage) {
+		var events []Event
+		page, err := db.NewSelect().
+			Model(&events).
+			Keyset(cursor, 4, "priority DESC", "id").
+			ScanKeyset(ctx)
+		require.NoError(t, err)
+
+		ids := make([]int64, len(events))
+		for i := range events {
+			ids[i] = events[i].ID
+		}
+		return ids, page
+	}
+
+	ids, page1 := scan("")
+	require.Equal(t, []int64{2, 5, 8, 1}, ids)
+	require.NotEmpty(t, page1.Next)
+	require.Empty(t, page1.Prev)
+
+	ids, page2 := scan(page1.Next)
+	require.Equal(t, []int64{4, 7, 3, 6}, ids)
+	require.NotEmpty(t, page2.Next)
+	require.NotEmpty(t, page2.Prev)
+
+	ids, page3 := scan(page2.Next)
+	require.Equal(t, []int64{9}, ids)
+	require.Empty(t, page3.Next)
+	require.NotEmpty(t, page3.Prev)
+
+	ids, page := scan(page3.Prev)
+	require.Equal(t, []int64{4, 7, 3, 6}, ids)
+	require.Equal(t, page2, page)
+
+	ids, page = scan(page2.Prev)
+	require.Equal(t, []int64{2, 5, 8, 1}, ids)
+	require.Empty(t, page.Prev)
+	require.Equal(t, page1.Next, page.Next)
+}
+
+func testWindow(t *testing.T, db *bun.DB) {
+	switch db.Dialect().Name() {
+	case dialect.MySQL:
+		if !db.HasFeature(feature.CTE) {
+			t.Skip("mysql does not support window functions before 8.0")
+		}
+	case dialect.MSSQL:
+		t.Skip("mssql does not support the WINDOW clause")
+	}
+
+	type Payment struct {
+		bun.BaseModel `bun:"table:window_payments"`
+		ID            int64 `bun:",pk"`
+		UserID        int64
+		Status        string
+		Amount        int
+	}
+
+	mustResetModel(t, ctx, db, (*Payment)(nil))
+
+	payments := []Payment{
+		{ID: 1, UserID: 1, Status: "paid", Amount: 10},
+		{ID: 2, UserID: 1, Status: "refunded", Amount: 5},
+		{ID: 3, UserID: 1, Status: "paid", Amount: 20},
+		{ID: 4, UserID: 2, Status: "paid", Amount: 7},
+		{ID: 5, UserID: 2, Status: "paid", Amount: 7},
+	}
+	_, err := db.NewInsert().Model(&payments).Exec(ctx)
+	require.NoError(t, err)
+
+	type Row struct {
+		ID           int64
+		AmountRank   int
+		RunningTotal int
+		PaidCount    int
+		PaidTotal    int
+	}
+
+	// Without FILTER the aggregates fall back to CASE WHEN.
+	dbs := []*bun.DB{db}
+	if db.Dialect().Name() == dialect.SQLite {
+		dbs = append(dbs, bun.NewDB(db.DB, sqlitedialect.New(sqlitedialect.WithoutFeature(feature.AggregateFilter))))
+	}
+
+	for _, db := range dbs {
+		var rows []Row
+		err := db.NewSelect().
+			Model((*Payment)(nil)).
+			Column("id").
+			ColumnExpr("? AS amount_rank", bun.NewFunc("rank", "").
+				Over(bun.NewWindow().PartitionBy("user_id").OrderBy("amount DESC"))).
+			ColumnExpr("? AS running_total", bun.NewFunc("sum", "?", bun.Ident("amount")).
+				Over(bun.NewWindow().Base("w").Rows(bun.UnboundedPreceding, bun.CurrentRow))).
+			ColumnExpr("? AS paid_count", bun.NewFunc("count", "*").Filter("status = ?", "paid").OverWindow("u")).
+			ColumnExpr("? AS paid_total", bun.NewFunc("sum", "?", bun.Ident("amount")).Filter("status = ?", "paid").OverWindow("u")).
+			Window("u", bun.NewWindow().PartitionBy("user_id")).
+			Window("w", bun.NewWindow().PartitionBy("user_id").OrderBy("id")).
+			Order("id").
+			Scan(ctx, &rows)
+		require.NoError(t, err)
+		require.Equal(t, []Row{
+			{ID: 1, AmountRank: 2, RunningTotal: 10, PaidCount: 2, PaidTotal: 30},
+			{ID: 2, AmountRank: 3, RunningTotal: 15, PaidCount: 2, PaidTotal: 30},
+			{ID: 3, AmountRank: 1, RunningTotal: 35, PaidCount: 2, PaidTotal: 30},
+			{ID: 4, AmountRank: 1, RunningTotal: 7, PaidCount: 2, PaidTotal: 14},
+			{ID: 5, AmountRank: 1, RunningTotal: 14, PaidCount: 2, PaidTotal: 14},
+		}, rows)
+
+		var refundedUsers int
+		err = db.NewSelect().
+			Model((*Payment)(nil)).
+			ColumnExpr("?", bun.NewFunc("count", "DISTINCT ?", bun.Ident("user_id")).Filter("status = ?", "refunded")).
+			Scan(ctx, &refundedUsers)
+		require.NoError(t, err)
+		require.Equal(t, 1, refundedUsers)
+
+		if !db.HasFeature(feature.AggregateFilter) {
+			query := db.NewSelect().
+				Model((*Payment)(nil)).
+				ColumnExpr("?", bun.NewFunc("count", "DISTINCT ?", bun.Ident("user_id")).Filter("status = ?", "paid")).
+				String()
+			require.Contains(t, query, "count(DISTINCT CASE WHEN status = 'paid' THEN ")
+		}
+	}
+}
+
+func testTenantScope(t *testing.T, db *bun.DB) {
+	type tenantKey struct{}
+
+	type Author struct {
+		bun.BaseModel `bun:"table:tenant_authors"`
+		ID            int64 `bun:",pk"`
+		TenantID      int64 `bun:",tenant"`
+		Name          string
+	}
+
+	type Story struct {
+		bun.BaseModel `bun:"table:tenant_stories"`
+		ID            int64 `bun:",pk"`
+		TenantID      int64 `bun:",tenant"`
+		Title         string
+		AuthorID      int64
+		Author        *Author `bun:"rel:belongs-to,join:author_id=id"`
+	}
+
+	db = bun.NewDB(db.DB, db.Dialect(), bun.WithTenantFromContext(
+		func(ctx context.Context) (any, bool) {
+			tenant, ok := ctx.Value(tenantKey{}).(int64)
+			return tenant, ok
+		}))
+	mustResetModel(t, ctx, db, (*Author)(nil), (*Story)(nil))
+
+	ctx1 := context.WithValue(ctx, tenantKey{}, int64(1))
+	ctx2 := context.WithValue(ctx, tenantKey{}, int64(2))
+
+	// Inserts get the tenant from the context.
+	_, err := db.NewInsert().Model(&[]Author{{ID: 1, Name: "one"}, {ID: 2, Name: "two"}}).Exec(ctx1)
+	require.NoError(t, err)
+	_, err = db.NewInsert().Model(&[]Story{{ID: 1, Title: "one", AuthorID: 1}, {ID: 2, Title: "two", AuthorID: 2}}).Exec(ctx1)
+	require.NoError(t, err)
+	// The author of story 3 belongs to the other tenant.
+	_, err = db.NewInsert().Model(&Story{ID: 3, TenantID: 1, Title: "three", AuthorID: 1}).Exec(ctx2)
+	require.NoError(t, err)
+
+	var stories []Story
+	err = db.NewSelect().Model(&stories).WithoutTenantScope().Order("id").Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, stories, 3)
+	require.Equal(t, []int64{1, 1, 2}, []int64{stories[0].TenantID, stories[1].TenantID, stories[2].TenantID})
+
+	stories = nil
+	err = db.NewSelect().Model(&stories).Relation("Author").Order("story.id").Scan(ctx1)
+	require.NoError(t, err)
+	require.Len(t, stories, 2)
+	require.Equal(t, "one", stories[0].Author.Name)
+
+	stories = nil
+	err = db.NewSelect().Model(&stories).Relation("Author").Scan(ctx2)
+	require.NoError(t, err)
+	require.Len(t, stories, 1)
+	require.Nil(t, stories[0].Author)
+
+	// Subqueries are scoped to the tenant of the outer query.
+	count, err := db.NewSelect().
+		Model((*Author)(nil)).
+		Where("id IN (?)", db.NewSelect().Model((*Story)(nil)).Column("author_id")).
+		WithoutTenantScope().
+		Count(ctx2)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	err = db.NewSelect().Model(&stories).Scan(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Story is tenant-scoped")
+
+	_, err = db.NewInsert().Model(&Story{ID: 4}).Exec(ctx)
+	require.Error(t, err)
+
+	// Updates neither touch nor move the rows of other tenants.
+	res, err := db.NewUpdate().Model(&Story{ID: 3, TenantID: 1, Title: "updated"}).WherePK().Exec(ctx1)
+	require.NoError(t, err)
+	n, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	res, err = db.NewUpdate().Model(&Story{ID: 3, TenantID: 1, Title: "updated"}).WherePK().Exec(ctx2)
+	require.NoError(t, err)
+	n, err = res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	story := new(Story)
+	err = db.NewSelect().Model(story).Where("id = 3").Scan(ctx2)
+	require.NoError(t, err)
+	require.Equal(t, "updated", story.Title)
+	require.Equal(t, int64(2), story.TenantID)
+
+	res, err = db.NewDelete().Model((*Story)(nil)).Where("1 = 1").Exec(ctx2)
+	require.NoError(t, err)
+	n, err = res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	count, err = db.NewSelect().Model((*Story)(nil)).Count(ctx1)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	// Inserts set the tenant even if the column is not selected and write it back to the model.
+	author := &Author{ID: 3, TenantID: 1, Name: "three"}
+	_, err = db.NewInsert().Model(author).Column("id", "name").Exec(ctx2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), author.TenantID)
+
+	count, err = db.NewSelect().Model((*Author)(nil)).Where("id = 3").Count(ctx2)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	_, err = db.NewInsert().Model(&Author{ID: 4, Name: "four"}).Value("tenant_id", "?", 1).Exec(ctx2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Value can't override column tenant_id")
+
+	require.NotPanics(t, func() {
+		_ = db.NewInsert().Model(&Author{ID: 4, Name: "four"}).String()
+	})
+
+	// Bulk updates and upserts don't move the rows to another tenant.
+	if db.HasFeature(feature.CTE) {
+		_, err = db.NewUpdate().Model(&[]Author{{ID: 3, TenantID: 1, Name: "bulk"}}).Bulk().Exec(ctx2)
+		require.NoError(t, err)
+	}
+
+	switch {
+	case db.HasFeature(feature.InsertOnConflict):
+		_, err = db.NewInsert().Model(&Author{ID: 3, Name: "upsert"}).On("CONFLICT (id) DO UPDATE").Exec(ctx1)
+		require.NoError(t, err)
+	case db.HasFeature(feature.InsertOnDuplicateKey):
+		_, err = db.NewInsert().Model(&Author{ID: 3, Name: "upsert"}).On("DUPLICATE KEY UPDATE").Exec(ctx1)
+		require.NoError(t, err)
+	}
+
+	author = new(Author)
+	err = db.NewSelect().Model(author).Where("id = 3").WithoutTenantScope().Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), author.TenantID)
+}
+
+type ScopeBlog struct {
+	ID       int64 `bun:",pk"`
+	Name     string
+	Archived bool
+	Posts    []*ScopePost `bun:"rel:has-many,join:id=blog_id"`
+}
+
+var _ bun.DefaultScoper = (*ScopeBlog)(nil)
+
+func (*ScopeBlog) DefaultScopes() []bun.Scope {
+	return []bun.Scope{bun.NewScope("active", "?TableAlias.archived = ?", false)}
+}
+
+type ScopePost struct {
+	ID        int64 `bun:",pk"`
+	BlogID    int64
+	Blog      *ScopeBlog `bun:"rel:belongs-to,join:blog_id=id"`
+	Published bool
+}
+
+func testScopes(t *testing.T, db *bun.DB) {
+	if db.Dialect().Name() == dialect.MSSQL {
+		t.Skip("mssql does not support boolean literals")
+	}
+
+	db = bun.NewDB(db.DB, db.Dialect())
+	db.RegisterScope((*ScopePost)(nil), "published", "?TableAlias.published = ?", true)
+	mustResetModel(t, ctx, db, (*ScopeBlog)(nil), (*ScopePost)(nil))
+
+	_, err := db.NewInsert().Model(&[]ScopeBlog{
+		{ID: 1, Name: "active"},
+		{ID: 2, Name: "archived", Archived: true},
+	}).Exec(ctx)
+	require.NoError(t, err)
+	_, err = db.NewInsert().Model(&[]ScopePost{
+		{ID: 1, BlogID: 1, Published: true},
+		{ID: 2, BlogID: 1},
+		{ID: 3, BlogID: 2, Published: true},
+	}).Exec(ctx)
+	require.NoError(t, err)
+
+	quote := string(db.Dialect().IdentQuote())
+	query := strings.ReplaceAll(db.NewSelect().Model((*ScopePost)(nil)).Relation("Blog").String(), quote, `"`)
+	require.Contains(t, query, `LEFT JOIN "scope_blogs" AS "blog" ON ("blog"."id" = "scope_post"."blog_id") AND ("blog".archived = FALSE)`)
+	require.Contains(t, query, `WHERE ("scope_post".published = TRUE)`)
+
+	postIDs := func(posts []*ScopePost) []int64 {
+		ids := make([]int64, len(posts))
+		for i, post := range posts {
+			ids[i] = post.ID
+		}
+		return ids
+	}
+
+	var posts []*ScopePost
+	err = db.NewSelect().Model(&posts).Relation("Blog").Order("scope_post.id").Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 3}, postIDs(posts))
+	require.NotNil(t, posts[0].Blog)
+	require.Nil(t, posts[1].Blog)
+
+	posts = nil
+	err = db.NewSelect().Model(&posts).Unscoped("published").Order("id").Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3}, postIDs(posts))
+
+	// Has-many relations are scoped too.
+	var blogs []*ScopeBlog
+	err = db.NewSelect().Model(&blogs).Relation("Posts").Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, blogs, 1)
+	require.Equal(t, []int64{1}, postIDs(blogs[0].Posts))
+
+	blogs = nil
+	err = db.NewSelect().Model(&blogs).Relation("Posts").Unscoped().Order("scope_blog.id").Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, blogs, 2)
+	require.Equal(t, []int64{1, 2}, postIDs(blogs[0].Posts))
+
+	res, err := db.NewUpdate().Model((*ScopePost)(nil)).Set("blog_id = 2").Where("blog_id = 1").Exec(ctx)
+	require.NoError(t, err)
+	n, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	res, err = db.NewDelete().Model((*ScopePost)(nil)).Where("blog_id = 2").Exec(ctx)
+	require.NoError(t, err)
+	n, err = res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	count, err := db.NewSelect().Model((*ScopePost)(nil)).Unscoped("published").Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/sqltype"
 	"github.com/uptrace/bun/migrate/sqlschema"
 	"github.com/uptrace/bun/schema"
@@ -73,6 +74,13 @@ type Journalist struct {
 
 func TestDatabaseInspector_Inspect(t *testing.T) {
 	testEachDB(t, func(t *testing.T, dbName string, db *bun.DB) {
+		switch db.Dialect().Name() {
+		case dialect.SQLite:
+			t.Skip("sqlite does not support CREATE SCHEMA")
+		case dialect.MySQL:
+			t.Skip("mysql schemas are separate databases")
+		}
+
 		defaultSchema := db.Dialect().DefaultSchema()
 
 		for _, tt := range []struct {
@@ -423,6 +431,10 @@ func cmpColumns(
 		if wantCol.IsIdentity != gotCol.IsIdentity {
 			errorf("IsIdentity:\n\t(+want)\t%t\n\t(-got)\t%t", wantCol.IsIdentity, gotCol.IsIdentity)
 		}
+
+		if wantCol.IsGenerated != gotCol.IsGenerated {
+			errorf("IsGenerated:\n\t(+want)\t%t\n\t(-got)\t%t", wantCol.IsGenerated, gotCol.IsGenerated)
+		}
 	}
 
 	if len(missing) > 0 {
@@ -464,6 +476,36 @@ func cmpConstraints(tb testing.TB, want, got *sqlschema.BaseTable) {
 		return
 	}
 	require.ElementsMatch(tb, stripNames(want.UniqueConstraints), stripNames(got.UniqueConstraints), "table %q does not have expected unique constraints (listA=want, listB=got)", want.Name)
+
+	indexNames := func(indexes []sqlschema.Index) (res []string) {
+		for _, idx := range indexes {
+			res = append(res, idx.Name)
+		}
+		return
+	}
+	require.ElementsMatch(tb, indexNames(want.Indexes), indexNames(got.Indexes), "table %q does not have expected indexes (listA=want, listB=got)", want.Name)
+	for _, wantIdx := range want.Indexes {
+		for _, gotIdx := range got.Indexes {
+			if gotIdx.Name == wantIdx.Name {
+				require.Truef(tb, wantIdx.Equals(gotIdx), "table %q has wrong index definition:\nwant: %+v\n got: %+v", want.Name, wantIdx, gotIdx)
+			}
+		}
+	}
+
+	checkNames := func(checks []sqlschema.Check) (res []string) {
+		for _, check := range checks {
+			res = append(res, check.Name)
+		}
+		return
+	}
+	require.ElementsMatch(tb, checkNames(want.CheckConstraints), checkNames(got.CheckConstraints), "table %q does not have expected check constraints (listA=want, listB=got)", want.Name)
+	for _, wantCheck := range want.CheckConstraints {
+		for _, gotCheck := range got.CheckConstraints {
+			if gotCheck.Name == wantCheck.Name {
+				require.Truef(tb, wantCheck.Equals(gotCheck), "table %q has wrong check constraint:\nwant: %+v\n got: %+v", want.Name, wantCheck, gotCheck)
+			}
+		}
+	}
 }
 
 func tableNames(tables []sqlschema.Table) (names []string) {
@@ -6,12 +6,15 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/dialect/sqltype"
 	"github.com/uptrace/bun/migrate"
 	"github.com/uptrace/bun/migrate/sqlschema"
@@ -422,8 +425,12 @@ func TestAutoMigrator_CreateSQLMigrations(t *testing.T) {
 
 			require.Len(t, migrations, 2, "expected up/down migration pair")
 			require.DirExists(t, migrationsDir)
-			checkMigrationFileContains(t, "_auto.tx.up.sql", "CREATE TABLE", "SET statement_timeout = 0")
-			checkMigrationFileContains(t, "_auto.tx.down.sql", "DROP TABLE", "SET statement_timeout = 0")
+			checkMigrationFileContains(t, "_auto.tx.up.sql", "CREATE TABLE")
+			checkMigrationFileContains(t, "_auto.tx.down.sql", "DROP TABLE")
+			if db.Dialect().Name() == dialect.PG {
+				checkMigrationFileContains(t, "_auto.tx.up.sql", "SET statement_timeout = 0")
+				checkMigrationFileContains(t, "_auto.tx.down.sql", "SET statement_timeout = 0")
+			}
 		})
 	})
 }
@@ -515,6 +522,13 @@ func TestAutoMigrator_Migrate(t *testing.T) {
 		{testAddDropColumn},
 		{testUnique},
 		{testUniqueRenamedTable},
+		{testDropUndeclaredIndex},
+		{testIndexes},
+		{testChecks},
+		{testComments},
+		{testEnums},
+		{testKeepUndeclaredEnum},
+		{testGeneratedColumns},
 		{testUpdatePrimaryKeys},
 		{testNothingToMigrate},
 		{testExcludeForeignKeys},
@@ -564,6 +578,11 @@ func testRenameTable(t *testing.T, db *bun.DB) {
 }
 
 func testCreateDropTable(t *testing.T, db *bun.DB) {
+	switch db.Dialect().Name() {
+	case dialect.SQLite, dialect.MySQL:
+		t.Skip(db.Dialect().Name().String() + " does not have gen_random_uuid()")
+	}
+
 	type DropMe struct {
 		bun.BaseModel `bun:"table:dropme"`
 		Foo           int `bun:"foo,identity"`
@@ -741,6 +760,11 @@ func checkHasColumn(t *testing.T, table sqlschema.Table, name string) {
 // testChangeColumnType_AutoCast checks type changes which can be type-casted automatically,
 // i.e. do not require supplying a USING clause (pgdialect).
 func testChangeColumnType_AutoCast(t *testing.T, db *bun.DB) {
+	switch db.Dialect().Name() {
+	case dialect.SQLite, dialect.MySQL:
+		t.Skip(db.Dialect().Name().String() + " does not have identity columns and gen_random_uuid()")
+	}
+
 	type TableBefore struct {
 		bun.BaseModel `bun:"table:change_me_own_type"`
 
@@ -835,6 +859,10 @@ func testChangeColumnType_AutoCast(t *testing.T, db *bun.DB) {
 }
 
 func testIdentity(t *testing.T, db *bun.DB) {
+	if !db.Dialect().Features().Has(feature.GeneratedIdentity) {
+		t.Skip("identity columns are not supported")
+	}
+
 	type TableBefore struct {
 		bun.BaseModel `bun:"table:bourne_identity"`
 		A             int64 `bun:",notnull,identity"`
@@ -880,6 +908,13 @@ func testIdentity(t *testing.T, db *bun.DB) {
 }
 
 func testAddDropColumn(t *testing.T, db *bun.DB) {
+	switch db.Dialect().Name() {
+	case dialect.SQLite:
+		t.Skip("sqlite only allows AUTOINCREMENT for INTEGER PRIMARY KEY")
+	case dialect.MySQL:
+		t.Skip("mysql only allows AUTO_INCREMENT for key columns")
+	}
+
 	type TableBefore struct {
 		bun.BaseModel `bun:"table:column_madness"`
 		DoNotTouch    string `bun:"do_not_touch"`
@@ -1011,7 +1046,433 @@ func testUnique(t *testing.T, db *bun.DB) {
 	cmpTables(t, db.Dialect().(sqlschema.InspectorDialect), wantTables, state.GetTables())
 }
 
+func testDropUndeclaredIndex(t *testing.T, db *bun.DB) {
+	type Store struct {
+		bun.BaseModel `bun:"table:indexed_stores"`
+		ID            int64  `bun:",pk"`
+		Name          string `bun:",unique"`
+		City          string `bun:"type:varchar(100)"`
+	}
+
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	mustResetModel(t, ctx, db, (*Store)(nil))
+	_, err := db.NewCreateIndex().Model((*Store)(nil)).Index("indexed_stores_city_idx").Column("city").Exec(ctx)
+	require.NoError(t, err)
+
+	tables := inspect(ctx).GetTables()
+	checkHasTable(t, tables, "indexed_stores")
+	for _, table := range tables {
+		if table.GetName() == "indexed_stores" {
+			require.Equal(t, []sqlschema.Index{{Name: "indexed_stores_city_idx", Columns: []string{"city"}}}, sqlschema.TableIndexes(table))
+		}
+	}
+
+	getIndexes := func() []sqlschema.Index {
+		for _, table := range inspect(ctx).GetTables() {
+			if table.GetName() == "indexed_stores" {
+				return sqlschema.TableIndexes(table)
+			}
+		}
+		require.Fail(t, "table indexed_stores not found")
+		return nil
+	}
+
+	// Indexes which are not declared by the model are kept by default.
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*Store)(nil)))
+	_, err = m.Migrate(ctx) // do not use runMigrations because we do not expect any files to be created
+	require.NoError(t, err, "auto migration failed")
+	require.Len(t, getIndexes(), 1)
+
+	// Act
+	m = newAutoMigratorOrSkip(t, db, migrate.WithModel((*Store)(nil)), migrate.WithManageIndexes())
+	runMigrations(t, m)
+
+	// Assert: the index is dropped, while the one backing the UNIQUE constraint is not reported.
+	require.Empty(t, getIndexes())
+}
+
+func testIndexes(t *testing.T, db *bun.DB) {
+	type StoreBefore struct {
+		bun.BaseModel `bun:"table:indexed_stores"`
+		ID            int64  `bun:",pk"`
+		Name          string `bun:"type:varchar(100),index:indexed_stores_owner_idx"`
+		Owner         string `bun:"type:varchar(100),index:indexed_stores_owner_idx"`
+		City          string `bun:"type:varchar(100),index"`
+	}
+
+	type StoreAfter struct {
+		bun.BaseModel `bun:"table:indexed_stores"`
+		ID            int64  `bun:",pk"`
+		Name          string `bun:"type:varchar(100),index"`
+		Owner         string `bun:"type:varchar(100),index:indexed_stores_owner_idx"` // shrink index
+		City          string `bun:"type:varchar(100)"`                                // drop index
+	}
+
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	mustDropTableOnCleanup(t, ctx, db, (*StoreBefore)(nil))
+	_, err := db.NewCreateTable().Model((*StoreBefore)(nil)).WithIndexes().Exec(ctx)
+	require.NoError(t, err)
+
+	getIndexes := func() []sqlschema.Index {
+		for _, table := range inspect(ctx).GetTables() {
+			if table.GetName() == "indexed_stores" {
+				return sqlschema.TableIndexes(table)
+			}
+		}
+		require.Fail(t, "table indexed_stores not found")
+		return nil
+	}
+	require.ElementsMatch(t, []sqlschema.Index{
+		{Name: "indexed_stores_city_idx", Columns: []string{"city"}},
+		{Name: "indexed_stores_owner_idx", Columns: []string{"name", "owner"}},
+	}, getIndexes())
+
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*StoreAfter)(nil)), migrate.WithManageIndexes())
+
+	// Act
+	runMigrations(t, m)
+
+	// Assert
+	require.ElementsMatch(t, []sqlschema.Index{
+		{Name: "indexed_stores_name_idx", Columns: []string{"name"}},
+		{Name: "indexed_stores_owner_idx", Columns: []string{"owner"}},
+	}, getIndexes())
+
+	group, err := m.Migrate(ctx)
+	require.NoError(t, err)
+	require.True(t, group.IsZero(), "unexpected migrations: %v", group)
+}
+
+func testChecks(t *testing.T, db *bun.DB) {
+	type ProductBefore struct {
+		bun.BaseModel `bun:"table:checked_products,check:discount < price"`
+		ID            int64 `bun:",pk"`
+		Price         int64 `bun:",check:price > 0"`
+		Discount      int64
+		Stock         int64 `bun:",check:stock >= 0"`
+	}
+
+	type ProductAfter struct {
+		bun.BaseModel `bun:"table:checked_products,check:discount < price"`
+		ID            int64 `bun:",pk"`
+		Price         int64 `bun:",check:price > 0"`
+		Discount      int64 `bun:",check:discount >= 0"` // add constraint
+		Stock         int64 // drop constraint
+	}
+
+	type ProductUnchecked struct {
+		bun.BaseModel `bun:"table:checked_products,check:discount < price"`
+		ID            int64 `bun:",pk"`
+		Price         int64 `bun:",check:price > 0"`
+		Discount      int64
+		Stock         int64
+	}
+
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	mustDropTableOnCleanup(t, ctx, db, (*ProductBefore)(nil))
+	_, err := db.NewCreateTable().Model((*ProductBefore)(nil)).Exec(ctx)
+	require.NoError(t, err)
+
+	getChecks := func() []sqlschema.Check {
+		for _, table := range inspect(ctx).GetTables() {
+			if table.GetName() == "checked_products" {
+				return table.GetCheckConstraints()
+			}
+		}
+		require.Fail(t, "table checked_products not found")
+		return nil
+	}
+	if len(getChecks()) == 0 && db.Dialect().Name() == dialect.MySQL {
+		t.Skip("mysql does not support CHECK constraints before 8.0.16")
+	}
+	cmpChecks(t, []sqlschema.Check{
+		{Name: "checked_products_check", Expr: "discount < price"},
+		{Name: "checked_products_price_check", Expr: "price > 0"},
+		{Name: "checked_products_stock_check", Expr: "stock >= 0"},
+	}, getChecks())
+
+	// Check constraints which are not declared by the model are kept by default.
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*ProductUnchecked)(nil)))
+	group, err := m.Migrate(ctx)
+	require.NoError(t, err)
+	require.True(t, group.IsZero(), "unexpected migrations: %v", group)
+
+	m = newAutoMigratorOrSkip(t, db, migrate.WithModel((*ProductAfter)(nil)), migrate.WithManageCheckConstraints())
+
+	// Act
+	runMigrations(t, m)
+
+	// Assert
+	cmpChecks(t, []sqlschema.Check{
+		{Name: "checked_products_check", Expr: "discount < price"},
+		{Name: "checked_products_price_check", Expr: "price > 0"},
+		{Name: "checked_products_discount_check", Expr: "discount >= 0"},
+	}, getChecks())
+
+	group, err = m.Migrate(ctx)
+	require.NoError(t, err)
+	require.True(t, group.IsZero(), "unexpected migrations: %v", group)
+}
+
+func testComments(t *testing.T, db *bun.DB) {
+	if !db.HasFeature(feature.CommentOn) && !db.HasFeature(feature.InlineComment) {
+		t.Skip(db.Dialect().Name().String() + " does not support comments")
+	}
+
+	type ArticleBefore struct {
+		bun.BaseModel `bun:"table:commented_articles,comment:Blog posts"`
+		ID            int64  `bun:",pk"`
+		Title         string `bun:"type:varchar(100),comment:Headline"`
+		Body          string `bun:",comment:Markdown"`
+		Author        string `bun:"type:varchar(100)"`
+	}
+
+	type ArticleAfter struct {
+		bun.BaseModel `bun:"table:commented_articles,comment:Published blog posts"`
+		ID            int64  `bun:",pk"`
+		Title         string `bun:"type:varchar(100),comment:Headline"` // unchanged
+		Body          string // drop comment
+		Author        string `bun:"type:varchar(100),comment:Author's name"` // add comment
+		Summary       string `bun:"type:varchar(200),comment:Teaser"`        // new column
+	}
+
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	mustDropTableOnCleanup(t, ctx, db, (*ArticleBefore)(nil))
+	_, err := db.NewCreateTable().Model((*ArticleBefore)(nil)).Exec(ctx)
+	require.NoError(t, err)
+
+	getComments := func() map[string]string {
+		for _, table := range inspect(ctx).GetTables() {
+			if table.GetName() == "commented_articles" {
+				comments := map[string]string{"": table.GetComment()}
+				for _, col := range table.GetColumns() {
+					comments[col.GetName()] = col.GetComment()
+				}
+				return comments
+			}
+		}
+		require.Fail(t, "table commented_articles not found")
+		return nil
+	}
+	require.Equal(t, map[string]string{
+		"":       "Blog posts",
+		"id":     "",
+		"title":  "Headline",
+		"body":   "Markdown",
+		"author": "",
+	}, getComments())
+
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*ArticleAfter)(nil)))
+
+	// Act
+	runMigrations(t, m)
+
+	// Assert
+	require.Equal(t, map[string]string{
+		"":        "Published blog posts",
+		"id":      "",
+		"title":   "Headline",
+		"body":    "",
+		"author":  "Author's name",
+		"summary": "Teaser",
+	}, getComments())
+
+	group, err := m.Migrate(ctx)
+	require.NoError(t, err)
+	require.True(t, group.IsZero(), "unexpected migrations: %v", group)
+}
+
+func cmpChecks(tb testing.TB, want, got []sqlschema.Check) {
+	tb.Helper()
+	require.Len(tb, got, len(want), "got: %+v", got)
+	for _, w := range want {
+		require.Truef(tb, slices.ContainsFunc(got, w.Equals), "missing check %+v, got: %+v", w, got)
+	}
+}
+
+type ticketStatus string
+
+func (ticketStatus) EnumValues() []string { return []string{"open", "closed"} }
+
+type ticketStatusV2 string
+
+func (ticketStatusV2) EnumValues() []string { return []string{"new", "open", "in_progress", "closed"} }
+
+type ticketPriority string
+
+func (ticketPriority) EnumValues() []string { return []string{"low", "high"} }
+
+type ticketKind string
+
+func (ticketKind) EnumValues() []string { return []string{"bug", "feature"} }
+
+func testEnums(t *testing.T, db *bun.DB) {
+	if !db.HasFeature(feature.EnumType) {
+		t.Skip(db.Dialect().Name().String() + " does not support enum types")
+	}
+
+	type TicketBefore struct {
+		bun.BaseModel `bun:"table:enum_tickets"`
+		ID            int64          `bun:",pk"`
+		Status        ticketStatus   `bun:"type:enum_ticket_status"`
+		Priority      ticketPriority `bun:"type:enum_ticket_priority"`
+	}
+
+	type TicketAfter struct {
+		bun.BaseModel `bun:"table:enum_tickets"`
+		ID            int64          `bun:",pk"`
+		Status        ticketStatusV2 `bun:"type:enum_ticket_status"` // add values
+		Kind          ticketKind     `bun:"type:enum_ticket_kind"`   // create type
+		// Priority is dropped together with its type
+	}
+
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	t.Cleanup(func() {
+		if _, err := db.ExecContext(ctx, "DROP TYPE IF EXISTS enum_ticket_status, enum_ticket_priority, enum_ticket_kind"); err != nil {
+			t.Logf("cleanup: drop enum types: %v", err)
+		}
+	})
+	mustDropTableOnCleanup(t, ctx, db, (*TicketBefore)(nil))
+
+	getEnums := func() []sqlschema.Enum {
+		var enums []sqlschema.Enum
+		for _, enum := range sqlschema.DatabaseEnums(inspect(ctx)) {
+			if strings.HasPrefix(enum.Name, "enum_ticket_") {
+				enums = append(enums, enum)
+			}
+		}
+		return enums
+	}
+
+	for _, query := range []string{
+		"CREATE TYPE enum_ticket_status AS ENUM ('open', 'closed')",
+		"CREATE TYPE enum_ticket_priority AS ENUM ('low', 'high')",
+	} {
+		_, err := db.ExecContext(ctx, query)
+		require.NoError(t, err)
+	}
+	_, err := db.NewCreateTable().Model((*TicketBefore)(nil)).Exec(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []sqlschema.Enum{
+		{Name: "enum_ticket_status", Values: []string{"open", "closed"}},
+		{Name: "enum_ticket_priority", Values: []string{"low", "high"}},
+	}, getEnums())
+
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*TicketAfter)(nil)), migrate.WithManageEnums())
+
+	// Act
+	runMigrations(t, m)
+
+	// Assert
+	require.ElementsMatch(t, []sqlschema.Enum{
+		{Name: "enum_ticket_status", Values: []string{"new", "open", "in_progress", "closed"}},
+		{Name: "enum_ticket_kind", Values: []string{"bug", "feature"}},
+	}, getEnums())
+
+	group, err := m.Migrate(ctx)
+	require.NoError(t, err)
+	require.True(t, group.IsZero(), "unexpected migrations: %v", group)
+}
+
+func testKeepUndeclaredEnum(t *testing.T, db *bun.DB) {
+	if !db.HasFeature(feature.EnumType) {
+		t.Skip(db.Dialect().Name().String() + " does not support enum types")
+	}
+
+	type Ticket struct {
+		bun.BaseModel `bun:"table:enum_kept_tickets"`
+		ID            int64 `bun:",pk"`
+	}
+
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	t.Cleanup(func() {
+		if _, err := db.ExecContext(ctx, "DROP TYPE IF EXISTS enum_kept_mood"); err != nil {
+			t.Logf("cleanup: drop enum type: %v", err)
+		}
+	})
+	mustResetModel(t, ctx, db, (*Ticket)(nil))
+
+	_, err := db.ExecContext(ctx, "CREATE TYPE enum_kept_mood AS ENUM ('sad', 'happy')")
+	require.NoError(t, err)
+
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*Ticket)(nil)))
+
+	// Act
+	group, err := m.Migrate(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	require.True(t, group.IsZero(), "unexpected migrations: %v", group)
+	require.Contains(t, sqlschema.DatabaseEnums(inspect(ctx)), sqlschema.Enum{Name: "enum_kept_mood", Values: []string{"sad", "happy"}})
+}
+
+func testGeneratedColumns(t *testing.T, db *bun.DB) {
+	type ItemBefore struct {
+		bun.BaseModel `bun:"table:generated_items"`
+		ID            int64 `bun:",pk"`
+		Price         int64
+		Qty           int64
+		Total         int64
+	}
+
+	type ItemAfter struct {
+		bun.BaseModel `bun:"table:generated_items"`
+		ID            int64 `bun:",pk"`
+		Price         int64
+		Qty           int64
+		Total         int64 `bun:",generated:price * qty"` // becomes generated
+		Double        int64 `bun:",generated:price * 2"`   // new generated column
+	}
+
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	mustResetModel(t, ctx, db, (*ItemBefore)(nil))
+
+	_, err := db.NewInsert().Model(&ItemBefore{ID: 1, Price: 2, Qty: 3}).Exec(ctx)
+	require.NoError(t, err)
+
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*ItemAfter)(nil)))
+
+	// Act
+	runMigrations(t, m)
+
+	// Assert
+	for _, table := range inspect(ctx).GetTables() {
+		if table.GetName() != "generated_items" {
+			continue
+		}
+		for _, col := range table.GetColumns() {
+			switch col.GetName() {
+			case "total", "double":
+				require.True(t, col.GetIsGenerated(), "column %q is not generated", col.GetName())
+			}
+		}
+	}
+
+	item := &ItemAfter{ID: 1}
+	require.NoError(t, db.NewSelect().Model(item).WherePK().Scan(ctx))
+	require.Equal(t, int64(6), item.Total)
+	require.Equal(t, int64(4), item.Double)
+
+	group, err := m.Migrate(ctx)
+	require.NoError(t, err)
+	require.True(t, group.IsZero(), "unexpected migrations: %v", group)
+}
+
 func testUniqueRenamedTable(t *testing.T, db *bun.DB) {
+	switch db.Dialect().Name() {
+	case dialect.SQLite:
+		t.Skip("sqlite does not support CREATE SCHEMA")
+	case dialect.MySQL:
+		t.Skip("mysql schemas are separate databases")
+	}
+
 	type TableBefore struct {
 		bun.BaseModel `bun:"table:automigrate.before"`
 		FirstName     string `bun:"first_name,unique:full_name"`
@@ -1162,7 +1623,7 @@ func testUpdatePrimaryKeys(t *testing.T, db *bun.DB) {
 					Name:       "new_id",
 					SQLType:    sqltype.BigInt,
 					IsNullable: false,
-					IsIdentity: true,
+					IsIdentity: db.Dialect().Features().Has(feature.GeneratedIdentity),
 				},
 				&sqlschema.BaseColumn{
 					Name:       "first_name",
@@ -0,0 +1,335 @@
+package dbtest_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/mysqldialect"
+	"github.com/uptrace/bun/migrate"
+	"github.com/uptrace/bun/migrate/sqlschema"
+)
+
+func TestMySQLMigrator_AppendSQL(t *testing.T) {
+	// The migrator only renders SQL, so it does not need a running server.
+	sqldb, err := sql.Open("mysql", "user:pass@/test")
+	require.NoError(t, err)
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, mysqldialect.New())
+
+	fk := sqlschema.ForeignKey{
+		From: sqlschema.NewColumnReference("things", "owner_id"),
+		To:   sqlschema.NewColumnReference("owners", "id"),
+	}
+
+	for _, tt := range []struct {
+		name       string
+		schemaName string
+		operation  migrate.Operation
+		want       string
+	}{
+		{
+			name:      "drop table",
+			operation: &migrate.DropTableOp{TableName: "things"},
+			want:      "DROP TABLE `things`",
+		},
+		{
+			name:       "drop table in another database",
+			schemaName: "other",
+			operation:  &migrate.DropTableOp{TableName: "things"},
+			want:       "DROP TABLE `other`.`things`",
+		},
+		{
+			name:      "rename table",
+			operation: &migrate.RenameTableOp{TableName: "things", NewName: "stuff"},
+			want:      "ALTER TABLE `things` RENAME TO `stuff`",
+		},
+		{
+			name:      "rename column",
+			operation: &migrate.RenameColumnOp{TableName: "things", OldName: "name", NewName: "title"},
+			want:      "ALTER TABLE `things` RENAME COLUMN `name` TO `title`",
+		},
+		{
+			name: "add column",
+			operation: &migrate.AddColumnOp{
+				TableName:  "things",
+				ColumnName: "title",
+				Column:     &sqlschema.BaseColumn{SQLType: "varchar", VarcharLen: 100, DefaultValue: "it's untitled"},
+			},
+			want: "ALTER TABLE `things` ADD COLUMN `title` varchar(100) NOT NULL DEFAULT 'it''s untitled'",
+		},
+		{
+			name: "add column with default expression",
+			operation: &migrate.AddColumnOp{
+				TableName:  "things",
+				ColumnName: "uid",
+				Column:     &sqlschema.BaseColumn{SQLType: "varchar", VarcharLen: 36, DefaultValue: "uuid()", IsNullable: true},
+			},
+			want: "ALTER TABLE `things` ADD COLUMN `uid` varchar(36) NULL DEFAULT (uuid())",
+		},
+		{
+			name: "add column with numeric default",
+			operation: &migrate.AddColumnOp{
+				TableName:  "things",
+				ColumnName: "count",
+				Column:     &sqlschema.BaseColumn{SQLType: "int", DefaultValue: "0"},
+			},
+			want: "ALTER TABLE `things` ADD COLUMN `count` int NOT NULL DEFAULT 0",
+		},
+		{
+			name:      "drop column",
+			operation: &migrate.DropColumnOp{TableName: "things", ColumnName: "title"},
+			want:      "ALTER TABLE `things` DROP COLUMN `title`",
+		},
+		{
+			name: "add column with comment",
+			operation: &migrate.AddColumnOp{
+				TableName:  "things",
+				ColumnName: "note",
+				Column:     &sqlschema.BaseColumn{SQLType: "text", IsNullable: true, Comment: "Free-form note"},
+			},
+			want: "ALTER TABLE `things` ADD COLUMN `note` text NULL COMMENT 'Free-form note'",
+		},
+		{
+			name:      "change table comment",
+			operation: &migrate.ChangeCommentOp{TableName: "things", From: "Things", To: "Things we own"},
+			want:      "ALTER TABLE `things` COMMENT = 'Things we own'",
+		},
+		{
+			name: "change column comment",
+			operation: &migrate.ChangeCommentOp{
+				TableName:  "things",
+				ColumnName: "name",
+				Column:     &sqlschema.BaseColumn{SQLType: "varchar", VarcharLen: 100, DefaultValue: "unnamed", Comment: "Old"},
+				From:       "Old",
+				To:         "Thing's name",
+			},
+			want: "ALTER TABLE `things` MODIFY COLUMN `name` varchar(100) NOT NULL DEFAULT 'unnamed' COMMENT 'Thing''s name'",
+		},
+		{
+			name: "change column type",
+			operation: &migrate.ChangeColumnTypeOp{
+				TableName: "things",
+				Column:    "id",
+				From:      &sqlschema.BaseColumn{SQLType: "int"},
+				To:        &sqlschema.BaseColumn{SQLType: "bigint", IsAutoIncrement: true},
+			},
+			want: "ALTER TABLE `things` MODIFY COLUMN `id` bigint NOT NULL AUTO_INCREMENT",
+		},
+		{
+			name: "make column nullable",
+			operation: &migrate.ChangeColumnTypeOp{
+				TableName: "things",
+				Column:    "title",
+				From:      &sqlschema.BaseColumn{SQLType: "varchar", VarcharLen: 255},
+				To:        &sqlschema.BaseColumn{SQLType: "varchar", VarcharLen: 255, IsNullable: true},
+			},
+			want: "ALTER TABLE `things` MODIFY COLUMN `title` varchar(255) NULL",
+		},
+		{
+			name: "add primary key",
+			operation: &migrate.AddPrimaryKeyOp{
+				TableName:  "things",
+				PrimaryKey: sqlschema.PrimaryKey{Columns: sqlschema.NewColumns("id")},
+			},
+			want: "ALTER TABLE `things` ADD PRIMARY KEY (id)",
+		},
+		{
+			name: "drop primary key",
+			operation: &migrate.DropPrimaryKeyOp{
+				TableName:  "things",
+				PrimaryKey: sqlschema.PrimaryKey{Name: "PRIMARY", Columns: sqlschema.NewColumns("id")},
+			},
+			want: "ALTER TABLE `things` DROP PRIMARY KEY",
+		},
+		{
+			name: "change primary key",
+			operation: &migrate.ChangePrimaryKeyOp{
+				TableName: "things",
+				Old:       sqlschema.PrimaryKey{Name: "PRIMARY", Columns: sqlschema.NewColumns("id")},
+				New:       sqlschema.PrimaryKey{Columns: sqlschema.NewColumns("id", "owner_id")},
+			},
+			want: "ALTER TABLE `things` DROP PRIMARY KEY, ADD PRIMARY KEY (id,owner_id)",
+		},
+		{
+			name: "add unique constraint",
+			operation: &migrate.AddUniqueConstraintOp{
+				TableName: "things",
+				Unique:    sqlschema.Unique{Columns: sqlschema.NewColumns("owner_id", "name")},
+			},
+			want: "ALTER TABLE `things` ADD CONSTRAINT `things_name_owner_id_key` UNIQUE (name,owner_id)",
+		},
+		{
+			name: "drop unique constraint",
+			operation: &migrate.DropUniqueConstraintOp{
+				TableName: "things",
+				Unique:    sqlschema.Unique{Name: "name", Columns: sqlschema.NewColumns("name")},
+			},
+			want: "ALTER TABLE `things` DROP INDEX `name`",
+		},
+		{
+			name:      "add foreign key",
+			operation: &migrate.AddForeignKeyOp{ForeignKey: fk},
+			want:      "ALTER TABLE `things` ADD CONSTRAINT `things_owner_id_fkey` FOREIGN KEY (owner_id) REFERENCES `owners` (id)",
+		},
+		{
+			name:      "drop foreign key",
+			operation: &migrate.DropForeignKeyOp{ForeignKey: fk, ConstraintName: "things_ibfk_1"},
+			want:      "ALTER TABLE `things` DROP FOREIGN KEY `things_ibfk_1`",
+		},
+		{
+			name: "create index",
+			operation: &migrate.CreateIndexOp{
+				TableName: "things",
+				Index:     sqlschema.Index{Name: "things_name_idx", Columns: []string{"name", "(lower(code))"}},
+			},
+			want: "CREATE INDEX `things_name_idx` ON `things` (`name`, (lower(code)))",
+		},
+		{
+			name: "create hash index",
+			operation: &migrate.CreateIndexOp{
+				TableName: "things",
+				Index:     sqlschema.Index{Name: "things_code_idx", Columns: []string{"code"}, Method: "hash"},
+			},
+			want: "CREATE INDEX `things_code_idx` ON `things` (`code`) USING HASH",
+		},
+		{
+			name: "create fulltext index",
+			operation: &migrate.CreateIndexOp{
+				TableName: "things",
+				Index:     sqlschema.Index{Name: "things_note_idx", Columns: []string{"note"}, Method: "fulltext"},
+			},
+			want: "CREATE FULLTEXT INDEX `things_note_idx` ON `things` (`note`)",
+		},
+		{
+			name:      "drop index",
+			operation: &migrate.DropIndexOp{TableName: "things", Index: sqlschema.Index{Name: "things_name_idx"}},
+			want:      "DROP INDEX `things_name_idx` ON `things`",
+		},
+		{
+			name: "add check constraint",
+			operation: &migrate.AddCheckConstraintOp{
+				TableName: "things",
+				Check:     sqlschema.Check{Name: "things_price_check", Expr: "price > 0"},
+			},
+			want: "ALTER TABLE `things` ADD CONSTRAINT `things_price_check` CHECK (price > 0)",
+		},
+		{
+			name: "drop check constraint",
+			operation: &migrate.DropCheckConstraintOp{
+				TableName: "things",
+				Check:     sqlschema.Check{Name: "things_price_check", Expr: "price > 0"},
+			},
+			want: "ALTER TABLE `things` DROP CONSTRAINT `things_price_check`",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaName := tt.schemaName
+			if schemaName == "" {
+				schemaName = db.Dialect().DefaultSchema()
+			}
+			m, err := sqlschema.NewMigrator(db, schemaName)
+			require.NoError(t, err)
+
+			b, err := m.AppendSQL(nil, tt.operation)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(b))
+		})
+	}
+}
+
+func TestMySQLCreateTableComment(t *testing.T) {
+	sqldb, err := sql.Open("mysql", "user:pass@/test")
+	require.NoError(t, err)
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, mysqldialect.New())
+
+	type Thing struct {
+		bun.BaseModel `bun:"table:things,comment:Things we own"`
+		ID            int64  `bun:",pk"`
+		Name          string `bun:",comment:Thing's name"`
+	}
+
+	query := db.NewCreateTable().Model((*Thing)(nil)).String()
+	require.Equal(t, "CREATE TABLE `things` (`id` BIGINT NOT NULL, `name` VARCHAR(255) COMMENT 'Thing''s name', PRIMARY KEY (`id`)) COMMENT 'Things we own'", query)
+}
+
+func TestMySQLInspector(t *testing.T) {
+	type Owner struct {
+		bun.BaseModel `bun:"table:inspect_owners"`
+		ID            int64 `bun:",pk,autoincrement"`
+	}
+
+	type Thing struct {
+		bun.BaseModel `bun:"table:inspect_things"`
+		ID            int64     `bun:",pk,autoincrement"`
+		Name          string    `bun:",notnull,unique,default:'unnamed'"`
+		Code          string    `bun:"type:char(3),unique:owner_code"`
+		OwnerID       int64     `bun:",unique:owner_code"`
+		CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+		Note          string    `bun:"type:text"`
+
+		Owner *Owner `bun:"rel:belongs-to,join:owner_id=id"`
+	}
+
+	db := mysql8(t)
+
+	mustCreateTableWithFKs(t, ctx, db, (*Owner)(nil), (*Thing)(nil))
+	_, err := db.NewCreateIndex().Model((*Thing)(nil)).Index("inspect_things_created_at_idx").Column("created_at", "name").Exec(ctx)
+	require.NoError(t, err)
+
+	inspector, err := sqlschema.NewInspector(db, sqlschema.WithExcludeTables("bun_%"))
+	require.NoError(t, err)
+
+	state, err := inspector.Inspect(ctx)
+	require.NoError(t, err)
+
+	wantTables := []sqlschema.Table{
+		&sqlschema.BaseTable{
+			Schema: db.Dialect().DefaultSchema(),
+			Name:   "inspect_owners",
+			Columns: []sqlschema.Column{
+				&sqlschema.BaseColumn{Name: "id", SQLType: "bigint", IsAutoIncrement: true},
+			},
+			PrimaryKey: &sqlschema.PrimaryKey{Name: "PRIMARY", Columns: sqlschema.NewColumns("id")},
+		},
+		&sqlschema.BaseTable{
+			Schema: db.Dialect().DefaultSchema(),
+			Name:   "inspect_things",
+			Columns: []sqlschema.Column{
+				&sqlschema.BaseColumn{Name: "id", SQLType: "bigint", IsAutoIncrement: true},
+				&sqlschema.BaseColumn{Name: "name", SQLType: "varchar", VarcharLen: 255, DefaultValue: "unnamed"},
+				&sqlschema.BaseColumn{Name: "code", SQLType: "char", VarcharLen: 3, IsNullable: true},
+				&sqlschema.BaseColumn{Name: "owner_id", SQLType: "bigint", IsNullable: true},
+				&sqlschema.BaseColumn{Name: "created_at", SQLType: "datetime", DefaultValue: "current_timestamp"},
+				&sqlschema.BaseColumn{Name: "note", SQLType: "text", IsNullable: true},
+			},
+			PrimaryKey: &sqlschema.PrimaryKey{Name: "PRIMARY", Columns: sqlschema.NewColumns("id")},
+			UniqueConstraints: []sqlschema.Unique{
+				{Name: "name", Columns: sqlschema.NewColumns("name")},
+				{Name: "owner_code", Columns: sqlschema.NewColumns("code", "owner_id")},
+			},
+			// The index MySQL creates for the foreign key must not be reported.
+			Indexes: []sqlschema.Index{
+				{Name: "inspect_things_created_at_idx", Columns: []string{"created_at", "name"}},
+			},
+		},
+	}
+	var gotTables []sqlschema.Table
+	for _, table := range state.GetTables() {
+		if table.GetName() == "inspect_owners" || table.GetName() == "inspect_things" {
+			gotTables = append(gotTables, table)
+		}
+	}
+	cmpTables(t, db.Dialect().(sqlschema.InspectorDialect), wantTables, gotTables)
+
+	require.Contains(t, state.GetForeignKeys(), sqlschema.ForeignKey{
+		From: sqlschema.NewColumnReference("inspect_things", "owner_id"),
+		To:   sqlschema.NewColumnReference("inspect_owners", "id"),
+	})
+}
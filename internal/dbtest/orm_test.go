@@ -6,6 +6,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -37,6 +38,8 @@ func TestORM(t *testing.T) {
 		{testCompositeM2M},
 		{testHasOneRelationWithOpts},
 		{testHasManyRelationWithOpts},
+		{testRelationBatches},
+		{testRelationLimit},
 	}
 
 	testEachDB(t, func(t *testing.T, dbName string, db *bun.DB) {
@@ -667,6 +670,108 @@ func testHasManyRelationWithOpts(t *testing.T, db *bun.DB) {
 	}, outUsers2)
 }
 
+func testRelationBatches(t *testing.T, db *bun.DB) {
+	// Select the relations of each base model with a separate query.
+	db = bun.NewDB(db.DB, db.Dialect(), bun.WithRelationBatchSize(1))
+
+	var queries atomic.Int32
+	db.AddQueryHook(&queryCounter{n: &queries})
+
+	var authors []Author
+	err := db.NewSelect().
+		Model(&authors).
+		Column("author.id").
+		Relation("Books", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("book.id", "book.author_id").OrderExpr("book.id ASC")
+		}).
+		Relation("Books.Translations", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("tr.id", "tr.book_id").OrderExpr("tr.id ASC")
+		}).
+		RelationWithOpts("Books.Genres", bun.RelationOpts{
+			Apply: func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.Column("genre.id").OrderExpr("genre.id ASC")
+			},
+			BatchSize: 2,
+		}).
+		OrderExpr("author.id ASC").
+		Scan(ctx)
+	require.NoError(t, err)
+
+	// authors + 3 batches of books + 3 batches of translations + 2 batches of genres
+	require.Equal(t, int32(9), queries.Load())
+
+	require.Equal(t, []Author{
+		{ID: 10, Books: []*Book{
+			{
+				ID: 100, AuthorID: 10,
+				Genres:       []Genre{{ID: 1}, {ID: 2}},
+				Translations: []Translation{{ID: 1000, BookID: 100}, {ID: 1001, BookID: 100}},
+			},
+			{
+				ID: 101, AuthorID: 10,
+				Genres:       []Genre{{ID: 1}},
+				Translations: []Translation{{ID: 1002, BookID: 101}},
+			},
+		}},
+		{ID: 11, Books: []*Book{{ID: 102, AuthorID: 11}}},
+		{ID: 12},
+	}, authors)
+}
+
+func testRelationLimit(t *testing.T, db *bun.DB) {
+	var books []Book
+	err := db.NewSelect().
+		Model(&books).
+		Column("book.id").
+		RelationWithOpts("Translations", bun.RelationOpts{
+			Apply: func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.Column("tr.id", "tr.book_id").OrderExpr("tr.id DESC")
+			},
+			Limit: 1,
+		}).
+		RelationWithOpts("Genres", bun.RelationOpts{
+			Apply: func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.Column("genre.id").OrderExpr("genre.id DESC")
+			},
+			Limit: 1,
+		}).
+		OrderExpr("book.id ASC").
+		Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Book{
+		{
+			ID:           100,
+			Genres:       []Genre{{ID: 2}},
+			Translations: []Translation{{ID: 1001, BookID: 100}},
+		},
+		{
+			ID:           101,
+			Genres:       []Genre{{ID: 1}},
+			Translations: []Translation{{ID: 1002, BookID: 101}},
+		},
+		{ID: 102},
+	}, books)
+
+	err = db.NewSelect().
+		Model(&books).
+		RelationWithOpts("Author", bun.RelationOpts{Limit: 1}).
+		Scan(ctx)
+	require.Error(t, err)
+}
+
+type queryCounter struct {
+	n *atomic.Int32
+}
+
+var _ bun.QueryHook = (*queryCounter)(nil)
+
+func (h *queryCounter) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	h.n.Add(1)
+	return ctx
+}
+
+func (h *queryCounter) AfterQuery(context.Context, *bun.QueryEvent) {}
+
 type Genre struct {
 	ID     int `bun:",pk"`
 	Name   string
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/base64"
 	"fmt"
 	"net"
 	"reflect"
@@ -17,9 +18,386 @@ import (
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/migrate"
+	"github.com/uptrace/bun/migrate/sqlschema"
 	"github.com/uptrace/bun/schema"
 )
 
+func TestPostgresCreateTableComment(t *testing.T) {
+	// Rendering the query does not need a running server.
+	sqldb := sql.OpenDB(pgdriver.NewConnector())
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, pgdialect.New())
+
+	type Thing struct {
+		bun.BaseModel `bun:"table:things,comment:Things we own"`
+		ID            int64  `bun:",pk"`
+		Name          string `bun:",comment:Thing's name"`
+	}
+
+	query := db.NewCreateTable().Model((*Thing)(nil)).String()
+	require.Equal(t, `CREATE TABLE "things" ("id" BIGINT NOT NULL, "name" VARCHAR, PRIMARY KEY ("id")); `+
+		`COMMENT ON TABLE "things" IS 'Things we own'; `+
+		`COMMENT ON COLUMN "things"."name" IS 'Thing''s name'`, query)
+}
+
+func TestPostgresGeneratedColumn(t *testing.T) {
+	// Rendering the queries does not need a running server.
+	sqldb := sql.OpenDB(pgdriver.NewConnector())
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, pgdialect.New())
+
+	type Item struct {
+		bun.BaseModel `bun:"table:items"`
+		ID            int64 `bun:",pk"`
+		Price         int64
+		Qty           int64
+		Total         int64 `bun:",generated:price * qty"`
+	}
+
+	query := db.NewCreateTable().Model((*Item)(nil)).String()
+	require.Equal(t, `CREATE TABLE "items" ("id" BIGINT NOT NULL, "price" BIGINT, "qty" BIGINT, `+
+		`"total" BIGINT GENERATED ALWAYS AS (price * qty) STORED, PRIMARY KEY ("id"))`, query)
+
+	items := []Item{{ID: 1, Price: 2, Qty: 3}, {ID: 2, Price: 1, Qty: 1}}
+
+	query = db.NewInsert().Model(&items[0]).String()
+	require.Equal(t, `INSERT INTO "items" ("id", "price", "qty") VALUES (1, 2, 3) RETURNING "total"`, query)
+
+	query = db.NewInsert().Model(&items).On("CONFLICT (id) DO UPDATE").String()
+	require.Equal(t, `INSERT INTO "items" AS "item" ("id", "price", "qty") VALUES (1, 2, 3), (2, 1, 1) `+
+		`ON CONFLICT (id) DO UPDATE SET "price" = EXCLUDED."price", "qty" = EXCLUDED."qty" RETURNING "total"`, query)
+
+	query = db.NewUpdate().Model(&items[0]).WherePK().String()
+	require.Equal(t, `UPDATE "items" AS "item" SET "price" = 2, "qty" = 3 WHERE ("item"."id" = 1)`, query)
+
+	query = db.NewUpdate().Model(&items).Bulk().String()
+	require.Contains(t, query, `SET "price" = _data."price", "qty" = _data."qty" FROM _data`)
+}
+
+func TestPostgresViews(t *testing.T) {
+	// Rendering the queries does not need a running server.
+	sqldb := sql.OpenDB(pgdriver.NewConnector())
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, pgdialect.New())
+
+	type ActiveUser struct {
+		bun.BaseModel `bun:"view:active_users,alias:u"`
+		ID            int64
+		Name          string
+	}
+
+	type UserStats struct {
+		bun.BaseModel `bun:"view:user_stats,materialized"`
+		Count         int
+	}
+
+	active := db.NewSelect().Table("users").Column("id", "name").Where("deleted_at IS NULL")
+
+	query := db.NewCreateView().Model((*ActiveUser)(nil)).OrReplace().As(active).String()
+	require.Equal(t, `CREATE OR REPLACE VIEW "active_users" AS `+
+		`SELECT "id", "name" FROM "users" WHERE (deleted_at IS NULL)`, query)
+
+	query = db.NewCreateView().Model((*UserStats)(nil)).IfNotExists().
+		As(db.NewSelect().Table("users").ColumnExpr("count(*) AS count")).String()
+	require.Equal(t, `CREATE MATERIALIZED VIEW IF NOT EXISTS "user_stats" AS SELECT count(*) AS count FROM "users"`, query)
+
+	query = db.NewRefreshMaterializedView().Model((*UserStats)(nil)).Concurrently().String()
+	require.Equal(t, `REFRESH MATERIALIZED VIEW CONCURRENTLY "user_stats"`, query)
+
+	query = db.NewDropView().Model((*UserStats)(nil)).IfExists().Cascade().String()
+	require.Equal(t, `DROP MATERIALIZED VIEW IF EXISTS "user_stats" CASCADE`, query)
+
+	query = db.NewDropView().Table("active_users", "other_users").String()
+	require.Equal(t, `DROP VIEW "active_users", "other_users"`, query)
+
+	query = db.NewSelect().Model((*ActiveUser)(nil)).String()
+	require.Equal(t, `SELECT "u"."id", "u"."name" FROM "active_users" AS "u"`, query)
+
+	_, err := db.NewInsert().Model(&ActiveUser{Name: "one"}).AppendQuery(db.QueryGen(), nil)
+	require.EqualError(t, err, "bun: ActiveUser is a view and does not support INSERT")
+
+	_, err = db.NewUpdate().Model(&ActiveUser{ID: 1}).Column("name").Where("id = 1").AppendQuery(db.QueryGen(), nil)
+	require.EqualError(t, err, "bun: ActiveUser is a view and does not support UPDATE")
+
+	_, err = db.NewDelete().Model((*ActiveUser)(nil)).Where("id = 1").AppendQuery(db.QueryGen(), nil)
+	require.EqualError(t, err, "bun: ActiveUser is a view and does not support DELETE")
+
+	_, err = db.NewCreateView().Model((*ActiveUser)(nil)).AppendQuery(db.QueryGen(), nil)
+	require.Error(t, err)
+}
+
+func TestPostgresVersionLock(t *testing.T) {
+	// Rendering the queries does not need a running server.
+	sqldb := sql.OpenDB(pgdriver.NewConnector())
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, pgdialect.New())
+
+	type Document struct {
+		bun.BaseModel `bun:"table:documents"`
+		ID            int64 `bun:",pk"`
+		Title         string
+		Version       int64 `bun:",version"`
+	}
+
+	doc := &Document{ID: 1, Title: "draft", Version: 3}
+
+	query := db.NewUpdate().Model(doc).WherePK().String()
+	require.Equal(t, `UPDATE "documents" AS "document" SET "title" = 'draft', "version" = "version" + 1 `+
+		`WHERE ("document"."id" = 1) AND ("document"."version" = 3)`, query)
+
+	query = db.NewUpdate().Model(doc).Set("title = upper(title)").WherePK().String()
+	require.Equal(t, `UPDATE "documents" AS "document" SET title = upper(title), "version" = "version" + 1 `+
+		`WHERE ("document"."id" = 1) AND ("document"."version" = 3)`, query)
+
+	query = db.NewDelete().Model(doc).WherePK().String()
+	require.Equal(t, `DELETE FROM "documents" AS "document" `+
+		`WHERE ("document"."id" = 1) AND ("document"."version" = 3)`, query)
+
+	docs := []Document{*doc, {ID: 2, Title: "final", Version: 1}}
+	query = db.NewUpdate().Model(&docs).Bulk().String()
+	require.Equal(t, `WITH "_data" ("id", "title", "version") AS (VALUES (1::BIGINT, 'draft'::VARCHAR, 3::BIGINT), (2::BIGINT, 'final'::VARCHAR, 1::BIGINT)) `+
+		`UPDATE "documents" AS "document" SET "title" = _data."title", "version" = "document"."version" + 1 `+
+		`FROM _data WHERE ("document"."id" = _data."id" AND "document"."version" = _data."version")`, query)
+
+	// The version of a template is not known.
+	query = db.NewUpdate().Model((*Document)(nil)).Set("title = ?", "x").Where("id = 1").String()
+	require.Equal(t, `UPDATE "documents" AS "document" SET title = 'x' WHERE (id = 1)`, query)
+}
+
+func TestPostgresKeyset(t *testing.T) {
+	// Rendering the queries does not need a running server.
+	sqldb := sql.OpenDB(pgdriver.NewConnector())
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, pgdialect.New())
+
+	type Post struct {
+		ID        int64 `bun:",pk"`
+		CreatedAt time.Time
+	}
+
+	cursor := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	var posts []Post
+
+	query := db.NewSelect().Model(&posts).Keyset("", 10, "created_at", "id").String()
+	require.Equal(t, `SELECT "post"."id", "post"."created_at" FROM "posts" AS "post" `+
+		`ORDER BY "post"."created_at" ASC, "post"."id" ASC LIMIT 11`, query)
+
+	next := cursor(`{"v":["2024-01-02T03:04:05Z",7]}`)
+	query = db.NewSelect().Model(&posts).Keyset(next, 10, "created_at DESC", "id DESC").String()
+	require.Equal(t, `SELECT "post"."id", "post"."created_at" FROM "posts" AS "post" `+
+		`WHERE (("post"."created_at", "post"."id") < ('2024-01-02 03:04:05+00:00', 7)) `+
+		`ORDER BY "post"."created_at" DESC, "post"."id" DESC LIMIT 11`, query)
+
+	// Mixed orders can not use tuple comparison.
+	query = db.NewSelect().Model(&posts).Keyset(next, 10, "created_at DESC", "id").String()
+	require.Equal(t, `SELECT "post"."id", "post"."created_at" FROM "posts" AS "post" `+
+		`WHERE (("post"."created_at" < '2024-01-02 03:04:05+00:00') `+
+		`OR ("post"."created_at" = '2024-01-02 03:04:05+00:00' AND "post"."id" > 7)) `+
+		`ORDER BY "post"."created_at" DESC, "post"."id" ASC LIMIT 11`, query)
+
+	// The previous page is selected in the reverse order.
+	prev := cursor(`{"p":true,"v":["2024-01-02T03:04:05Z",7]}`)
+	query = db.NewSelect().Model(&posts).Keyset(prev, 10, "created_at DESC", "id").String()
+	require.Equal(t, `SELECT "post"."id", "post"."created_at" FROM "posts" AS "post" `+
+		`WHERE (("post"."created_at" > '2024-01-02 03:04:05+00:00') `+
+		`OR ("post"."created_at" = '2024-01-02 03:04:05+00:00' AND "post"."id" < 7)) `+
+		`ORDER BY "post"."created_at" ASC, "post"."id" DESC LIMIT 11`, query)
+
+	err := db.NewSelect().Model(&posts).Keyset("garbage", 10, "id").Scan(ctx)
+	require.EqualError(t, err, "bun: invalid keyset cursor")
+
+	err = db.NewSelect().Model(&posts).Keyset(next, 10, "id").Scan(ctx)
+	require.EqualError(t, err, "bun: invalid keyset cursor")
+
+	_, err = db.NewSelect().Model(&posts).Keyset("", 10, "title").ScanKeyset(ctx)
+	require.Error(t, err)
+}
+
+func TestPostgresWindow(t *testing.T) {
+	// Rendering the queries does not need a running server.
+	sqldb := sql.OpenDB(pgdriver.NewConnector())
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, pgdialect.New())
+
+	type Order struct {
+		ID        int64 `bun:",pk"`
+		UserID    int64
+		Status    string
+		Amount    int
+		CreatedAt time.Time
+	}
+
+	query := db.NewSelect().
+		Model((*Order)(nil)).
+		Column("id").
+		ColumnExpr("? AS rank", bun.NewFunc("row_number", "").
+			Over(bun.NewWindow().PartitionBy("order.user_id").OrderBy("created_at DESC", "id"))).
+		ColumnExpr("? AS running_total", bun.NewFunc("sum", "?", bun.Ident("amount")).
+			Over(bun.NewWindow().Base("w").Rows(bun.UnboundedPreceding, bun.CurrentRow))).
+		ColumnExpr("? AS moving_avg", bun.NewFunc("avg", "?", bun.Ident("amount")).
+			Over(bun.NewWindow().Base("w").Range(bun.Preceding(2), bun.Following(1)))).
+		ColumnExpr("? AS total", bun.NewFunc("sum", "?", bun.Ident("amount")).OverWindow("w")).
+		Window("w", bun.NewWindow().PartitionBy("user_id").OrderBy("created_at")).
+		Order("id").
+		String()
+	require.Equal(t, `SELECT "order"."id", `+
+		`row_number() OVER (PARTITION BY "order"."user_id" ORDER BY "created_at" DESC, "id") AS rank, `+
+		`sum("amount") OVER ("w" ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS running_total, `+
+		`avg("amount") OVER ("w" RANGE BETWEEN 2 PRECEDING AND 1 FOLLOWING) AS moving_avg, `+
+		`sum("amount") OVER "w" AS total `+
+		`FROM "orders" AS "order" `+
+		`WINDOW "w" AS (PARTITION BY "user_id" ORDER BY "created_at") ORDER BY "id"`, query)
+
+	query = db.NewSelect().
+		Model((*Order)(nil)).
+		Column("user_id").
+		ColumnExpr("? AS paid", bun.NewFunc("count", "*").Filter("status = ?", "paid")).
+		ColumnExpr("? AS refunded", bun.NewFunc("sum", "?", bun.Ident("amount")).
+			Filter("status = ?", "refunded").Filter("amount > ?", 0)).
+		ColumnExpr("? AS share", bun.NewFunc("count", "*").Filter("status = ?", "paid").Over(bun.NewWindow())).
+		Group("user_id").
+		String()
+	require.Equal(t, `SELECT "order"."user_id", `+
+		`count(*) FILTER (WHERE status = 'paid') AS paid, `+
+		`sum("amount") FILTER (WHERE (status = 'refunded') AND (amount > 0)) AS refunded, `+
+		`count(*) FILTER (WHERE status = 'paid') OVER () AS share `+
+		`FROM "orders" AS "order" GROUP BY "user_id"`, query)
+}
+
+func TestPostgresTypedColumns(t *testing.T) {
+	// Rendering the queries does not need a running server.
+	sqldb := sql.OpenDB(pgdriver.NewConnector())
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, pgdialect.New())
+
+	type User struct {
+		bun.BaseModel `bun:"table:users,alias:u"`
+		ID            int64 `bun:",pk"`
+		Email         string
+		Age           int
+	}
+
+	var UserColumns = struct {
+		ID    bun.Column[int64]
+		Email bun.Column[string]
+		Age   bun.Column[int]
+	}{
+		ID:    bun.NewColumn[int64]("u", "id"),
+		Email: bun.NewColumn[string]("u", "email"),
+		Age:   bun.NewColumn[int]("u", "age"),
+	}
+
+	query := db.NewSelect().
+		Model((*User)(nil)).
+		ColumnRef(UserColumns.Email).
+		ColumnExpr("count(*)").
+		WherePred(
+			UserColumns.ID.In(1, 2, 3),
+			bun.Or(UserColumns.Age.Between(18, 65), bun.Not(UserColumns.Email.Ne("root@example.com"))),
+		).
+		WherePred(UserColumns.Email.WithAlias("owner").IsNotNull()).
+		GroupRef(UserColumns.Email).
+		OrderRef(UserColumns.Email, bun.OrderDesc).
+		String()
+	require.Equal(t, `SELECT "u"."email", count(*) FROM "users" AS "u" `+
+		`WHERE ("u"."id" IN (1, 2, 3)) `+
+		`AND (("u"."age" BETWEEN 18 AND 65) OR (NOT ("u"."email" <> 'root@example.com'))) `+
+		`AND ("owner"."email" IS NOT NULL) `+
+		`GROUP BY "u"."email" ORDER BY "u"."email" DESC`, query)
+
+	query = db.NewSelect().Model((*User)(nil)).WherePred(UserColumns.ID.In()).String()
+	require.Equal(t, `SELECT "u"."id", "u"."email", "u"."age" FROM "users" AS "u" WHERE ("u"."id" IN (NULL))`, query)
+
+	query = db.NewUpdate().
+		Model((*User)(nil)).
+		SetAssignment(UserColumns.Email.Set("new@example.com"), UserColumns.Age.Set(30)).
+		WherePred(UserColumns.ID.Eq(1)).
+		String()
+	require.Equal(t, `UPDATE "users" AS "u" SET "email" = 'new@example.com', "age" = 30 WHERE ("u"."id" = 1)`, query)
+
+	query = db.NewDelete().
+		Model((*User)(nil)).
+		WherePred(UserColumns.Age.Lt(18), UserColumns.Email.IsNull()).
+		String()
+	require.Equal(t, `DELETE FROM "users" AS "u" WHERE ("u"."age" < 18) AND ("u"."email" IS NULL)`, query)
+}
+
+func TestPostgresMigrator_AppendSQL(t *testing.T) {
+	// The migrator only renders SQL, so it does not need a running server.
+	sqldb := sql.OpenDB(pgdriver.NewConnector())
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, pgdialect.New())
+
+	mood := sqlschema.Enum{Name: "mood", Values: []string{"sad", "ok", "happy"}}
+
+	for _, tt := range []struct {
+		name      string
+		operation migrate.Operation
+		want      string
+	}{
+		{
+			name:      "create enum",
+			operation: &migrate.CreateEnumOp{Enum: mood},
+			want:      `CREATE TYPE "public"."mood" AS ENUM ('sad', 'ok', 'happy')`,
+		},
+		{
+			name:      "add enum value",
+			operation: &migrate.AddEnumValueOp{TypeName: "mood", Value: "meh"},
+			want:      `ALTER TYPE "public"."mood" ADD VALUE 'meh'`,
+		},
+		{
+			name:      "add enum value after",
+			operation: &migrate.AddEnumValueOp{TypeName: "mood", Value: "meh", After: "sad"},
+			want:      `ALTER TYPE "public"."mood" ADD VALUE 'meh' AFTER 'sad'`,
+		},
+		{
+			name:      "add enum value before",
+			operation: &migrate.AddEnumValueOp{TypeName: "mood", Value: "awful", Before: "sad"},
+			want:      `ALTER TYPE "public"."mood" ADD VALUE 'awful' BEFORE 'sad'`,
+		},
+		{
+			name:      "drop enum",
+			operation: &migrate.DropEnumOp{Enum: mood},
+			want:      `DROP TYPE "public"."mood"`,
+		},
+		{
+			name: "add generated column",
+			operation: &migrate.AddColumnOp{
+				TableName:  "items",
+				ColumnName: "total",
+				Column: &sqlschema.BaseColumn{
+					SQLType:       "bigint",
+					IsNullable:    true,
+					IsGenerated:   true,
+					GeneratedExpr: "price * qty",
+				},
+			},
+			want: `ALTER TABLE "public"."items" ADD COLUMN "total" bigint GENERATED ALWAYS AS (price * qty) STORED`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := sqlschema.NewMigrator(db, db.Dialect().DefaultSchema())
+			require.NoError(t, err)
+
+			b, err := m.AppendSQL(nil, tt.operation)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(b))
+		})
+	}
+}
+
 func TestPostgresArray(t *testing.T) {
 	type Model struct {
 		ID     int64     `bun:",pk,autoincrement"`
@@ -682,6 +1060,73 @@ func TestPostgresCopyFromCopyTo(t *testing.T) {
 	})
 }
 
+func TestPostgresBatch(t *testing.T) {
+	type BatchModel struct {
+		ID   int64 `bun:",pk,autoincrement"`
+		Name string
+	}
+
+	ctx := context.Background()
+
+	db := pg(t)
+	mustResetModel(t, ctx, db, (*BatchModel)(nil))
+
+	models := []*BatchModel{{Name: "foo"}, {Name: "bar"}}
+
+	batch := pgdriver.NewBatch(db)
+	inserts := []*pgdriver.BatchQuery{
+		batch.Queue(db.NewInsert().Model(models[0])),
+		batch.Queue(db.NewInsert().Model(models[1])),
+	}
+	invalid := batch.Queue(db.NewRaw("SELECT * FROM missing_table"))
+	var names []string
+	sel := batch.Queue(db.NewSelect().Model((*BatchModel)(nil)).Column("name").Order("id"), &names)
+	var count int
+	cnt := batch.Queue(db.NewSelect().Model((*BatchModel)(nil)).ColumnExpr("count(*)"), &count)
+	missing := new(BatchModel)
+	noRows := batch.Queue(db.NewSelect().Model(missing).Where("id = 0"))
+	require.Equal(t, 6, batch.Len())
+
+	err := batch.Run(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing_table")
+
+	for i, insert := range inserts {
+		require.NoError(t, insert.Err)
+		n, err := insert.Result.RowsAffected()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), n)
+		require.NotZero(t, models[i].ID)
+	}
+
+	require.Error(t, invalid.Err)
+	require.Equal(t, err, invalid.Err)
+
+	require.NoError(t, sel.Err)
+	require.Equal(t, []string{"foo", "bar"}, names)
+	n, err := sel.Result.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	require.NoError(t, cnt.Err)
+	require.Equal(t, 2, count)
+
+	require.Equal(t, sql.ErrNoRows, noRows.Err)
+
+	var selected []BatchModel
+	batch = pgdriver.NewBatch(db)
+	batch.Queue(db.NewSelect().Model(&selected).Order("id"))
+	batch.Queue(db.NewDelete().Model((*BatchModel)(nil)).Where("id = ?", models[0].ID))
+	require.NoError(t, batch.Run(ctx))
+	require.Len(t, selected, 2)
+	require.Equal(t, *models[0], selected[0])
+
+	// The connection is reused after the batch.
+	count, err = db.NewSelect().Model((*BatchModel)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
 func TestPostgresUUID(t *testing.T) {
 	type Model struct {
 		ID uuid.UUID `bun:",pk,nullzero,type:uuid,default:uuid_generate_v4()"`
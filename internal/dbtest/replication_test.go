@@ -0,0 +1,96 @@
+package dbtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+func TestReplicator(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := pg(t)
+
+	var walLevel string
+	err := db.QueryRowContext(ctx, "SHOW wal_level").Scan(&walLevel)
+	require.NoError(t, err)
+	if walLevel != "logical" {
+		t.Skip("wal_level is not logical")
+	}
+
+	_, err = db.ExecContext(ctx, "DROP TABLE IF EXISTS replication_test")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "CREATE TABLE replication_test (id int PRIMARY KEY, name text)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "DROP PUBLICATION IF EXISTS replication_test")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "CREATE PUBLICATION replication_test FOR TABLE replication_test")
+	require.NoError(t, err)
+
+	r := pgdriver.NewReplicator(db, pgdriver.WithStandbyStatusInterval(time.Second))
+	defer r.Close()
+
+	info, err := r.IdentifySystem(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "test", info.DBName)
+	require.NotZero(t, info.XLogPos)
+
+	slot, err := r.CreateReplicationSlot(ctx, "replication_test", true)
+	require.NoError(t, err)
+	require.Equal(t, "replication_test", slot.Name)
+	require.Equal(t, "pgoutput", slot.OutputPlugin)
+
+	err = r.StartReplication(ctx, slot.Name, slot.ConsistentPoint, "replication_test")
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, "INSERT INTO replication_test VALUES (1, 'foo')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "UPDATE replication_test SET name = 'bar' WHERE id = 1")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "DELETE FROM replication_test WHERE id = 1")
+	require.NoError(t, err)
+
+	var changes []pgdriver.LogicalMessage
+	var commits int
+	for msg, err := range r.Messages(ctx) {
+		require.NoError(t, err)
+
+		switch m := msg.Message.(type) {
+		case *pgdriver.InsertMessage, *pgdriver.UpdateMessage, *pgdriver.DeleteMessage:
+			changes = append(changes, m)
+		case *pgdriver.CommitMessage:
+			commits++
+			r.Ack(m.TransactionEndLSN)
+		}
+		if commits == 3 {
+			break
+		}
+	}
+	require.NoError(t, r.SendStandbyStatus(ctx))
+
+	require.Len(t, changes, 3)
+
+	insert := changes[0].(*pgdriver.InsertMessage)
+	require.NotNil(t, insert.Relation)
+	require.Equal(t, "replication_test", insert.Relation.RelationName)
+	require.Equal(t, map[string]any{"id": "1", "name": "foo"}, insert.New.Values(insert.Relation))
+
+	update := changes[1].(*pgdriver.UpdateMessage)
+	require.Equal(t, map[string]any{"id": "1", "name": "bar"}, update.New.Values(update.Relation))
+
+	del := changes[2].(*pgdriver.DeleteMessage)
+	require.Equal(t, byte('K'), del.OldKind)
+	require.Equal(t, "1", del.Old.Values(del.Relation)["id"])
+
+	require.NoError(t, r.Close())
+
+	_, err = db.ExecContext(ctx, "DROP PUBLICATION replication_test")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "DROP TABLE replication_test")
+	require.NoError(t, err)
+}
@@ -0,0 +1,870 @@
+package dbtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"github.com/uptrace/bun/dialect/sqltype"
+	"github.com/uptrace/bun/extra/bunaudit"
+	"github.com/uptrace/bun/migrate"
+	"github.com/uptrace/bun/migrate/sqlschema"
+)
+
+// sqliteWithForeignKeys returns an SQLite database with foreign key enforcement turned on.
+// PRAGMA foreign_keys is a per-connection setting, so the pool is limited to a single connection.
+func sqliteWithForeignKeys(tb testing.TB) *bun.DB {
+	db := sqlite(tb)
+	db.SetMaxOpenConns(1)
+	_, err := db.Exec("PRAGMA foreign_keys = ON")
+	require.NoError(tb, err)
+	return db
+}
+
+func mustExecSQLite(tb testing.TB, db bun.IConn, queries ...string) {
+	tb.Helper()
+	for _, query := range queries {
+		_, err := db.ExecContext(ctx, query)
+		require.NoError(tb, err, query)
+	}
+}
+
+// appendSQLiteMigration initializes the migrator on the connection and renders the operations.
+func appendSQLiteMigration(tb testing.TB, db *bun.DB, conn bun.IDB, operations ...migrate.Operation) ([]string, error) {
+	tb.Helper()
+
+	m, err := sqlschema.NewMigrator(db, db.Dialect().DefaultSchema())
+	require.NoError(tb, err)
+	require.NoError(tb, m.(sqlschema.StatefulMigrator).Init(ctx, conn))
+
+	var queries []string
+	for _, op := range operations {
+		b, err := m.AppendSQL(nil, op)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, string(b))
+	}
+	return queries, nil
+}
+
+// mustApplySQLiteMigration renders the operations and executes them on a single connection.
+func mustApplySQLiteMigration(tb testing.TB, db *bun.DB, operations ...migrate.Operation) {
+	tb.Helper()
+
+	conn, err := db.Conn(ctx)
+	require.NoError(tb, err)
+	defer conn.Close()
+
+	queries, err := appendSQLiteMigration(tb, db, conn, operations...)
+	require.NoError(tb, err, "append sql")
+	mustExecSQLite(tb, conn, queries...)
+}
+
+func inspectSQLiteTable(tb testing.TB, db *bun.DB, name string) *sqlschema.BaseTable {
+	tb.Helper()
+
+	// Do not pass WithSchemaName: the inspector must default to the main schema.
+	inspector, err := sqlschema.NewInspector(db)
+	require.NoError(tb, err)
+
+	state, err := inspector.Inspect(ctx)
+	require.NoError(tb, err)
+
+	for _, t := range state.GetTables() {
+		if t.GetName() == name {
+			return t.(*sqlschema.BaseTable)
+		}
+	}
+	require.FailNowf(tb, "incomplete schema", "table %q not in schema", name)
+	return nil
+}
+
+func sqliteIndexes(tb testing.TB, db *bun.DB, table string) []string {
+	tb.Helper()
+	var names []string
+	err := db.NewRaw(`SELECT "name" FROM sqlite_master WHERE "type" = 'index' AND "tbl_name" = ? AND "sql" IS NOT NULL ORDER BY "name"`, table).
+		Scan(ctx, &names)
+	require.NoError(tb, err)
+	return names
+}
+
+func TestSQLiteInspector(t *testing.T) {
+	db := sqlite(t)
+	mustExecSQLite(t, db,
+		`CREATE TABLE "parent" ("id" INTEGER PRIMARY KEY AUTOINCREMENT, "code" VARCHAR(10) NOT NULL DEFAULT 'x-1' CHECK (length(code) > 2), "name" TEXT, UNIQUE ("code", "name"), CONSTRAINT "parent_name_check" CHECK ("name" <> ''))`,
+		`CREATE TABLE "child" ("id" BIGINT NOT NULL, "parent_id" INTEGER REFERENCES "parent" ON DELETE CASCADE, "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP, "n" INTEGER DEFAULT (1), PRIMARY KEY ("id"))`,
+		`CREATE INDEX "child_parent_id_idx" ON "child" ("parent_id")`,
+		`CREATE UNIQUE INDEX "child_created_at_idx" ON "child" (date("created_at"), "id") WHERE "n" > 0`,
+	)
+
+	inspector, err := sqlschema.NewInspector(db)
+	require.NoError(t, err)
+
+	state, err := inspector.Inspect(ctx)
+	require.NoError(t, err)
+
+	wantTables := []sqlschema.Table{
+		&sqlschema.BaseTable{
+			Schema: "main",
+			Name:   "parent",
+			Columns: []sqlschema.Column{
+				&sqlschema.BaseColumn{Name: "id", SQLType: sqltype.Integer, IsAutoIncrement: true},
+				&sqlschema.BaseColumn{Name: "code", SQLType: sqltype.VarChar, VarcharLen: 10, DefaultValue: "x-1"},
+				&sqlschema.BaseColumn{Name: "name", SQLType: "text", IsNullable: true},
+			},
+			PrimaryKey:        &sqlschema.PrimaryKey{Columns: sqlschema.NewColumns("id")},
+			UniqueConstraints: []sqlschema.Unique{{Columns: sqlschema.NewColumns("code", "name")}},
+			CheckConstraints: []sqlschema.Check{
+				{Expr: "length(code) > 2"},
+				{Name: "parent_name_check", Expr: `"name" <> ''`},
+			},
+		},
+		&sqlschema.BaseTable{
+			Schema: "main",
+			Name:   "child",
+			Columns: []sqlschema.Column{
+				&sqlschema.BaseColumn{Name: "id", SQLType: sqltype.BigInt},
+				&sqlschema.BaseColumn{Name: "parent_id", SQLType: sqltype.Integer, IsNullable: true},
+				&sqlschema.BaseColumn{Name: "created_at", SQLType: "timestamp", IsNullable: true, DefaultValue: "current_timestamp"},
+				&sqlschema.BaseColumn{Name: "n", SQLType: sqltype.Integer, IsNullable: true, DefaultValue: "1"},
+			},
+			PrimaryKey: &sqlschema.PrimaryKey{Columns: sqlschema.NewColumns("id")},
+			Indexes: []sqlschema.Index{
+				{Name: "child_parent_id_idx", Columns: []string{"parent_id"}},
+				{Name: "child_created_at_idx", Unique: true, Columns: []string{"(date(created_at))", "id"}, Where: "n > 0"},
+			},
+		},
+	}
+	cmpTables(t, db.Dialect().(sqlschema.InspectorDialect), wantTables, state.GetTables())
+
+	var fks []sqlschema.ForeignKey
+	for fk := range state.GetForeignKeys() {
+		fks = append(fks, fk)
+	}
+	require.Equal(t, []sqlschema.ForeignKey{{
+		From: sqlschema.NewColumnReference("child", "parent_id"),
+		To:   sqlschema.NewColumnReference("parent", "id"),
+	}}, fks)
+}
+
+func TestSQLiteMigrator(t *testing.T) {
+	setup := []string{
+		`CREATE TABLE "parent" ("id" INTEGER PRIMARY KEY, "name" VARCHAR(100) NOT NULL, "budget" INTEGER)`,
+		`CREATE TABLE "child" ("id" INTEGER NOT NULL, "parent_id" INTEGER, "note" TEXT DEFAULT 'none', PRIMARY KEY ("id"))`,
+		`CREATE INDEX "parent_name_idx" ON "parent" ("name")`,
+		`CREATE INDEX "parent_lower_name_idx" ON "parent" (lower("name"))`,
+		`CREATE INDEX "parent_budget_idx" ON "parent" ("id") WHERE "budget" > 0`,
+		`INSERT INTO "parent" VALUES (1, 'one', 10), (2, 'two', NULL)`,
+		`INSERT INTO "child" VALUES (1, 1, 'a'), (2, 2, 'b')`,
+	}
+
+	type Created struct {
+		bun.BaseModel `bun:"table:created"`
+		ID            int64  `bun:",pk,autoincrement"`
+		Name          string `bun:",unique"`
+	}
+
+	for _, tt := range []struct {
+		name      string
+		operation migrate.Operation
+		check     func(t *testing.T, db *bun.DB)
+	}{
+		{
+			name:      "create table",
+			operation: &migrate.CreateTableOp{TableName: "created", Model: (*Created)(nil)},
+			check: func(t *testing.T, db *bun.DB) {
+				table := inspectSQLiteTable(t, db, "created")
+				require.Equal(t, sqlschema.NewColumns("id"), table.PrimaryKey.Columns)
+				require.True(t, table.Columns[0].(*sqlschema.BaseColumn).IsAutoIncrement)
+			},
+		},
+		{
+			name:      "drop table",
+			operation: &migrate.DropTableOp{TableName: "child"},
+			check: func(t *testing.T, db *bun.DB) {
+				exists, err := db.NewSelect().Table("sqlite_master").Where("name = 'child'").Exists(ctx)
+				require.NoError(t, err)
+				require.False(t, exists)
+			},
+		},
+		{
+			name:      "rename table",
+			operation: &migrate.RenameTableOp{TableName: "parent", NewName: "renamed"},
+			check: func(t *testing.T, db *bun.DB) {
+				require.Equal(t, []string{"parent_budget_idx", "parent_lower_name_idx", "parent_name_idx"}, sqliteIndexes(t, db, "renamed"))
+			},
+		},
+		{
+			name:      "rename column",
+			operation: &migrate.RenameColumnOp{TableName: "parent", OldName: "name", NewName: "title"},
+			check: func(t *testing.T, db *bun.DB) {
+				checkHasColumn(t, inspectSQLiteTable(t, db, "parent"), "title")
+			},
+		},
+		{
+			name: "add column in place",
+			operation: &migrate.AddColumnOp{
+				TableName:  "parent",
+				ColumnName: "language",
+				Column:     &sqlschema.BaseColumn{SQLType: sqltype.VarChar, VarcharLen: 20, DefaultValue: "en-GB"},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				var language string
+				err := db.NewRaw(`SELECT "language" FROM "parent" WHERE "id" = 1`).Scan(ctx, &language)
+				require.NoError(t, err)
+				require.Equal(t, "en-GB", language)
+			},
+		},
+		{
+			name: "add column with rebuild",
+			operation: &migrate.AddColumnOp{
+				TableName:  "parent",
+				ColumnName: "created_at",
+				Column:     &sqlschema.BaseColumn{SQLType: sqltype.Timestamp, DefaultValue: "current_timestamp"},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				checkHasColumn(t, inspectSQLiteTable(t, db, "parent"), "created_at")
+				require.Equal(t, []string{"parent_budget_idx", "parent_lower_name_idx", "parent_name_idx"}, sqliteIndexes(t, db, "parent"))
+			},
+		},
+		{
+			name:      "drop column",
+			operation: &migrate.DropColumnOp{TableName: "parent", ColumnName: "budget"},
+			check: func(t *testing.T, db *bun.DB) {
+				require.Len(t, inspectSQLiteTable(t, db, "parent").Columns, 2)
+				// Partial index depends on the dropped column.
+				require.Equal(t, []string{"parent_lower_name_idx", "parent_name_idx"}, sqliteIndexes(t, db, "parent"))
+			},
+		},
+		{
+			name: "add primary key",
+			operation: &migrate.AddPrimaryKeyOp{
+				TableName:  "child",
+				PrimaryKey: sqlschema.PrimaryKey{Columns: sqlschema.NewColumns("id", "parent_id")},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				table := inspectSQLiteTable(t, db, "child")
+				require.Equal(t, sqlschema.NewColumns("id", "parent_id"), table.PrimaryKey.Columns)
+			},
+		},
+		{
+			name: "change primary key",
+			operation: &migrate.ChangePrimaryKeyOp{
+				TableName: "child",
+				Old:       sqlschema.PrimaryKey{Columns: sqlschema.NewColumns("id")},
+				New:       sqlschema.PrimaryKey{Columns: sqlschema.NewColumns("parent_id")},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				table := inspectSQLiteTable(t, db, "child")
+				require.Equal(t, sqlschema.NewColumns("parent_id"), table.PrimaryKey.Columns)
+			},
+		},
+		{
+			name: "drop primary key",
+			operation: &migrate.DropPrimaryKeyOp{
+				TableName:  "child",
+				PrimaryKey: sqlschema.PrimaryKey{Columns: sqlschema.NewColumns("id")},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				require.Nil(t, inspectSQLiteTable(t, db, "child").PrimaryKey)
+			},
+		},
+		{
+			name: "add unique constraint",
+			operation: &migrate.AddUniqueConstraintOp{
+				TableName: "child",
+				Unique:    sqlschema.Unique{Name: "one_note_per_parent", Columns: sqlschema.NewColumns("parent_id", "note")},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				table := inspectSQLiteTable(t, db, "child")
+				require.Len(t, table.UniqueConstraints, 1)
+				require.Equal(t, sqlschema.NewColumns("parent_id", "note"), table.UniqueConstraints[0].Columns)
+			},
+		},
+		{
+			name: "change column type",
+			operation: &migrate.ChangeColumnTypeOp{
+				TableName: "parent",
+				Column:    "name",
+				From:      &sqlschema.BaseColumn{SQLType: sqltype.VarChar, VarcharLen: 100},
+				To:        &sqlschema.BaseColumn{SQLType: sqltype.VarChar, VarcharLen: 200, IsNullable: true, DefaultValue: "anonymous"},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				col := inspectSQLiteTable(t, db, "parent").Columns[1].(*sqlschema.BaseColumn)
+				require.Equal(t, 200, col.VarcharLen)
+				require.True(t, col.IsNullable)
+				require.Equal(t, "anonymous", col.DefaultValue)
+				require.Equal(t, []string{"parent_budget_idx", "parent_lower_name_idx", "parent_name_idx"}, sqliteIndexes(t, db, "parent"))
+			},
+		},
+		{
+			name: "add foreign key",
+			operation: &migrate.AddForeignKeyOp{
+				ForeignKey: sqlschema.ForeignKey{
+					From: sqlschema.NewColumnReference("child", "parent_id"),
+					To:   sqlschema.NewColumnReference("parent", "id"),
+				},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				var n int
+				err := db.NewRaw(`SELECT count(*) FROM pragma_foreign_key_list('child')`).Scan(ctx, &n)
+				require.NoError(t, err)
+				require.Equal(t, 1, n)
+			},
+		},
+		{
+			name: "create index",
+			operation: &migrate.CreateIndexOp{
+				TableName: "child",
+				Index:     sqlschema.Index{Name: "child_note_idx", Columns: []string{"(lower(note))", "parent_id"}, Where: "parent_id IS NOT NULL"},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				require.Equal(t, []sqlschema.Index{
+					{Name: "child_note_idx", Columns: []string{"(lower(note))", "parent_id"}, Where: "(parent_id IS NOT NULL)"},
+				}, inspectSQLiteTable(t, db, "child").Indexes)
+			},
+		},
+		{
+			name: "drop index",
+			operation: &migrate.DropIndexOp{
+				TableName: "parent",
+				Index:     sqlschema.Index{Name: "parent_lower_name_idx"},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				require.Equal(t, []string{"parent_budget_idx", "parent_name_idx"}, sqliteIndexes(t, db, "parent"))
+			},
+		},
+		{
+			name: "add check constraint",
+			operation: &migrate.AddCheckConstraintOp{
+				TableName: "parent",
+				Check:     sqlschema.Check{Name: "parent_budget_check", Expr: "budget >= 0"},
+			},
+			check: func(t *testing.T, db *bun.DB) {
+				require.Equal(t, []sqlschema.Check{
+					{Name: "parent_budget_check", Expr: "budget >= 0"},
+				}, inspectSQLiteTable(t, db, "parent").CheckConstraints)
+
+				_, err := db.NewRaw(`INSERT INTO "parent" VALUES (3, 'three', -1)`).Exec(ctx)
+				require.ErrorContains(t, err, "CHECK constraint failed")
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			db := sqlite(t)
+			mustExecSQLite(t, db, setup...)
+
+			mustApplySQLiteMigration(t, db, tt.operation)
+			tt.check(t, db)
+
+			// Existing data must be preserved.
+			var n int
+			err := db.NewRaw(`SELECT count(*) FROM sqlite_master WHERE "name" = 'parent'`).Scan(ctx, &n)
+			require.NoError(t, err)
+			if n > 0 {
+				count, err := db.NewSelect().Table("parent").Count(ctx)
+				require.NoError(t, err)
+				require.Equal(t, 2, count)
+			}
+		})
+	}
+
+	t.Run("drop unique and foreign key", func(t *testing.T) {
+		db := sqlite(t)
+		mustExecSQLite(t, db,
+			`CREATE TABLE "parent" ("id" INTEGER PRIMARY KEY, "name" VARCHAR(100) UNIQUE)`,
+			`CREATE TABLE "child" ("id" INTEGER PRIMARY KEY, "parent_id" INTEGER REFERENCES "parent" ("id"))`,
+		)
+
+		mustApplySQLiteMigration(t, db,
+			&migrate.DropUniqueConstraintOp{
+				TableName: "parent",
+				Unique:    sqlschema.Unique{Columns: sqlschema.NewColumns("name")},
+			},
+			&migrate.DropForeignKeyOp{
+				ForeignKey: sqlschema.ForeignKey{
+					From: sqlschema.NewColumnReference("child", "parent_id"),
+					To:   sqlschema.NewColumnReference("parent", "id"),
+				},
+			},
+		)
+
+		require.Empty(t, inspectSQLiteTable(t, db, "parent").UniqueConstraints)
+
+		var n int
+		err := db.NewRaw(`SELECT count(*) FROM pragma_foreign_key_list('child')`).Scan(ctx, &n)
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("rename column used by expression index", func(t *testing.T) {
+		db := sqlite(t)
+		mustExecSQLite(t, db, setup...)
+
+		// The index is re-created from the tracked definition during the rebuild,
+		// which must refer to the column by its new name.
+		mustApplySQLiteMigration(t, db,
+			&migrate.RenameColumnOp{TableName: "parent", OldName: "name", NewName: "title"},
+			&migrate.RenameTableOp{TableName: "parent", NewName: "renamed"},
+			&migrate.DropColumnOp{TableName: "renamed", ColumnName: "budget"},
+			&migrate.RenameColumnOp{TableName: "renamed", OldName: "title", NewName: "name"},
+		)
+		require.Equal(t, []string{"parent_lower_name_idx", "parent_name_idx"}, sqliteIndexes(t, db, "renamed"))
+	})
+
+	t.Run("drop column used by expression index", func(t *testing.T) {
+		db := sqlite(t)
+		mustExecSQLite(t, db, setup...)
+
+		mustApplySQLiteMigration(t, db, &migrate.DropColumnOp{TableName: "parent", ColumnName: "name"})
+		require.Equal(t, []string{"parent_budget_idx"}, sqliteIndexes(t, db, "parent"))
+	})
+
+	t.Run("created index survives rebuild", func(t *testing.T) {
+		db := sqlite(t)
+		mustExecSQLite(t, db, setup...)
+
+		mustApplySQLiteMigration(t, db,
+			&migrate.CreateIndexOp{
+				TableName: "child",
+				Index:     sqlschema.Index{Name: "child_parent_id_idx", Unique: true, Columns: []string{"parent_id"}},
+			},
+			&migrate.DropIndexOp{
+				TableName: "parent",
+				Index:     sqlschema.Index{Name: "parent_name_idx"},
+			},
+			&migrate.DropColumnOp{TableName: "child", ColumnName: "note"},
+			&migrate.DropColumnOp{TableName: "parent", ColumnName: "budget"},
+		)
+		require.Equal(t, []string{"child_parent_id_idx"}, sqliteIndexes(t, db, "child"))
+		require.Equal(t, []string{"parent_lower_name_idx"}, sqliteIndexes(t, db, "parent"))
+	})
+
+	t.Run("check constraints survive rebuild", func(t *testing.T) {
+		db := sqlite(t)
+		mustExecSQLite(t, db,
+			`CREATE TABLE "things" ("id" INTEGER PRIMARY KEY, "price" INTEGER CHECK (price > 0), "discount" INTEGER, "note" TEXT, CONSTRAINT "things_discount_check" CHECK ("discount" < "price"))`,
+		)
+
+		mustApplySQLiteMigration(t, db,
+			&migrate.RenameColumnOp{TableName: "things", OldName: "price", NewName: "cost"},
+			&migrate.DropColumnOp{TableName: "things", ColumnName: "note"},
+			&migrate.DropCheckConstraintOp{TableName: "things", Check: sqlschema.Check{Expr: "cost > 0"}},
+		)
+		require.Equal(t, []sqlschema.Check{
+			{Name: "things_discount_check", Expr: `"discount" < "cost"`},
+		}, inspectSQLiteTable(t, db, "things").CheckConstraints)
+
+		_, err := db.NewRaw(`INSERT INTO "things" ("cost", "discount") VALUES (0, -1)`).Exec(ctx)
+		require.NoError(t, err)
+		_, err = db.NewRaw(`INSERT INTO "things" ("cost", "discount") VALUES (1, 1)`).Exec(ctx)
+		require.ErrorContains(t, err, "CHECK constraint failed")
+	})
+
+	t.Run("index method is not supported", func(t *testing.T) {
+		db := sqlite(t)
+		mustExecSQLite(t, db, setup...)
+
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = appendSQLiteMigration(t, db, conn, &migrate.CreateIndexOp{
+			TableName: "parent",
+			Index:     sqlschema.Index{Name: "parent_name_hash_idx", Columns: []string{"name"}, Method: "hash"},
+		})
+		require.ErrorContains(t, err, "index method")
+	})
+
+	t.Run("autoincrement is only allowed for integer primary key", func(t *testing.T) {
+		db := sqlite(t)
+		mustExecSQLite(t, db, setup...)
+
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = appendSQLiteMigration(t, db, conn, &migrate.AddColumnOp{
+			TableName:  "parent",
+			ColumnName: "n",
+			Column:     &sqlschema.BaseColumn{SQLType: sqltype.BigInt, IsAutoIncrement: true},
+		})
+		require.ErrorContains(t, err, "AUTOINCREMENT")
+	})
+}
+
+func TestSQLiteMigrator_ForeignKeys(t *testing.T) {
+	setup := []string{
+		`CREATE TABLE "parent" ("id" INTEGER PRIMARY KEY, "name" VARCHAR(100))`,
+		`CREATE TABLE "child" ("id" INTEGER PRIMARY KEY, "parent_id" INTEGER REFERENCES "parent" ("id") ON DELETE CASCADE)`,
+		`CREATE INDEX "parent_lower_name_idx" ON "parent" (lower("name"))`,
+		`INSERT INTO "parent" VALUES (1, 'one')`,
+		`INSERT INTO "child" VALUES (1, 1)`,
+	}
+
+	changeType := &migrate.ChangeColumnTypeOp{
+		TableName: "parent",
+		Column:    "name",
+		From:      &sqlschema.BaseColumn{SQLType: sqltype.VarChar, VarcharLen: 100, IsNullable: true},
+		To:        &sqlschema.BaseColumn{SQLType: sqltype.VarChar, VarcharLen: 200, IsNullable: true},
+	}
+
+	countChildren := func(t *testing.T, db bun.IDB) int {
+		count, err := db.NewSelect().Table("child").Count(ctx)
+		require.NoError(t, err)
+		return count
+	}
+
+	t.Run("rebuild does not cascade", func(t *testing.T) {
+		db := sqliteWithForeignKeys(t)
+		mustExecSQLite(t, db, setup...)
+
+		mustApplySQLiteMigration(t, db, changeType)
+
+		require.Equal(t, 1, countChildren(t, db))
+		require.Equal(t, []string{"parent_lower_name_idx"}, sqliteIndexes(t, db, "parent"))
+
+		var enabled bool
+		require.NoError(t, db.NewRaw("PRAGMA foreign_keys").Scan(ctx, &enabled))
+		require.True(t, enabled, "foreign keys must be enforced after the migration")
+	})
+
+	t.Run("rebuild in transaction fails", func(t *testing.T) {
+		db := sqliteWithForeignKeys(t)
+		mustExecSQLite(t, db, setup...)
+
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+
+		queries, err := appendSQLiteMigration(t, db, tx, changeType)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(ctx, queries[0])
+		require.Error(t, err)
+		require.NoError(t, tx.Rollback())
+
+		require.Equal(t, 1, countChildren(t, db))
+	})
+
+	t.Run("rebuild fails on foreign key violation", func(t *testing.T) {
+		db := sqliteWithForeignKeys(t)
+		mustExecSQLite(t, db, setup...)
+		mustExecSQLite(t, db,
+			`CREATE TABLE "orphan" ("id" INTEGER PRIMARY KEY, "parent_id" INTEGER)`,
+			`INSERT INTO "orphan" VALUES (1, 42)`,
+		)
+
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+
+		queries, err := appendSQLiteMigration(t, db, conn, &migrate.AddForeignKeyOp{
+			ForeignKey: sqlschema.ForeignKey{
+				From: sqlschema.NewColumnReference("orphan", "parent_id"),
+				To:   sqlschema.NewColumnReference("parent", "id"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = conn.ExecContext(ctx, queries[0])
+		require.ErrorContains(t, err, "foreign_key_violation")
+		_, err = conn.ExecContext(ctx, "ROLLBACK")
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+
+		var n int
+		err = db.NewRaw(`SELECT count(*) FROM pragma_foreign_key_list('orphan')`).Scan(ctx, &n)
+		require.NoError(t, err)
+		require.Zero(t, n, "foreign key must not be added")
+	})
+}
+
+func TestSQLiteAutoMigrator(t *testing.T) {
+	type ParentBefore struct {
+		bun.BaseModel `bun:"table:parents"`
+		ID            int64  `bun:",pk,autoincrement"`
+		Name          string `bun:",type:varchar(100)"`
+	}
+
+	type ParentAfter struct {
+		bun.BaseModel `bun:"table:parents"`
+		ID            int64  `bun:",pk,autoincrement"`
+		Name          string `bun:",type:varchar(200),notnull"`
+	}
+
+	t.Run("migrate twice", func(t *testing.T) {
+		db := sqlite(t)
+		cleanupMigrations(t, ctx, db)
+		mustResetModel(t, ctx, db, (*ParentBefore)(nil))
+		_, err := db.NewInsert().Model(&ParentBefore{Name: "one"}).Exec(ctx)
+		require.NoError(t, err)
+
+		m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*ParentAfter)(nil)))
+		runMigrations(t, m)
+
+		// The second run must not detect any changes.
+		group, err := m.Migrate(ctx)
+		require.NoError(t, err)
+		require.True(t, group.IsZero(), "unexpected migrations: %v", group)
+
+		count, err := db.NewSelect().Model((*ParentAfter)(nil)).Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("non-primary key autoincrement", func(t *testing.T) {
+		type Counter struct {
+			bun.BaseModel `bun:"table:counters"`
+			ID            int64 `bun:",pk"`
+			N             int64 `bun:",autoincrement"`
+		}
+
+		db := sqlite(t)
+		cleanupMigrations(t, ctx, db)
+		mustDropTableOnCleanup(t, ctx, db, (*Counter)(nil))
+
+		m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*Counter)(nil)))
+		_, err := m.Migrate(ctx)
+		require.ErrorContains(t, err, "AUTOINCREMENT")
+	})
+}
+
+func TestSQLiteGeneratedColumns(t *testing.T) {
+	type Item struct {
+		bun.BaseModel `bun:"table:items"`
+		ID            int64 `bun:",pk,autoincrement"`
+		Price         int64
+		Qty           int64
+		Note          string
+		Total         int64  `bun:",generated:price * qty"`
+		Label         string `bun:",generated:\"'#' || id\",virtual"`
+	}
+
+	db := sqlite(t)
+	mustResetModel(t, ctx, db, (*Item)(nil))
+
+	item := &Item{Price: 2, Qty: 3}
+	_, err := db.NewInsert().Model(item).Exec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), item.Total)
+	require.Equal(t, "#1", item.Label)
+
+	item.Qty = 5
+	_, err = db.NewUpdate().Model(item).WherePK().Exec(ctx)
+	require.NoError(t, err)
+
+	got := new(Item)
+	require.NoError(t, db.NewSelect().Model(got).Where("id = ?", item.ID).Scan(ctx))
+	require.Equal(t, int64(10), got.Total)
+
+	column := func(name string) sqlschema.Column {
+		for _, col := range inspectSQLiteTable(t, db, "items").GetColumns() {
+			if col.GetName() == name {
+				return col
+			}
+		}
+		require.FailNowf(t, "incomplete schema", "column %q not in table", name)
+		return nil
+	}
+
+	total := column("total")
+	require.True(t, total.GetIsGenerated())
+	require.False(t, total.GetIsVirtual())
+	require.Equal(t, "price * qty", total.GetGeneratedExpr())
+
+	label := column("label")
+	require.True(t, label.GetIsGenerated())
+	require.True(t, label.GetIsVirtual())
+	require.Equal(t, "'#' || id", label.GetGeneratedExpr())
+
+	// Dropping a column rebuilds the table, which must keep the generated columns.
+	mustApplySQLiteMigration(t, db,
+		&migrate.DropColumnOp{TableName: "items", ColumnName: "note", Column: &sqlschema.BaseColumn{SQLType: "varchar"}},
+		&migrate.RenameColumnOp{TableName: "items", OldName: "qty", NewName: "quantity"},
+	)
+
+	total = column("total")
+	require.True(t, total.GetIsGenerated())
+	require.Equal(t, `price * "quantity"`, total.GetGeneratedExpr())
+
+	var n int64
+	require.NoError(t, db.NewRaw(`SELECT "total" FROM "items" WHERE "id" = ?`, item.ID).Scan(ctx, &n))
+	require.Equal(t, int64(10), n)
+}
+
+func TestSQLiteViews(t *testing.T) {
+	type User struct {
+		bun.BaseModel `bun:"table:view_users"`
+		ID            int64 `bun:",pk,autoincrement"`
+		Name          string
+		Active        bool
+	}
+
+	type ActiveUser struct {
+		bun.BaseModel `bun:"view:view_active_users"`
+		ID            int64
+		Name          string
+	}
+
+	db := sqlite(t)
+	mustResetModel(t, ctx, db, (*User)(nil))
+
+	_, err := db.NewCreateView().Model((*ActiveUser)(nil)).IfNotExists().
+		As(db.NewSelect().Model((*User)(nil)).Column("id", "name").Where("active")).
+		Exec(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if _, err := db.NewDropView().Model((*ActiveUser)(nil)).IfExists().Exec(ctx); err != nil {
+			t.Logf("cleanup: drop view: %v", err)
+		}
+	})
+
+	users := []User{{Name: "one", Active: true}, {Name: "two"}}
+	_, err = db.NewInsert().Model(&users).Exec(ctx)
+	require.NoError(t, err)
+
+	var active []ActiveUser
+	require.NoError(t, db.NewSelect().Model(&active).Scan(ctx))
+	require.Equal(t, []ActiveUser{{ID: 1, Name: "one"}}, active)
+
+	_, err = db.NewInsert().Model(&ActiveUser{Name: "three"}).Exec(ctx)
+	require.ErrorContains(t, err, "is a view")
+
+	// The auto-migrator must not create a table for the view.
+	cleanupMigrations(t, ctx, db)
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*User)(nil), (*ActiveUser)(nil)))
+	group, err := m.Migrate(ctx)
+	require.NoError(t, err)
+	require.True(t, group.IsZero(), "unexpected migrations: %v", group)
+
+	_, err = db.NewDropView().Model((*ActiveUser)(nil)).Exec(ctx)
+	require.NoError(t, err)
+}
+
+type AuditAccount struct {
+	bunaudit.Audited
+
+	ID      int64 `bun:",pk"`
+	Name    string
+	Balance int64
+}
+
+func TestSQLiteAudit(t *testing.T) {
+	type actorKey struct{}
+
+	type Test struct {
+		name    string
+		dialect *sqlitedialect.Dialect
+	}
+
+	tests := []Test{
+		{name: "returning", dialect: sqlitedialect.New()},
+		{name: "pre-select", dialect: sqlitedialect.New(sqlitedialect.WithoutFeature(
+			feature.Returning | feature.InsertReturning | feature.DeleteReturning))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := bun.NewDB(sqlite(t).DB, test.dialect)
+			auditor := bunaudit.New(db, bunaudit.WithActor(func(ctx context.Context) string {
+				actor, _ := ctx.Value(actorKey{}).(string)
+				return actor
+			}))
+			mustResetModel(t, ctx, db, (*AuditAccount)(nil), (*bunaudit.Entry)(nil))
+			require.NoError(t, auditor.CreateTable(ctx, db))
+
+			ctx := context.WithValue(ctx, actorKey{}, "admin")
+			err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+				accounts := []AuditAccount{
+					{ID: 1, Name: "one", Balance: 10},
+					{ID: 2, Name: "two", Balance: 20},
+					{ID: 3, Name: "three", Balance: 30},
+				}
+				if _, err := tx.NewInsert().Model(&accounts).Exec(ctx); err != nil {
+					return err
+				}
+
+				if _, err := tx.NewUpdate().
+					Model(&AuditAccount{ID: 1, Name: "one", Balance: 15}).
+					WherePK().
+					Exec(ctx); err != nil {
+					return err
+				}
+
+				accounts[1].Name = "second"
+				accounts[2].Balance = 35
+				bulk := accounts[1:]
+				if _, err := tx.NewUpdate().Model(&bulk).Bulk().Exec(ctx); err != nil {
+					return err
+				}
+
+				if _, err := tx.NewUpdate().
+					Model((*AuditAccount)(nil)).
+					Set("balance = balance + 1").
+					Where("balance > ?", 30).
+					Exec(ctx); err != nil {
+					return err
+				}
+
+				if _, err := tx.NewDelete().Model(&AuditAccount{ID: 1}).WherePK().Exec(ctx); err != nil {
+					return err
+				}
+				_, err := tx.NewDelete().Model((*AuditAccount)(nil)).Where("name = ?", "second").Exec(ctx)
+				return err
+			})
+			require.NoError(t, err)
+
+			var entries []bunaudit.Entry
+			err = db.NewSelect().Model(&entries).Order("id").Scan(ctx)
+			require.NoError(t, err)
+
+			type change struct {
+				Op     string
+				Key    any
+				Before map[string]any
+				After  map[string]any
+			}
+			var changes []change
+			for _, entry := range entries {
+				require.Equal(t, "audit_accounts", entry.TableName)
+				require.Equal(t, "admin", entry.Actor)
+				changes = append(changes, change{
+					Op:     entry.Operation,
+					Key:    entry.Key["id"],
+					Before: entry.Before,
+					After:  entry.After,
+				})
+			}
+
+			// JSON decodes the numbers as float64.
+			require.Equal(t, []change{
+				{Op: "INSERT", Key: 1.0, After: map[string]any{"id": 1.0, "name": "one", "balance": 10.0}},
+				{Op: "INSERT", Key: 2.0, After: map[string]any{"id": 2.0, "name": "two", "balance": 20.0}},
+				{Op: "INSERT", Key: 3.0, After: map[string]any{"id": 3.0, "name": "three", "balance": 30.0}},
+				{Op: "UPDATE", Key: 1.0, Before: map[string]any{"balance": 10.0}, After: map[string]any{"balance": 15.0}},
+				{Op: "UPDATE", Key: 2.0, Before: map[string]any{"name": "two"}, After: map[string]any{"name": "second"}},
+				{Op: "UPDATE", Key: 3.0, Before: map[string]any{"balance": 30.0}, After: map[string]any{"balance": 35.0}},
+				{Op: "UPDATE", Key: 3.0, Before: map[string]any{"balance": 35.0}, After: map[string]any{"balance": 36.0}},
+				{Op: "DELETE", Key: 1.0, Before: map[string]any{"id": 1.0, "name": "one", "balance": 15.0}},
+				{Op: "DELETE", Key: 2.0, Before: map[string]any{"id": 2.0, "name": "second", "balance": 20.0}},
+			}, changes)
+
+			// The entries are rolled back with the transaction.
+			errRollback := errors.New("rollback")
+			err = db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+				if _, err := tx.NewInsert().Model(&AuditAccount{ID: 4}).Exec(ctx); err != nil {
+					return err
+				}
+				return errRollback
+			})
+			require.ErrorIs(t, err, errRollback)
+
+			count, err := db.NewSelect().Model((*bunaudit.Entry)(nil)).Count(ctx)
+			require.NoError(t, err)
+			require.Equal(t, len(changes), count)
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package bun
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/schema"
+)
+
+// KeysetPage holds the cursors of the pages around the one scanned by SelectQuery.ScanKeyset.
+// The cursors are opaque strings which can be passed to SelectQuery.Keyset as is.
+type KeysetPage struct {
+	Next string // empty if there are no more rows
+	Prev string // empty on the first page
+}
+
+var errInvalidKeysetCursor = errors.New("bun: invalid keyset cursor")
+
+type keyset struct {
+	columns []keysetColumn
+	limit   int
+
+	hasCursor bool
+	prev      bool // the cursor points to the first row of the next page
+}
+
+type keysetColumn struct {
+	field *schema.Field
+	desc  bool
+}
+
+// keysetCursor is the JSON representation of the cursor.
+type keysetCursor struct {
+	Prev   bool              `json:"p,omitempty"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// Keyset paginates the query by the key columns, which must uniquely identify a row.
+// The columns are ordered ascending unless they are followed by DESC, e.g. "created_at DESC", "id DESC".
+// The cursor is empty for the first page, otherwise it is one of the cursors returned by ScanKeyset.
+//
+// Keyset orders the query by the columns, adds the predicate which skips the rows before the cursor
+// and fetches one more row than the limit to find out if there are more pages.
+// It must be called after Model with a pointer to a slice.
+func (q *SelectQuery) Keyset(cursor string, limit int, columns ...string) *SelectQuery {
+	if q.table == nil {
+		q.setErr(errNilModel)
+		return q
+	}
+	if limit <= 0 {
+		q.setErr(fmt.Errorf("bun: Keyset requires a positive limit, got %d", limit))
+		return q
+	}
+	if len(columns) == 0 {
+		q.setErr(errors.New("bun: Keyset requires at least one column"))
+		return q
+	}
+
+	ks := &keyset{limit: limit}
+	for _, column := range columns {
+		name, order, _ := strings.Cut(strings.TrimSpace(column), " ")
+		field, err := q.table.Field(name)
+		if err != nil {
+			q.setErr(err)
+			return q
+		}
+
+		col := keysetColumn{field: field}
+		switch strings.ToUpper(strings.TrimSpace(order)) {
+		case "", "ASC":
+		case "DESC":
+			col.desc = true
+		default:
+			q.setErr(fmt.Errorf("bun: Keyset: unsupported order %q", column))
+			return q
+		}
+		ks.columns = append(ks.columns, col)
+	}
+
+	if cursor != "" {
+		values, err := ks.decode(cursor)
+		if err != nil {
+			q.setErr(err)
+			return q
+		}
+		q.addWhere(ks.where(q.table, values, q.db.HasFeature(feature.CompositeIn)))
+	}
+
+	for _, col := range ks.columns {
+		// The previous page is selected in the reverse order and reversed after scanning.
+		if col.desc != ks.prev {
+			q.OrderExpr("?.? DESC", q.table.SQLAlias, col.field.SQLName)
+		} else {
+			q.OrderExpr("?.? ASC", q.table.SQLAlias, col.field.SQLName)
+		}
+	}
+	q.setLimit(limit + 1)
+
+	q.keyset = ks
+	return q
+}
+
+// ScanKeyset scans the page selected by Keyset into the model
+// and returns the cursors of the next and previous pages.
+func (q *SelectQuery) ScanKeyset(ctx context.Context) (KeysetPage, error) {
+	if q.err != nil {
+		return KeysetPage{}, q.err
+	}
+	if q.keyset == nil {
+		return KeysetPage{}, errors.New("bun: ScanKeyset requires Keyset")
+	}
+	model, ok := q.model.(*sliceTableModel)
+	if !ok {
+		return KeysetPage{}, fmt.Errorf("bun: ScanKeyset requires a slice model, got %T", q.model)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return KeysetPage{}, err
+	}
+
+	ks := q.keyset
+	slice := model.slice
+	n := slice.Len()
+	hasMore := n > ks.limit
+	if hasMore {
+		n = ks.limit
+		slice.Set(slice.Slice(0, n))
+	}
+	if n == 0 {
+		return KeysetPage{}, nil
+	}
+	if ks.prev {
+		swap := reflect.Swapper(slice.Interface())
+		for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+
+	first, last := indirect(slice.Index(0)), indirect(slice.Index(n-1))
+
+	var page KeysetPage
+	var err error
+	if hasMore || ks.prev {
+		if page.Next, err = ks.encode(last, false); err != nil {
+			return KeysetPage{}, err
+		}
+	}
+	if hasMore && ks.prev || ks.hasCursor && !ks.prev {
+		if page.Prev, err = ks.encode(first, true); err != nil {
+			return KeysetPage{}, err
+		}
+	}
+	return page, nil
+}
+
+// where returns the predicate which matches the rows after the cursor in the keyset order.
+// Tuple comparison only works if all columns are ordered in the same direction.
+func (ks *keyset) where(table *schema.Table, values []any, compositeIn bool) schema.QueryWithSep {
+	sameOrder := true
+	for _, col := range ks.columns[1:] {
+		if col.desc != ks.columns[0].desc {
+			sameOrder = false
+		}
+	}
+
+	var b []byte
+	var args []any
+
+	if compositeIn && sameOrder && len(ks.columns) > 1 {
+		b = append(b, '(')
+		for i, col := range ks.columns {
+			if i > 0 {
+				b = append(b, ", "...)
+			}
+			b = appendKeysetColumn(b, table, col)
+		}
+		b = append(b, ") "...)
+		b = append(b, ks.operator(ks.columns[0])...)
+		b = append(b, " ("...)
+		for i := range ks.columns {
+			if i > 0 {
+				b = append(b, ", "...)
+			}
+			b = append(b, '?')
+		}
+		b = append(b, ')')
+		return schema.SafeQueryWithSep(string(b), values, " AND ")
+	}
+
+	// (a > ?) OR (a = ? AND b > ?) OR ...
+	for i, col := range ks.columns {
+		if i > 0 {
+			b = append(b, " OR "...)
+		}
+		b = append(b, '(')
+		for j := range i {
+			b = appendKeysetColumn(b, table, ks.columns[j])
+			b = append(b, " = ? AND "...)
+			args = append(args, values[j])
+		}
+		b = appendKeysetColumn(b, table, col)
+		b = append(b, ' ')
+		b = append(b, ks.operator(col)...)
+		b = append(b, " ?)"...)
+		args = append(args, values[i])
+	}
+	return schema.SafeQueryWithSep(string(b), args, " AND ")
+}
+
+func (ks *keyset) operator(col keysetColumn) string {
+	if col.desc != ks.prev {
+		return "<"
+	}
+	return ">"
+}
+
+func appendKeysetColumn(b []byte, table *schema.Table, col keysetColumn) []byte {
+	b = append(b, table.SQLAlias...)
+	b = append(b, '.')
+	b = append(b, col.field.SQLName...)
+	return b
+}
+
+func (ks *keyset) encode(strct reflect.Value, prev bool) (string, error) {
+	cursor := keysetCursor{Prev: prev}
+	for _, col := range ks.columns {
+		value, err := json.Marshal(col.field.Value(strct).Interface())
+		if err != nil {
+			return "", fmt.Errorf("bun: Keyset: %s: %w", col.field.GoName, err)
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decode returns the values of the key columns, decoded into the types of the model fields.
+func (ks *keyset) decode(s string) ([]any, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidKeysetCursor
+	}
+	var cursor keysetCursor
+	if err := json.Unmarshal(b, &cursor); err != nil || len(cursor.Values) != len(ks.columns) {
+		return nil, errInvalidKeysetCursor
+	}
+
+	values := make([]any, len(ks.columns))
+	for i, col := range ks.columns {
+		v := reflect.New(col.field.StructField.Type)
+		if err := json.Unmarshal(cursor.Values[i], v.Interface()); err != nil {
+			return nil, errInvalidKeysetCursor
+		}
+		values[i] = v.Elem().Interface()
+	}
+
+	ks.hasCursor = true
+	ks.prev = cursor.Prev
+	return values, nil
+}
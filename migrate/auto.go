@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/internal"
 	"github.com/uptrace/bun/migrate/sqlschema"
 	"github.com/uptrace/bun/schema"
@@ -49,6 +51,33 @@ func WithExcludeForeignKeys(fks ...sqlschema.ForeignKey) AutoMigratorOption {
 	}
 }
 
+// WithManageIndexes tells AutoMigrator to drop the indexes which are not declared by any model.
+// By default, AutoMigrator only creates and re-creates the indexes declared by the models and keeps
+// other indexes, e.g. those created with CreateIndexQuery or in SQL migrations.
+func WithManageIndexes() AutoMigratorOption {
+	return func(m *AutoMigrator) {
+		m.diffOpts = append(m.diffOpts, withManageIndexes(true))
+	}
+}
+
+// WithManageCheckConstraints tells AutoMigrator to drop the check constraints which are not declared
+// by any model. By default, AutoMigrator only adds the check constraints declared by the models and keeps
+// other constraints, e.g. those created in SQL migrations.
+func WithManageCheckConstraints() AutoMigratorOption {
+	return func(m *AutoMigrator) {
+		m.diffOpts = append(m.diffOpts, withManageChecks(true))
+	}
+}
+
+// WithManageEnums tells AutoMigrator to drop the enumerated types which are not declared by any model.
+// By default, AutoMigrator only creates the types declared by the models and adds new values to them,
+// keeping other types, e.g. those created in SQL migrations or used by tables outside the migration scope.
+func WithManageEnums() AutoMigratorOption {
+	return func(m *AutoMigrator) {
+		m.diffOpts = append(m.diffOpts, withManageEnums(true))
+	}
+}
+
 // WithSchemaName sets the database schema to migrate objects in.
 // By default, dialects' default schema is used.
 func WithSchemaName(schemaName string) AutoMigratorOption {
@@ -175,7 +204,10 @@ func NewAutoMigrator(db *bun.DB, opts ...AutoMigratorOption) (*AutoMigrator, err
 		return nil, err
 	}
 	am.dbInspector = dbInspector
-	am.diffOpts = append(am.diffOpts, withCompareTypeFunc(db.Dialect().(sqlschema.InspectorDialect).CompareType))
+	am.diffOpts = append(am.diffOpts,
+		withCompareTypeFunc(db.Dialect().(sqlschema.InspectorDialect).CompareType),
+		withComments(db.HasFeature(feature.CommentOn) || db.HasFeature(feature.InlineComment)),
+	)
 
 	dbMigrator, err := sqlschema.NewMigrator(db, am.schemaName)
 	if err != nil {
@@ -246,6 +278,9 @@ func (am *AutoMigrator) CreateSQLMigrations(ctx context.Context) ([]*MigrationFi
 
 // CreateTxSQLMigration writes required changes to a new migration file making sure they will be executed
 // in a transaction when applied. Use migrate.Migrator to apply the generated migrations.
+//
+// SQLite re-creates tables to apply most of the changes, which requires turning off foreign key
+// enforcement outside of a transaction. If it is on, such migrations fail without changing anything.
 func (am *AutoMigrator) CreateTxSQLMigrations(ctx context.Context) ([]*MigrationFile, error) {
 	_, files, err := am.createSQLMigrations(ctx, true)
 	if err == errNothingToMigrate {
@@ -282,6 +317,14 @@ func (am *AutoMigrator) createSQLMigrations(ctx context.Context, transactional b
 		return name + map[bool]string{true: ".tx.", false: "."}[transactional] + direction + ".sql"
 	}
 
+	// Up and down migrations are rendered one after another, so that stateful migrators
+	// render the down migration against the schema state the up migration leaves behind.
+	if sm, ok := am.dbMigrator.(sqlschema.StatefulMigrator); ok {
+		if err := sm.Init(ctx, am.db); err != nil {
+			return nil, nil, fmt.Errorf("create sql migrations: %w", err)
+		}
+	}
+
 	up, err := am.createSQL(ctx, migrations, fname("up"), changes, transactional)
 	if err != nil {
 		return nil, nil, fmt.Errorf("create sql migration up: %w", err)
@@ -297,7 +340,7 @@ func (am *AutoMigrator) createSQLMigrations(ctx context.Context, transactional b
 func (am *AutoMigrator) createSQL(_ context.Context, migrations *Migrations, fname string, changes *changeset, transactional bool) (*MigrationFile, error) {
 	var buf bytes.Buffer
 
-	if transactional {
+	if transactional && am.db.Dialect().Name() == dialect.PG {
 		buf.WriteString("SET statement_timeout = 0;")
 	}
 
@@ -350,11 +393,25 @@ func (c *changeset) Down(m sqlschema.Migrator) MigrationFunc {
 }
 
 // apply generates SQL for each operation and executes it.
+// Stateful migrators are initialized with the current schema and all operations
+// are executed on the same connection.
 func (c *changeset) apply(ctx context.Context, db *bun.DB, m sqlschema.Migrator) error {
 	if len(c.operations) == 0 {
 		return nil
 	}
 
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("apply changes: %w", err)
+	}
+	defer conn.Close()
+
+	if sm, ok := m.(sqlschema.StatefulMigrator); ok {
+		if err := sm.Init(ctx, conn); err != nil {
+			return fmt.Errorf("apply changes: %w", err)
+		}
+	}
+
 	for _, op := range c.operations {
 		if _, skip := op.(*Unimplemented); skip {
 			continue
@@ -367,7 +424,8 @@ func (c *changeset) apply(ctx context.Context, db *bun.DB, m sqlschema.Migrator)
 		}
 
 		query := internal.String(b)
-		if _, err = db.ExecContext(ctx, query); err != nil {
+		if _, err = conn.ExecContext(ctx, query); err != nil {
+			discardConn(conn)
 			return fmt.Errorf("apply changes: %w", err)
 		}
 	}
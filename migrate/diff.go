@@ -1,6 +1,8 @@
 package migrate
 
 import (
+	"slices"
+
 	"github.com/uptrace/bun/internal/ordered"
 	"github.com/uptrace/bun/migrate/sqlschema"
 )
@@ -26,6 +28,8 @@ func (d *detector) detectChanges() *changeset {
 	currentTables := toOrderedMap(d.current.GetTables())
 	targetTables := toOrderedMap(d.target.GetTables())
 
+	d.detectEnumChanges()
+
 RenameCreate:
 	for _, wantPair := range targetTables.Pairs() {
 		wantName, wantTable := wantPair.Key, wantPair.Value
@@ -35,6 +39,8 @@ RenameCreate:
 		if haveTable, ok := currentTables.Load(wantName); ok {
 			d.detectColumnChanges(haveTable, wantTable, true)
 			d.detectConstraintChanges(haveTable, wantTable)
+			d.detectIndexChanges(haveTable, wantTable)
+			d.detectTableComment(haveTable, wantTable)
 			continue
 		}
 
@@ -52,6 +58,8 @@ RenameCreate:
 				// We need not check wantTable any further.
 				d.detectColumnChanges(haveTable, wantTable, false)
 				d.detectConstraintChanges(haveTable, wantTable)
+				d.detectIndexChanges(haveTable, wantTable)
+				d.detectTableComment(haveTable, wantTable)
 				currentTables.Delete(haveName)
 				continue RenameCreate
 			}
@@ -64,6 +72,12 @@ RenameCreate:
 			TableName: wantTable.GetName(),
 			Model:     additional.Model,
 		})
+		for _, idx := range sqlschema.TableIndexes(wantTable) {
+			d.changes.Add(&CreateIndexOp{
+				TableName: wantTable.GetName(),
+				Index:     idx,
+			})
+		}
 	}
 
 	// Drop any remaining "current" tables which do not have a model.
@@ -72,6 +86,7 @@ RenameCreate:
 		if _, keep := targetTables.Load(name); !keep {
 			d.changes.Add(&DropTableOp{
 				TableName: table.GetName(),
+				Table:     table,
 			})
 		}
 	}
@@ -122,7 +137,21 @@ ChangeRename:
 		// Still, we should not delete(columns, thisColumn), because later we will need to
 		// check that we do not try to rename a column to an already a name that already exists.
 		if cCol, ok := currentColumns.Load(tName); ok {
-			if checkType && !d.equalColumns(cCol, tCol) {
+			// A regular column cannot be altered to become a generated one or vice versa, so it is re-created.
+			if cCol.GetIsGenerated() != tCol.GetIsGenerated() {
+				d.changes.Add(&DropColumnOp{
+					TableName:  target.GetName(),
+					ColumnName: tName,
+					Column:     cCol,
+				}, &AddColumnOp{
+					TableName:  target.GetName(),
+					ColumnName: tName,
+					Column:     tCol,
+				})
+				continue
+			}
+			// Values of generated columns are computed by the database, changes to their expression are not detected.
+			if checkType && !tCol.GetIsGenerated() && !d.equalColumns(cCol, tCol) {
 				d.changes.Add(&ChangeColumnTypeOp{
 					TableName: target.GetName(),
 					Column:    tName,
@@ -130,6 +159,7 @@ ChangeRename:
 					To:        d.makeTargetColDef(cCol, tCol),
 				})
 			}
+			d.detectColumnComment(target.GetName(), cCol, tCol)
 			continue
 		}
 
@@ -149,9 +179,13 @@ ChangeRename:
 			d.refMap.RenameColumn(target.GetName(), cName, tName)
 			currentColumns.Delete(cName) // no need to check this column again
 
-			// Update primary key definition to avoid superficially recreating the constraint.
+			// Update primary key and index definitions to avoid superficially recreating them.
 			current.GetPrimaryKey().Columns.Replace(cName, tName)
+			for _, idx := range sqlschema.TableIndexes(current) {
+				idx.ReplaceColumn(cName, tName)
+			}
 
+			d.detectColumnComment(target.GetName(), cCol, tCol)
 			continue ChangeRename
 		}
 
@@ -175,6 +209,30 @@ ChangeRename:
 	}
 }
 
+// detectTableComment checks if the table comment has changed.
+func (d *detector) detectTableComment(current, target sqlschema.Table) {
+	if d.comments && current.GetComment() != target.GetComment() {
+		d.changes.Add(&ChangeCommentOp{
+			TableName: target.GetName(),
+			From:      current.GetComment(),
+			To:        target.GetComment(),
+		})
+	}
+}
+
+// detectColumnComment checks if the comment on an existing or renamed column has changed.
+func (d *detector) detectColumnComment(tableName string, current, target sqlschema.Column) {
+	if d.comments && current.GetComment() != target.GetComment() {
+		d.changes.Add(&ChangeCommentOp{
+			TableName:  tableName,
+			ColumnName: target.GetName(),
+			Column:     d.makeTargetColDef(current, target),
+			From:       current.GetComment(),
+			To:         target.GetComment(),
+		})
+	}
+}
+
 func (d *detector) detectConstraintChanges(current, target sqlschema.Table) {
 Add:
 	for _, want := range target.GetUniqueConstraints() {
@@ -203,6 +261,34 @@ Drop:
 		})
 	}
 
+AddCheck:
+	for _, want := range target.GetCheckConstraints() {
+		for _, got := range current.GetCheckConstraints() {
+			if got.Equals(want) {
+				continue AddCheck
+			}
+		}
+		d.changes.Add(&AddCheckConstraintOp{
+			TableName: target.GetName(),
+			Check:     want,
+		})
+	}
+
+	if d.manageChecks {
+	DropCheck:
+		for _, got := range current.GetCheckConstraints() {
+			for _, want := range target.GetCheckConstraints() {
+				if got.Equals(want) {
+					continue DropCheck
+				}
+			}
+			d.changes.Add(&DropCheckConstraintOp{
+				TableName: target.GetName(),
+				Check:     got,
+			})
+		}
+	}
+
 	targetPK := target.GetPrimaryKey()
 	currentPK := current.GetPrimaryKey()
 
@@ -230,6 +316,96 @@ Drop:
 	}
 }
 
+// detectIndexChanges compares indexes by name. An index whose definition has changed is dropped and re-created,
+// as SQL has no statement to alter the definition of an existing index.
+// Indexes which are not declared by the model are only dropped if the detector manages indexes,
+// because they may have been created with CreateIndexQuery or in SQL migrations.
+func (d *detector) detectIndexChanges(current, target sqlschema.Table) {
+	currentIndexes := make(map[string]sqlschema.Index)
+	for _, idx := range sqlschema.TableIndexes(current) {
+		currentIndexes[idx.Name] = idx
+	}
+
+	for _, want := range sqlschema.TableIndexes(target) {
+		got, ok := currentIndexes[want.Name]
+		if ok && got.Equals(want) {
+			continue
+		}
+		if ok {
+			d.changes.Add(&DropIndexOp{
+				TableName: target.GetName(),
+				Index:     got,
+			})
+		}
+		d.changes.Add(&CreateIndexOp{
+			TableName: target.GetName(),
+			Index:     want,
+		})
+	}
+
+	if !d.manageIndexes {
+		return
+	}
+
+Drop:
+	for _, got := range sqlschema.TableIndexes(current) {
+		for _, want := range sqlschema.TableIndexes(target) {
+			if got.Name == want.Name {
+				continue Drop
+			}
+		}
+		d.changes.Add(&DropIndexOp{
+			TableName: target.GetName(),
+			Index:     got,
+		})
+	}
+}
+
+// detectEnumChanges creates missing enumerated types, adds new values to the existing ones
+// and, if enabled, drops the types which are not declared by any model.
+// Values removed from the model are kept, because PostgreSQL cannot drop a value from an enum.
+func (d *detector) detectEnumChanges() {
+	currentEnums := make(map[string]sqlschema.Enum)
+	for _, enum := range sqlschema.DatabaseEnums(d.current) {
+		currentEnums[enum.Name] = enum
+	}
+
+	targetEnums := make(map[string]sqlschema.Enum)
+	for _, want := range sqlschema.DatabaseEnums(d.target) {
+		targetEnums[want.Name] = want
+
+		got, ok := currentEnums[want.Name]
+		if !ok {
+			d.changes.Add(&CreateEnumOp{Enum: want})
+			continue
+		}
+
+		for i, value := range want.Values {
+			if slices.Contains(got.Values, value) {
+				continue
+			}
+			add := &AddEnumValueOp{TypeName: want.Name, Value: value}
+			switch {
+			case i > 0:
+				add.After = want.Values[i-1]
+			case len(got.Values) > 0:
+				add.Before = got.Values[0]
+			}
+			d.changes.Add(add)
+		}
+	}
+
+	if !d.manageEnums {
+		return
+	}
+
+	for _, got := range sqlschema.DatabaseEnums(d.current) {
+		if _, keep := targetEnums[got.Name]; !keep {
+			d.changes.Add(&DropEnumOp{Enum: got})
+		}
+	}
+}
+
 func newDetector(got, want sqlschema.Database, opts ...diffOption) *detector {
 	cfg := &detectorConfig{
 		cmpType: func(c1, c2 sqlschema.Column) bool {
@@ -241,10 +417,14 @@ func newDetector(got, want sqlschema.Database, opts ...diffOption) *detector {
 	}
 
 	return &detector{
-		current: got,
-		target:  want,
-		refMap:  newRefMap(got.GetForeignKeys()),
-		cmpType: cfg.cmpType,
+		current:       got,
+		target:        want,
+		refMap:        newRefMap(got.GetForeignKeys()),
+		cmpType:       cfg.cmpType,
+		comments:      cfg.comments,
+		manageIndexes: cfg.manageIndexes,
+		manageChecks:  cfg.manageChecks,
+		manageEnums:   cfg.manageEnums,
 	}
 }
 
@@ -256,9 +436,43 @@ func withCompareTypeFunc(f CompareTypeFunc) diffOption {
 	}
 }
 
+// withComments enables detection of changed table and column comments.
+// It should only be used with dialects which support comments, as otherwise
+// the comments declared in the models would never match the database.
+func withComments(enabled bool) diffOption {
+	return func(cfg *detectorConfig) {
+		cfg.comments = enabled
+	}
+}
+
+// withManageIndexes enables dropping the indexes which are not declared by the models.
+func withManageIndexes(enabled bool) diffOption {
+	return func(cfg *detectorConfig) {
+		cfg.manageIndexes = enabled
+	}
+}
+
+// withManageChecks enables dropping the check constraints which are not declared by the models.
+func withManageChecks(enabled bool) diffOption {
+	return func(cfg *detectorConfig) {
+		cfg.manageChecks = enabled
+	}
+}
+
+// withManageEnums enables dropping the enumerated types which are not declared by the models.
+func withManageEnums(enabled bool) diffOption {
+	return func(cfg *detectorConfig) {
+		cfg.manageEnums = enabled
+	}
+}
+
 // detectorConfig controls how differences in the model states are resolved.
 type detectorConfig struct {
-	cmpType CompareTypeFunc
+	cmpType       CompareTypeFunc
+	comments      bool
+	manageIndexes bool
+	manageChecks  bool
+	manageEnums   bool
 }
 
 // detector may modify the passed database schemas, so it isn't safe to re-use them.
@@ -277,6 +491,18 @@ type detector struct {
 	// due to the existence of dialect-specific type aliases. The caller
 	// should pass a concrete InspectorDialect.EquivalentType for robust comparison.
 	cmpType CompareTypeFunc
+
+	// comments is true if the dialect supports table and column comments.
+	comments bool
+
+	// manageIndexes is true if the indexes which are not declared by the models should be dropped.
+	manageIndexes bool
+
+	// manageChecks is true if the check constraints which are not declared by the models should be dropped.
+	manageChecks bool
+
+	// manageEnums is true if the enumerated types which are not declared by the models should be dropped.
+	manageEnums bool
 }
 
 // canRename checks if t1 can be renamed to t2.
@@ -289,7 +515,8 @@ func (d detector) equalColumns(col1, col2 sqlschema.Column) bool {
 		col1.GetDefaultValue() == col2.GetDefaultValue() &&
 		col1.GetIsNullable() == col2.GetIsNullable() &&
 		col1.GetIsAutoIncrement() == col2.GetIsAutoIncrement() &&
-		col1.GetIsIdentity() == col2.GetIsIdentity()
+		col1.GetIsIdentity() == col2.GetIsIdentity() &&
+		col1.GetIsGenerated() == col2.GetIsGenerated()
 }
 
 func (d detector) makeTargetColDef(current, target sqlschema.Column) sqlschema.Column {
@@ -301,6 +528,10 @@ func (d detector) makeTargetColDef(current, target sqlschema.Column) sqlschema.C
 			IsNullable:      target.GetIsNullable(),
 			IsAutoIncrement: target.GetIsAutoIncrement(),
 			IsIdentity:      target.GetIsIdentity(),
+			IsGenerated:     target.GetIsGenerated(),
+			GeneratedExpr:   target.GetGeneratedExpr(),
+			IsVirtual:       target.GetIsVirtual(),
+			Comment:         target.GetComment(),
 
 			SQLType:    current.GetSQLType(),
 			VarcharLen: current.GetVarcharLen(),
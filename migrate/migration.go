@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"database/sql/driver"
 	"fmt"
 	"io"
 	"io/fs"
@@ -147,6 +148,9 @@ func newSQLMigrationFunc(fsys fs.FS, name string) internalMigrationFunc {
 			}
 
 			if conn, ok := idb.(bun.Conn); ok {
+				if execErr != nil {
+					discardConn(conn)
+				}
 				retErr = conn.Close()
 				return
 			}
@@ -162,6 +166,13 @@ func newSQLMigrationFunc(fsys fs.FS, name string) internalMigrationFunc {
 	}
 }
 
+// discardConn closes the connection instead of returning it to the pool.
+// A migration which failed half-way through may leave the session in an unexpected state,
+// e.g. with an open transaction or with foreign key enforcement turned off.
+func discardConn(conn bun.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+}
+
 func renderTemplate(contents []byte, templateData any) (*bytes.Buffer, error) {
 	tmpl, err := template.New("migration").Parse(string(contents))
 	if err != nil {
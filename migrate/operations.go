@@ -2,6 +2,8 @@ package migrate
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/uptrace/bun/migrate/sqlschema"
 )
@@ -27,7 +29,8 @@ type Operation interface {
 
 // CreateTableOp creates a new table in the schema.
 //
-// It does not report dependency on any other migration and may be executed first.
+// It only depends on CreateEnumOp and AddEnumValueOp, as its columns may use the enumerated types,
+// and may otherwise be executed first.
 // Make sure the dialect does not include FOREIGN KEY constraints in the CREATE TABLE
 // statement, as those may potentially reference not-yet-existing columns/tables.
 type CreateTableOp struct {
@@ -41,9 +44,21 @@ func (op *CreateTableOp) GetReverse() Operation {
 	return &DropTableOp{TableName: op.TableName}
 }
 
+func (op *CreateTableOp) DependsOn(another Operation) bool {
+	switch another.(type) {
+	case *CreateEnumOp, *AddEnumValueOp:
+		return true
+	}
+	return false
+}
+
 // DropTableOp drops a database table. This operation is not reversible.
 type DropTableOp struct {
 	TableName string
+
+	// Table is the definition of the dropped table. It is nil if the definition is not known,
+	// e.g. when the operation reverses CreateTableOp.
+	Table sqlschema.Table
 }
 
 var _ Operation = (*DropTableOp)(nil)
@@ -115,12 +130,20 @@ func (op *AddColumnOp) GetReverse() Operation {
 	}
 }
 
+func (op *AddColumnOp) DependsOn(another Operation) bool {
+	if drop, ok := another.(*DropColumnOp); ok {
+		// The column is being re-created.
+		return op.TableName == drop.TableName && op.ColumnName == drop.ColumnName
+	}
+	return dependsOnEnum(op.Column, another)
+}
+
 // DropColumnOp drop a column from the table.
 //
 // While some dialects allow DROP CASCADE to drop dependent constraints,
 // explicit handling on constraints is preferred for transparency and debugging.
-// DropColumnOp depends on DropForeignKeyOp, DropPrimaryKeyOp, and ChangePrimaryKeyOp
-// if any of the constraints is defined on this table.
+// DropColumnOp depends on DropForeignKeyOp, DropPrimaryKeyOp, ChangePrimaryKeyOp, DropCheckConstraintOp,
+// and DropIndexOp if any of the constraints or indexes is defined on this table.
 type DropColumnOp struct {
 	TableName  string
 	ColumnName string
@@ -145,6 +168,10 @@ func (op *DropColumnOp) DependsOn(another Operation) bool {
 		return op.TableName == drop.TableName && drop.PrimaryKey.Columns.Contains(op.ColumnName)
 	case *ChangePrimaryKeyOp:
 		return op.TableName == drop.TableName && drop.Old.Columns.Contains(op.ColumnName)
+	case *DropIndexOp:
+		return op.TableName == drop.TableName && drop.Index.DependsOnColumn(op.ColumnName)
+	case *DropCheckConstraintOp:
+		return op.TableName == drop.TableName && drop.Check.DependsOnColumn(op.ColumnName)
 	}
 	return false
 }
@@ -248,6 +275,60 @@ func (op *DropUniqueConstraintOp) GetReverse() Operation {
 	}
 }
 
+// AddCheckConstraintOp adds a new CHECK constraint to the table.
+type AddCheckConstraintOp struct {
+	TableName string
+	Check     sqlschema.Check
+}
+
+var _ Operation = (*AddCheckConstraintOp)(nil)
+
+func (op *AddCheckConstraintOp) GetReverse() Operation {
+	return &DropCheckConstraintOp{
+		TableName: op.TableName,
+		Check:     op.Check,
+	}
+}
+
+func (op *AddCheckConstraintOp) DependsOn(another Operation) bool {
+	switch another := another.(type) {
+	case *AddColumnOp:
+		return op.TableName == another.TableName && op.Check.DependsOnColumn(another.ColumnName)
+	case *RenameColumnOp:
+		return op.TableName == another.TableName && op.Check.DependsOnColumn(another.NewName)
+	case *ChangeColumnTypeOp:
+		return op.TableName == another.TableName && op.Check.DependsOnColumn(another.Column)
+	case *RenameTableOp:
+		return op.TableName == another.NewName
+	case *DropCheckConstraintOp:
+		// We want to drop the constraint with the same name before adding this one.
+		return op.TableName == another.TableName && op.Check.Name == another.Check.Name
+	}
+	return false
+}
+
+// DropCheckConstraintOp drops a CHECK constraint.
+type DropCheckConstraintOp struct {
+	TableName string
+	Check     sqlschema.Check
+}
+
+var _ Operation = (*DropCheckConstraintOp)(nil)
+
+func (op *DropCheckConstraintOp) DependsOn(another Operation) bool {
+	if rename, ok := another.(*RenameTableOp); ok {
+		return op.TableName == rename.NewName
+	}
+	return false
+}
+
+func (op *DropCheckConstraintOp) GetReverse() Operation {
+	return &AddCheckConstraintOp{
+		TableName: op.TableName,
+		Check:     op.Check,
+	}
+}
+
 // ChangeColumnTypeOp set a new data type for the column.
 // The two types should be such that the data can be auto-casted from one to another.
 // E.g. reducing VARCHAR lenght is not possible in most dialects.
@@ -270,6 +351,48 @@ func (op *ChangeColumnTypeOp) GetReverse() Operation {
 	}
 }
 
+func (op *ChangeColumnTypeOp) DependsOn(another Operation) bool {
+	return dependsOnEnum(op.To, another)
+}
+
+// ChangeCommentOp changes the comment on a table or, if ColumnName is set, on a column.
+// An empty comment removes it.
+type ChangeCommentOp struct {
+	TableName  string
+	ColumnName string
+
+	// Column is the definition of the commented column, nil for table comments.
+	// It is used by dialects which can only change the comment by re-defining the column.
+	Column sqlschema.Column
+
+	From string
+	To   string
+}
+
+var _ Operation = (*ChangeCommentOp)(nil)
+
+func (op *ChangeCommentOp) GetReverse() Operation {
+	return &ChangeCommentOp{
+		TableName:  op.TableName,
+		ColumnName: op.ColumnName,
+		Column:     op.Column,
+		From:       op.To,
+		To:         op.From,
+	}
+}
+
+func (op *ChangeCommentOp) DependsOn(another Operation) bool {
+	switch another := another.(type) {
+	case *RenameTableOp:
+		return op.TableName == another.NewName
+	case *RenameColumnOp:
+		return op.ColumnName != "" && op.TableName == another.TableName && op.ColumnName == another.NewName
+	case *ChangeColumnTypeOp:
+		return op.ColumnName != "" && op.TableName == another.TableName && op.ColumnName == another.Column
+	}
+	return false
+}
+
 // DropPrimaryKeyOp drops the table's PRIMARY KEY.
 type DropPrimaryKeyOp struct {
 	TableName  string
@@ -325,6 +448,133 @@ func (op *ChangePrimaryKeyOp) GetReverse() Operation {
 	}
 }
 
+// CreateIndexOp creates a secondary index on the table.
+type CreateIndexOp struct {
+	TableName string
+	Index     sqlschema.Index
+}
+
+var _ Operation = (*CreateIndexOp)(nil)
+
+func (op *CreateIndexOp) GetReverse() Operation {
+	return &DropIndexOp{
+		TableName: op.TableName,
+		Index:     op.Index,
+	}
+}
+
+func (op *CreateIndexOp) DependsOn(another Operation) bool {
+	switch another := another.(type) {
+	case *CreateTableOp:
+		return op.TableName == another.TableName
+	case *RenameTableOp:
+		return op.TableName == another.NewName
+	case *AddColumnOp:
+		return op.TableName == another.TableName && op.Index.DependsOnColumn(another.ColumnName)
+	case *RenameColumnOp:
+		return op.TableName == another.TableName && op.Index.DependsOnColumn(another.NewName)
+	case *ChangeColumnTypeOp:
+		return op.TableName == another.TableName && op.Index.DependsOnColumn(another.Column)
+	case *DropIndexOp:
+		// We want to drop the index with the same name before creating this one.
+		return op.TableName == another.TableName && op.Index.Name == another.Index.Name
+	}
+	return false
+}
+
+// DropIndexOp drops a secondary index.
+type DropIndexOp struct {
+	TableName string
+	Index     sqlschema.Index
+}
+
+var _ Operation = (*DropIndexOp)(nil)
+
+func (op *DropIndexOp) GetReverse() Operation {
+	return &CreateIndexOp{
+		TableName: op.TableName,
+		Index:     op.Index,
+	}
+}
+
+func (op *DropIndexOp) DependsOn(another Operation) bool {
+	if rename, ok := another.(*RenameTableOp); ok {
+		return op.TableName == rename.NewName
+	}
+	return false
+}
+
+// CreateEnumOp creates an enumerated type, e.g. CREATE TYPE ... AS ENUM in PostgreSQL.
+type CreateEnumOp struct {
+	Enum sqlschema.Enum
+}
+
+var _ Operation = (*CreateEnumOp)(nil)
+
+func (op *CreateEnumOp) GetReverse() Operation {
+	return &DropEnumOp{Enum: op.Enum}
+}
+
+// AddEnumValueOp adds a value to an existing enumerated type.
+// The value is placed before or after another value, if either is set, and is appended otherwise.
+//
+// PostgreSQL does not allow using the new value in the same transaction,
+// so a column which uses it as its default must be added in a later migration.
+type AddEnumValueOp struct {
+	TypeName string
+	Value    string
+	Before   string
+	After    string
+}
+
+var _ Operation = (*AddEnumValueOp)(nil)
+
+// GetReverse for AddEnumValueOp returns a no-op migration, because values cannot be removed from an enumerated type.
+func (op *AddEnumValueOp) GetReverse() Operation {
+	c := Unimplemented(fmt.Sprintf("WARNING: value %q cannot be removed from type %s automatically", op.Value, op.TypeName))
+	return &c
+}
+
+func (op *AddEnumValueOp) DependsOn(another Operation) bool {
+	add, ok := another.(*AddEnumValueOp)
+	return ok && op.TypeName == add.TypeName && (op.Before == add.Value || op.After == add.Value)
+}
+
+// DropEnumOp drops an enumerated type.
+// It depends on the operations which remove columns of this type, as the type cannot be dropped while it is in use.
+type DropEnumOp struct {
+	Enum sqlschema.Enum
+}
+
+var _ Operation = (*DropEnumOp)(nil)
+
+func (op *DropEnumOp) GetReverse() Operation {
+	return &CreateEnumOp{Enum: op.Enum}
+}
+
+func (op *DropEnumOp) DependsOn(another Operation) bool {
+	switch another := another.(type) {
+	case *DropTableOp:
+		return another.Table != nil && slices.ContainsFunc(another.Table.GetColumns(), op.Enum.IsTypeOf)
+	case *DropColumnOp:
+		return another.Column != nil && op.Enum.IsTypeOf(another.Column)
+	case *ChangeColumnTypeOp:
+		return op.Enum.IsTypeOf(another.From)
+	}
+	return false
+}
+
+// dependsOnEnum reports whether the operation creates the enumerated type of the column or adds a value to it.
+func dependsOnEnum(col sqlschema.Column, another Operation) bool {
+	switch another := another.(type) {
+	case *CreateEnumOp:
+		return another.Enum.IsTypeOf(col)
+	case *AddEnumValueOp:
+		return strings.EqualFold(col.GetSQLType(), another.TypeName)
+	}
+	return false
+}
+
 // Unimplemented denotes an Operation that cannot be executed.
 //
 // Operations, which cannot be reversed due to current technical limitations,
@@ -14,6 +14,10 @@ type Column interface {
 	GetIsNullable() bool
 	GetIsAutoIncrement() bool
 	GetIsIdentity() bool
+	GetIsGenerated() bool
+	GetGeneratedExpr() string
+	GetIsVirtual() bool
+	GetComment() string
 	AppendQuery(schema.QueryGen, []byte) ([]byte, error)
 }
 
@@ -31,6 +35,10 @@ type BaseColumn struct {
 	IsNullable      bool
 	IsAutoIncrement bool
 	IsIdentity      bool
+	IsGenerated     bool
+	GeneratedExpr   string // expression of the generated column, inspectors are not required to report it
+	IsVirtual       bool   // generated column is computed when read rather than stored
+	Comment         string
 	// TODO: add Precision and Cardinality for timestamps/bit-strings/floats and arrays respectively.
 }
 
@@ -62,6 +70,22 @@ func (cd BaseColumn) GetIsIdentity() bool {
 	return cd.IsIdentity
 }
 
+func (cd BaseColumn) GetIsGenerated() bool {
+	return cd.IsGenerated
+}
+
+func (cd BaseColumn) GetGeneratedExpr() string {
+	return cd.GeneratedExpr
+}
+
+func (cd BaseColumn) GetIsVirtual() bool {
+	return cd.IsVirtual
+}
+
+func (cd BaseColumn) GetComment() string {
+	return cd.Comment
+}
+
 // AppendQuery appends full SQL data type.
 func (c *BaseColumn) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte, err error) {
 	b = append(b, c.SQLType...)
@@ -1,8 +1,10 @@
 package sqlschema
 
 import (
+	"regexp"
 	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/uptrace/bun/schema"
 )
@@ -12,7 +14,25 @@ type Database interface {
 	GetForeignKeys() map[ForeignKey]string
 }
 
-var _ Database = (*BaseDatabase)(nil)
+// EnumDatabase is implemented by databases which report their enumerated types.
+// It is separate from Database, so that the existing implementations of Database remain valid.
+type EnumDatabase interface {
+	Database
+	GetEnums() []Enum
+}
+
+// DatabaseEnums returns the enumerated types of the database, or nil if the database does not implement EnumDatabase.
+func DatabaseEnums(db Database) []Enum {
+	if db, ok := db.(EnumDatabase); ok {
+		return db.GetEnums()
+	}
+	return nil
+}
+
+var (
+	_ Database     = (*BaseDatabase)(nil)
+	_ EnumDatabase = (*BaseDatabase)(nil)
+)
 
 // BaseDatabase is a base database definition.
 //
@@ -21,6 +41,7 @@ var _ Database = (*BaseDatabase)(nil)
 type BaseDatabase struct {
 	Tables      []Table
 	ForeignKeys map[ForeignKey]string
+	Enums       []Enum
 }
 
 func (ds BaseDatabase) GetTables() []Table {
@@ -31,6 +52,21 @@ func (ds BaseDatabase) GetForeignKeys() map[ForeignKey]string {
 	return ds.ForeignKeys
 }
 
+func (ds BaseDatabase) GetEnums() []Enum {
+	return ds.Enums
+}
+
+// Enum is an enumerated type, e.g. one created with CREATE TYPE ... AS ENUM in PostgreSQL.
+type Enum struct {
+	Name   string
+	Values []string
+}
+
+// IsTypeOf reports whether the column is of this enumerated type.
+func (e Enum) IsTypeOf(col Column) bool {
+	return strings.EqualFold(col.GetSQLType(), e.Name)
+}
+
 // ForeignKey represents a foreign key constraint between two tables.
 type ForeignKey struct {
 	From ColumnReference
@@ -121,6 +157,110 @@ func (u Unique) Equals(other Unique) bool {
 	return u.Columns == other.Columns
 }
 
+// Index represents a secondary index defined on 1 or more columns or expressions.
+type Index struct {
+	Name   string
+	Unique bool
+
+	// Columns of the index key in order. An expression is enclosed in parentheses, e.g. (lower(email)),
+	// to distinguish it from a column name.
+	Columns []string
+
+	// Method is the index access method, e.g. gin. An empty method means the dialect's default.
+	Method string
+
+	// Where is the predicate of a partial index.
+	Where string
+
+	// Include lists non-key columns stored in the index.
+	Include []string
+}
+
+// Equals checks that two indexes have the same definition, assuming both are defined for the same table.
+// Expressions are compared loosely, as databases usually store them in a normalized form, e.g.
+// PostgreSQL would return "(deleted_at IS NULL)" for an index declared with "WHERE deleted_at IS NULL".
+func (idx Index) Equals(other Index) bool {
+	return idx.Name == other.Name &&
+		idx.Unique == other.Unique &&
+		strings.EqualFold(idx.Method, other.Method) &&
+		slices.EqualFunc(idx.Columns, other.Columns, equalExpr) &&
+		equalExpr(idx.Where, other.Where) &&
+		slices.Equal(idx.Include, other.Include)
+}
+
+// DependsOnColumn checks if the column is part of the index key, its predicate or included columns.
+func (idx Index) DependsOnColumn(column string) bool {
+	if slices.Contains(idx.Include, column) {
+		return true
+	}
+	for _, expr := range append(slices.Clone(idx.Columns), idx.Where) {
+		if slices.Contains(exprIdents(expr), strings.ToLower(column)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplaceColumn renames a column in the index key and in the included columns.
+// Column names in expressions are not renamed.
+func (idx Index) ReplaceColumn(oldColumn, newColumn string) {
+	for _, columns := range [][]string{idx.Columns, idx.Include} {
+		for i, column := range columns {
+			if column == oldColumn {
+				columns[i] = newColumn
+			}
+		}
+	}
+}
+
+// Check represents a CHECK constraint defined on the table.
+type Check struct {
+	Name string
+	Expr string
+}
+
+// Equals checks that two constraints have the same name and an equivalent expression.
+//
+// Databases store the expression in a normalized form, which may differ from how it was declared
+// beyond what equalExpr can account for, e.g. PostgreSQL rewrites IN (...) to = ANY (ARRAY[...]).
+// Such constraints will be re-created on every migration, unless the expression in the model is
+// declared in the form reported by the database.
+func (c Check) Equals(other Check) bool {
+	return c.Name == other.Name && equalExpr(c.Expr, other.Expr)
+}
+
+// DependsOnColumn checks if the column is used in the constraint's expression.
+func (c Check) DependsOnColumn(column string) bool {
+	return slices.Contains(exprIdents(c.Expr), strings.ToLower(column))
+}
+
+// equalExpr compares SQL expressions ignoring case, whitespace, parentheses, identifier quotes and type casts.
+func equalExpr(expr1, expr2 string) bool {
+	return normalizeExpr(expr1) == normalizeExpr(expr2)
+}
+
+var typeCast = regexp.MustCompile(`::[a-z0-9_]+(\[\])?`)
+
+func normalizeExpr(expr string) string {
+	expr = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return -1
+		case r == '(' || r == ')' || r == '"' || r == '`':
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, expr)
+	return typeCast.ReplaceAllString(expr, "")
+}
+
+// exprIdents returns lower-cased identifiers and keywords used in the expression.
+func exprIdents(expr string) []string {
+	return strings.FieldsFunc(strings.ToLower(expr), func(r rune) bool {
+		return !(r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+}
+
 // ColumnReference identifies a column or set of columns in a table.
 type ColumnReference struct {
 	TableName string
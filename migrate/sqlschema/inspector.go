@@ -3,10 +3,12 @@ package sqlschema
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/schema"
 )
 
@@ -135,13 +137,26 @@ func (bmi *BunModelInspector) Inspect(ctx context.Context) (Database, error) {
 			ForeignKeys: make(map[ForeignKey]string),
 		},
 	}
+	hasEnums := bmi.tables.Dialect().Features().Has(feature.EnumType)
+	enums := make(map[string][]string)
 	for _, t := range bmi.tables.All() {
-		if t.Schema != bmi.SchemaName {
+		// Views are not tables and are not managed by the migrations.
+		if t.Schema != bmi.SchemaName || t.IsView {
 			continue
 		}
 
 		var columns []Column
 		for _, f := range t.Fields {
+			if hasEnums && len(f.EnumValues) > 0 {
+				name := strings.ToLower(f.UserSQLType)
+				switch values, ok := enums[name]; {
+				case !ok:
+					enums[name] = f.EnumValues
+					state.Enums = append(state.Enums, Enum{Name: name, Values: f.EnumValues})
+				case !slices.Equal(values, f.EnumValues):
+					return nil, fmt.Errorf("enum %s has conflicting values in %s.%s", name, t.TypeName, f.GoName)
+				}
+			}
 
 			sqlType, length, err := parseLen(f.CreateTableSQLType)
 			if err != nil {
@@ -155,7 +170,11 @@ func (bmi *BunModelInspector) Inspect(ctx context.Context) (Database, error) {
 				DefaultValue:    exprOrLiteral(f.SQLDefault),
 				IsNullable:      !f.NotNull,
 				IsAutoIncrement: f.AutoIncrement,
-				IsIdentity:      f.Identity,
+				IsIdentity:      f.Identity && bmi.tables.Dialect().Features().Has(feature.GeneratedIdentity),
+				IsGenerated:     f.Generated != "",
+				GeneratedExpr:   f.Generated,
+				IsVirtual:       f.Virtual,
+				Comment:         f.Comment,
 			})
 		}
 
@@ -178,6 +197,23 @@ func (bmi *BunModelInspector) Inspect(ctx context.Context) (Database, error) {
 			unique = append(unique, Unique{Name: name, Columns: NewColumns(columns...)})
 		}
 
+		var indexes []Index
+		for _, idx := range t.Indexes {
+			index := Index{Name: idx.Name, Method: idx.Method, Where: idx.Where}
+			for _, f := range idx.Columns {
+				index.Columns = append(index.Columns, f.Name)
+			}
+			for _, f := range idx.Include {
+				index.Include = append(index.Include, f.Name)
+			}
+			indexes = append(indexes, index)
+		}
+
+		var checks []Check
+		for _, check := range t.Checks {
+			checks = append(checks, Check{Name: check.Name, Expr: check.Expr})
+		}
+
 		var pk *PrimaryKey
 		if len(t.PKs) > 0 {
 			var columns []string
@@ -200,6 +236,9 @@ func (bmi *BunModelInspector) Inspect(ctx context.Context) (Database, error) {
 				Name:              tableName,
 				Columns:           columns,
 				UniqueConstraints: unique,
+				CheckConstraints:  checks,
+				Indexes:           indexes,
+				Comment:           t.Comment,
 				PrimaryKey:        pk,
 			},
 			Model: t.ZeroIface,
@@ -212,6 +251,10 @@ func (bmi *BunModelInspector) Inspect(ctx context.Context) (Database, error) {
 				rel.Type == schema.HasManyRelation {
 				continue
 			}
+			// Foreign keys cannot reference a view.
+			if rel.JoinTable.IsView {
+				continue
+			}
 
 			var fromCols, toCols []string
 			for _, f := range rel.BasePKs {
@@ -1,7 +1,9 @@
 package sqlschema
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/schema"
@@ -18,11 +20,33 @@ type Migrator interface {
 	AppendSQL(b []byte, operation any) ([]byte, error)
 }
 
+// StatefulMigrator is a Migrator which needs to know the current schema to render some of the operations,
+// e.g. SQLite can only alter most of the table's properties by re-creating it from its complete definition.
+//
+// Init must be called before rendering a sequence of operations. After that the migrator
+// updates its state with every operation it renders, assuming they are applied in that order.
+type StatefulMigrator interface {
+	Migrator
+
+	// Init reads the current schema via conn, discarding any state tracked so far.
+	Init(ctx context.Context, conn bun.IDB) error
+}
+
 // migrator is a dialect-agnostic wrapper for sqlschema.MigratorDialect.
 type migrator struct {
 	Migrator
 }
 
+var _ StatefulMigrator = (*migrator)(nil)
+
+// Init initializes the underlying migrator if it is a StatefulMigrator.
+func (m *migrator) Init(ctx context.Context, conn bun.IDB) error {
+	if sm, ok := m.Migrator.(StatefulMigrator); ok {
+		return sm.Init(ctx, conn)
+	}
+	return nil
+}
+
 func NewMigrator(db *bun.DB, schemaName string) (Migrator, error) {
 	md, ok := db.Dialect().(MigratorDialect)
 	if !ok {
@@ -49,3 +73,30 @@ func (m *BaseMigrator) AppendCreateTable(b []byte, model any) ([]byte, error) {
 func (m *BaseMigrator) AppendDropTable(b []byte, schemaName, tableName string) ([]byte, error) {
 	return m.db.NewDropTable().TableExpr("?.?", bun.Ident(schemaName), bun.Ident(tableName)).AppendQuery(m.db.QueryGen(), b)
 }
+
+// NewCreateIndex builds a CREATE INDEX query for the index definition.
+// The caller should set the table name and, if the dialect allows it, qualify the index name.
+// Columns enclosed in parentheses are added as expressions.
+func (m *BaseMigrator) NewCreateIndex(index Index) *bun.CreateIndexQuery {
+	q := m.db.NewCreateIndex().Index(index.Name)
+	if index.Unique {
+		q = q.Unique()
+	}
+	if index.Method != "" {
+		q = q.Using(index.Method)
+	}
+	for _, column := range index.Columns {
+		if strings.HasPrefix(column, "(") {
+			q = q.ColumnExpr("?", bun.Safe(column))
+		} else {
+			q = q.Column(column)
+		}
+	}
+	if len(index.Include) > 0 {
+		q = q.Include(index.Include...)
+	}
+	if index.Where != "" {
+		q = q.Where("?", bun.Safe(index.Where))
+	}
+	return q
+}
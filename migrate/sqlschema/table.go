@@ -6,9 +6,29 @@ type Table interface {
 	GetColumns() []Column
 	GetPrimaryKey() *PrimaryKey
 	GetUniqueConstraints() []Unique
+	GetCheckConstraints() []Check
+	GetComment() string
 }
 
-var _ Table = (*BaseTable)(nil)
+// IndexedTable is implemented by tables which report their secondary indexes.
+// It is separate from Table, so that the existing implementations of Table remain valid.
+type IndexedTable interface {
+	Table
+	GetIndexes() []Index
+}
+
+// TableIndexes returns the indexes of the table, or nil if the table does not implement IndexedTable.
+func TableIndexes(t Table) []Index {
+	if t, ok := t.(IndexedTable); ok {
+		return t.GetIndexes()
+	}
+	return nil
+}
+
+var (
+	_ Table        = (*BaseTable)(nil)
+	_ IndexedTable = (*BaseTable)(nil)
+)
 
 // BaseTable is a base table definition.
 //
@@ -27,6 +47,15 @@ type BaseTable struct {
 
 	// UniqueConstraints defined on the table.
 	UniqueConstraints []Unique
+
+	// CheckConstraints defined on the table.
+	CheckConstraints []Check
+
+	// Indexes defined on the table, excluding those which back PRIMARY KEY and UNIQUE constraints.
+	Indexes []Index
+
+	// Comment on the table, empty if the table has no comment.
+	Comment string
 }
 
 // PrimaryKey represents a primary key constraint defined on 1 or more columns.
@@ -54,3 +83,15 @@ func (td *BaseTable) GetPrimaryKey() *PrimaryKey {
 func (td *BaseTable) GetUniqueConstraints() []Unique {
 	return td.UniqueConstraints
 }
+
+func (td *BaseTable) GetCheckConstraints() []Check {
+	return td.CheckConstraints
+}
+
+func (td *BaseTable) GetIndexes() []Index {
+	return td.Indexes
+}
+
+func (td *BaseTable) GetComment() string {
+	return td.Comment
+}
@@ -82,6 +82,9 @@ func (m *hasManyModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error
 func (m *hasManyModel) Scan(src any) error {
 	column := m.columns[m.scanIndex]
 	m.scanIndex++
+	if column == rowNumberColumn {
+		return nil
+	}
 
 	field := m.table.LookupField(column)
 	if field == nil {
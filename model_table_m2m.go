@@ -82,6 +82,9 @@ func (m *m2mModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error) {
 func (m *m2mModel) Scan(src any) error {
 	column := m.columns[m.scanIndex]
 	m.scanIndex++
+	if column == rowNumberColumn {
+		return nil
+	}
 
 	// Base pks must come first.
 	if m.scanIndex <= len(m.rel.M2MBasePKs) {
@@ -6,6 +6,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +21,10 @@ const (
 	forceDeleteFlag internal.Flag = 1 << iota
 	deletedFlag
 	allWithDeletedFlag
+	skipJoinsFlag
+	tenantFlag
+	withoutTenantScopeFlag
+	unscopedFlag
 )
 
 // WithQuery defines a common table expression used by another query.
@@ -66,6 +71,9 @@ type IDB interface {
 	NewTruncateTable() *TruncateTableQuery
 	NewAddColumn() *AddColumnQuery
 	NewDropColumn() *DropColumnQuery
+	NewCreateView() *CreateViewQuery
+	NewDropView() *DropViewQuery
+	NewRefreshMaterializedView() *RefreshMaterializedViewQuery
 
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error)
 	RunInTx(ctx context.Context, opts *sql.TxOptions, f func(ctx context.Context, tx Tx) error) error
@@ -110,7 +118,9 @@ type baseQuery struct {
 	tables         []schema.QueryWithArgs
 	columns        []schema.QueryWithArgs
 
-	flags internal.Flag
+	tenant   any      // set with tenantFlag
+	unscoped []string // names of the removed scopes
+	flags    internal.Flag
 }
 
 func (q *baseQuery) DB() *DB {
@@ -129,6 +139,16 @@ func (q *baseQuery) resolveConn(ctx context.Context, query Query) IConn {
 	return q.db.DB
 }
 
+// isPooled reports whether the query runs on the connection pool rather than on a Conn or Tx.
+func (q *baseQuery) isPooled() bool {
+	switch q.conn.(type) {
+	case nil, *sql.DB:
+		return true
+	default:
+		return false
+	}
+}
+
 func (q *baseQuery) GetModel() Model {
 	return q.model
 }
@@ -228,6 +248,15 @@ func (q *baseQuery) checkSoftDelete() error {
 	return nil
 }
 
+// checkWritable returns an error if the query modifies a model mapped to a view,
+// unless the table name has been overridden with ModelTableExpr.
+func (q *baseQuery) checkWritable(query Query) error {
+	if q.table == nil || !q.table.IsView || !q.modelTableName.IsZero() {
+		return nil
+	}
+	return fmt.Errorf("bun: %s is a view and does not support %s", q.table.TypeName, query.Operation())
+}
+
 // Deleted adds `WHERE deleted_at IS NOT NULL` clause for soft deleted models.
 func (q *baseQuery) whereDeleted() {
 	if err := q.checkSoftDelete(); err != nil {
@@ -256,6 +285,48 @@ func (q *baseQuery) isSoftDelete() bool {
 	return false
 }
 
+// resolveTenant extracts the tenant from the context, see WithTenantFromContext.
+// The tenant is resolved even for queries without tenant scope, because
+// their subqueries and joins can still be scoped.
+func (q *baseQuery) resolveTenant(ctx context.Context) {
+	if q.db.tenantFromContext == nil {
+		return
+	}
+	if tenant, ok := q.db.tenantFromContext(ctx); ok {
+		q.tenant = tenant
+		q.flags = q.flags.Set(tenantFlag)
+	}
+}
+
+// genWithTenant passes the resolved tenant to the tables and subqueries of the query.
+func (q *baseQuery) genWithTenant(gen schema.QueryGen) schema.QueryGen {
+	if q.flags.Has(tenantFlag) {
+		return gen.WithTenant(q.tenant)
+	}
+	return gen
+}
+
+func (q *baseQuery) isTenantScoped(table *schema.Table) bool {
+	return table != nil && table.TenantField != nil && !q.flags.Has(withoutTenantScopeFlag)
+}
+
+// appendTenantValue appends the tenant of the tenant-scoped table.
+func (q *baseQuery) appendTenantValue(
+	gen schema.QueryGen, b []byte, table *schema.Table,
+) ([]byte, error) {
+	tenant, ok := gen.Tenant()
+	if !ok {
+		return nil, errNoTenant(table)
+	}
+	return gen.Append(b, tenant), nil
+}
+
+func errNoTenant(table *schema.Table) error {
+	return fmt.Errorf(
+		"bun: %s is tenant-scoped, but the context has no tenant (use WithoutTenantScope to query all tenants)",
+		table.TypeName)
+}
+
 //------------------------------------------------------------------------------
 
 // NewWithQuery creates a CTE with the given name and underlying query.
@@ -824,6 +895,27 @@ func (q *whereBaseQuery) addWhereCols(cols []string) {
 	}
 }
 
+// newSelectAffected returns a SelectQuery of the rows matched by the tables and
+// the WHERE conditions of the query. Without alias, the table is selected by its name,
+// because the conditions refer to it by name.
+func (q *whereBaseQuery) newSelectAffected(withAlias bool) *SelectQuery {
+	sel := NewSelectQuery(q.db)
+	sel.baseQuery = q.baseQuery
+	sel.with = slices.Clone(q.with)
+	sel.tables = slices.Clone(q.tables)
+	sel.columns = nil
+	sel.where = slices.Clone(q.where)
+	sel.whereFields = q.whereFields
+
+	if !withAlias && q.table != nil {
+		sel.ModelTableExpr("?", q.table.SQLName)
+		for _, f := range q.table.Fields {
+			sel.ColumnExpr("?.?", q.table.SQLName, f.SQLName)
+		}
+	}
+	return sel
+}
+
 func (q *whereBaseQuery) mustAppendWhere(
 	gen schema.QueryGen, b []byte, withAlias bool,
 ) ([]byte, error) {
@@ -831,13 +923,20 @@ func (q *whereBaseQuery) mustAppendWhere(
 		err := errors.New("bun: Update and Delete queries require at least one Where")
 		return nil, err
 	}
-	return q.appendWhere(gen, b, withAlias)
+	b, err := q.appendWhere(gen, b, withAlias)
+	if err != nil {
+		return nil, err
+	}
+	return q.appendVersionWhere(gen, b, withAlias)
 }
 
 func (q *whereBaseQuery) appendWhere(
 	gen schema.QueryGen, b []byte, withAlias bool,
 ) (_ []byte, err error) {
-	if len(q.where) == 0 && q.whereFields == nil && !q.isSoftDelete() {
+	isTenantScoped := q.isTenantScoped(q.table)
+	scopes := q.tableScopes(q.table)
+	if len(q.where) == 0 && q.whereFields == nil && !q.isSoftDelete() && !isTenantScoped &&
+		len(scopes) == 0 {
 		return b, nil
 	}
 
@@ -851,6 +950,37 @@ func (q *whereBaseQuery) appendWhere(
 		}
 	}
 
+	if isTenantScoped {
+		if len(b) > startLen {
+			b = append(b, " AND "...)
+		}
+
+		if withAlias {
+			b = append(b, q.table.SQLAlias...)
+		} else {
+			b = append(b, q.table.SQLName...)
+		}
+		b = append(b, '.')
+		b = append(b, q.table.TenantField.SQLName...)
+		b = append(b, " = "...)
+		b, err = q.appendTenantValue(gen, b, q.table)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(scopes) > 0 {
+		if len(b) > startLen {
+			b = append(b, " AND "...)
+		}
+
+		alias := q.table.SQLName
+		if withAlias {
+			alias = q.table.SQLAlias
+		}
+		b = appendScopes(gen, b, scopes, []byte(alias))
+	}
+
 	if q.isSoftDelete() {
 		if len(b) > startLen {
 			b = append(b, " AND "...)
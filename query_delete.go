@@ -103,6 +103,14 @@ func (q *DeleteQuery) Where(query string, args ...any) *DeleteQuery {
 	return q
 }
 
+// WherePred adds WHERE conditions built from typed columns, combined with AND.
+func (q *DeleteQuery) WherePred(preds ...Predicate) *DeleteQuery {
+	for _, pred := range preds {
+		q.addWhere(schema.SafeQueryWithSep("?", []any{pred}, " AND "))
+	}
+	return q
+}
+
 func (q *DeleteQuery) WhereOr(query string, args ...any) *DeleteQuery {
 	q.addWhere(schema.SafeQueryWithSep(query, args, " OR "))
 	return q
@@ -132,6 +140,26 @@ func (q *DeleteQuery) WhereAllWithDeleted() *DeleteQuery {
 	return q
 }
 
+// WithoutTenantScope disables the tenant predicate of the model, see WithTenantFromContext.
+func (q *DeleteQuery) WithoutTenantScope() *DeleteQuery {
+	q.flags = q.flags.Set(withoutTenantScopeFlag)
+	return q
+}
+
+// SelectAffected returns a query which selects the rows matched by the tables and
+// the WHERE conditions of the delete, e.g. to read the rows before they are deleted.
+// It uses the same connection.
+func (q *DeleteQuery) SelectAffected() *SelectQuery {
+	return q.newSelectAffected(q.db.HasFeature(feature.DeleteTableAlias))
+}
+
+// Unscoped removes the named default scopes of the models, see Scope.
+// Without names, it removes all of them.
+func (q *DeleteQuery) Unscoped(names ...string) *DeleteQuery {
+	q.unscope(names)
+	return q
+}
+
 func (q *DeleteQuery) Order(orders ...string) *DeleteQuery {
 	if !q.hasFeature(feature.DeleteOrderLimit) {
 		q.setErr(feature.NewNotSupportError(feature.DeleteOrderLimit))
@@ -198,10 +226,14 @@ func (q *DeleteQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte, err
 	if q.err != nil {
 		return nil, q.err
 	}
+	if err := q.checkWritable(q); err != nil {
+		return nil, err
+	}
 
 	b = appendComment(b, q.comment)
 
 	gen = formatterWithModel(gen, q)
+	gen = q.genWithTenant(gen)
 
 	if q.isSoftDelete() {
 		now := time.Now()
@@ -330,6 +362,7 @@ func (q *DeleteQuery) scanOrExec(
 
 	// if a comment is propagated via the context, use it
 	setCommentFromContext(ctx, q)
+	q.resolveTenant(ctx)
 
 	// Generate the query before checking hasReturning.
 	queryBytes, err := q.AppendQuery(q.db.gen, q.db.makeQueryBytes())
@@ -350,18 +383,24 @@ func (q *DeleteQuery) scanOrExec(
 
 	query := internal.String(queryBytes)
 
+	lock := q.structVersionLock()
+
 	var res sql.Result
 
 	if useScan {
 		res, err = q.scan(ctx, q, query, model, hasDest)
-		if err != nil {
-			return nil, err
-		}
 	} else {
 		res, err = q.exec(ctx, q, query)
-		if err != nil {
-			return nil, err
-		}
+	}
+	if lock != nil {
+		err = lock.check(res, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if lock != nil && q.isSoftDelete() {
+		// Soft deletes are updates, which increment the version.
+		lock.increment()
 	}
 
 	if q.table != nil {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/uptrace/bun/dialect/feature"
@@ -149,6 +150,13 @@ func (q *InsertQuery) Returning(query string, args ...any) *InsertQuery {
 	return q
 }
 
+// WithoutTenantScope inserts the tenant of the model instead of the tenant
+// from the context, see WithTenantFromContext.
+func (q *InsertQuery) WithoutTenantScope() *InsertQuery {
+	q.flags = q.flags.Set(withoutTenantScopeFlag)
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 // Ignore generates different queries depending on the DBMS:
@@ -188,10 +196,14 @@ func (q *InsertQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte, err
 	if q.err != nil {
 		return nil, q.err
 	}
+	if err := q.checkWritable(q); err != nil {
+		return nil, err
+	}
 
 	b = appendComment(b, q.comment)
 
 	gen = formatterWithModel(gen, q)
+	gen = q.genWithTenant(gen)
 
 	b, err = q.appendWith(gen, b)
 	if err != nil {
@@ -334,12 +346,19 @@ func (q *InsertQuery) appendStructValues(
 	gen schema.QueryGen, b []byte, fields []*schema.Field, strct reflect.Value,
 ) (_ []byte, err error) {
 	isTemplate := gen.IsNop()
+	_, hasTenant := gen.Tenant()
 	for i, f := range fields {
 		if i > 0 {
 			b = append(b, ", "...)
 		}
 
+		isTenant := q.isTenantScoped(q.table) && f == q.table.TenantField
 		app, ok := q.modelValues[f.Name]
+		if ok && isTenant {
+			return nil, fmt.Errorf(
+				"bun: %s is tenant-scoped, Value can't override column %s (use WithoutTenantScope)",
+				q.table.TypeName, f.Name)
+		}
 		if ok {
 			b, err = app.AppendQuery(gen, b)
 			if err != nil {
@@ -352,6 +371,11 @@ func (q *InsertQuery) appendStructValues(
 		switch {
 		case isTemplate:
 			b = append(b, '?')
+		case isTenant && hasTenant:
+			b, err = q.appendTenantValue(gen, b, q.table)
+			if err != nil {
+				return nil, err
+			}
 		case q.marshalsToDefault(f, strct):
 			if q.db.HasFeature(feature.DefaultPlaceholder) {
 				b = append(b, "DEFAULT"...)
@@ -406,7 +430,11 @@ func (q *InsertQuery) getFields() ([]*schema.Field, error) {
 	hasIdentity := q.db.HasFeature(feature.Identity)
 
 	if len(q.columns) > 0 || q.db.HasFeature(feature.DefaultPlaceholder) && !hasIdentity {
-		return q.baseQuery.getFields()
+		fields, err := q.baseQuery.getFields()
+		if err != nil {
+			return nil, err
+		}
+		return q.withTenant(q.withoutGenerated(fields)), nil
 	}
 
 	var strct reflect.Value
@@ -426,6 +454,10 @@ func (q *InsertQuery) getFields() ([]*schema.Field, error) {
 	fields := make([]*schema.Field, 0, len(q.table.Fields))
 
 	for _, f := range q.table.Fields {
+		if isGenerated(f) {
+			q.addReturningField(f)
+			continue
+		}
 		if hasIdentity && f.AutoIncrement {
 			q.addReturningField(f)
 			continue
@@ -437,7 +469,37 @@ func (q *InsertQuery) getFields() ([]*schema.Field, error) {
 		fields = append(fields, f)
 	}
 
-	return fields, nil
+	return q.withTenant(fields), nil
+}
+
+// withTenant adds the tenant column of the tenant-scoped model, so that the rows
+// get the tenant even when the column is not selected with Column.
+func (q *InsertQuery) withTenant(fields []*schema.Field) []*schema.Field {
+	if !q.isTenantScoped(q.table) || slices.Contains(fields, q.table.TenantField) {
+		return fields
+	}
+	return append(slices.Clone(fields), q.table.TenantField)
+}
+
+// withoutGenerated removes generated columns, which cannot be inserted, from the fields
+// and returns them instead, so that the values computed by the database are scanned into the model.
+func (q *InsertQuery) withoutGenerated(fields []*schema.Field) []*schema.Field {
+	if !slices.ContainsFunc(fields, isGenerated) {
+		return fields
+	}
+	filtered := make([]*schema.Field, 0, len(fields))
+	for _, f := range fields {
+		if isGenerated(f) {
+			q.addReturningField(f)
+			continue
+		}
+		filtered = append(filtered, f)
+	}
+	return filtered
+}
+
+func isGenerated(f *schema.Field) bool {
+	return f.Generated != ""
 }
 
 // marshalsToDefault checks if the value will be marshaled as DEFAULT or NULL (if DEFAULT placeholder is not supported)
@@ -500,13 +562,13 @@ func (q *InsertQuery) appendOn(gen schema.QueryGen, b []byte) (_ []byte, err err
 			return nil, err
 		}
 	} else if q.onConflictDoUpdate() {
-		fields, err := q.getDataFields()
+		fields, err := q.getUpsertFields()
 		if err != nil {
 			return nil, err
 		}
 		b = q.appendSetExcluded(b, fields)
 	} else if q.onDuplicateKeyUpdate() {
-		fields, err := q.getDataFields()
+		fields, err := q.getUpsertFields()
 		if err != nil {
 			return nil, err
 		}
@@ -525,6 +587,21 @@ func (q *InsertQuery) appendOn(gen schema.QueryGen, b []byte) (_ []byte, err err
 	return b, nil
 }
 
+// getUpsertFields returns the fields which are updated when the row already exists.
+func (q *InsertQuery) getUpsertFields() ([]*schema.Field, error) {
+	fields, err := q.getDataFields()
+	if err != nil {
+		return nil, err
+	}
+	if q.isTenantScoped(q.table) {
+		// Rows can't be moved to another tenant.
+		fields = slices.DeleteFunc(slices.Clone(fields), func(f *schema.Field) bool {
+			return f == q.table.TenantField
+		})
+	}
+	return fields, nil
+}
+
 func (q *InsertQuery) onConflictDoUpdate() bool {
 	return strings.HasSuffix(strings.ToUpper(q.on.Query), " DO UPDATE")
 }
@@ -535,8 +612,12 @@ func (q *InsertQuery) onDuplicateKeyUpdate() bool {
 
 func (q *InsertQuery) appendSetExcluded(b []byte, fields []*schema.Field) []byte {
 	b = append(b, " SET "...)
-	for i, f := range fields {
-		if i > 0 {
+	pos := len(b)
+	for _, f := range fields {
+		if isGenerated(f) {
+			continue
+		}
+		if len(b) != pos {
 			b = append(b, ", "...)
 		}
 		b = append(b, f.SQLName...)
@@ -548,8 +629,12 @@ func (q *InsertQuery) appendSetExcluded(b []byte, fields []*schema.Field) []byte
 
 func (q *InsertQuery) appendSetValues(b []byte, fields []*schema.Field) []byte {
 	b = append(b, " "...)
-	for i, f := range fields {
-		if i > 0 {
+	pos := len(b)
+	for _, f := range fields {
+		if isGenerated(f) {
+			continue
+		}
+		if len(b) != pos {
 			b = append(b, ", "...)
 		}
 		b = append(b, f.SQLName...)
@@ -591,6 +676,10 @@ func (q *InsertQuery) scanOrExec(
 
 	// if a comment is propagated via the context, use it
 	setCommentFromContext(ctx, q)
+	q.resolveTenant(ctx)
+	if q.isTenantScoped(q.table) && !q.flags.Has(tenantFlag) {
+		return nil, errNoTenant(q.table)
+	}
 
 	// Generate the query before checking hasReturning.
 	queryBytes, err := q.AppendQuery(q.db.gen, q.db.makeQueryBytes())
@@ -628,6 +717,12 @@ func (q *InsertQuery) scanOrExec(
 		}
 	}
 
+	if q.isTenantScoped(q.table) {
+		if err := q.setModelTenant(); err != nil {
+			return nil, err
+		}
+	}
+
 	if q.table != nil {
 		if err := q.afterInsertHook(ctx); err != nil {
 			return nil, err
@@ -637,6 +732,22 @@ func (q *InsertQuery) scanOrExec(
 	return res, nil
 }
 
+// setModelTenant writes the tenant from the context into the inserted models.
+func (q *InsertQuery) setModelTenant() error {
+	f := q.table.TenantField
+	switch model := q.tableModel.(type) {
+	case *structTableModel:
+		return f.ScanValue(model.strct, q.tenant)
+	case *sliceTableModel:
+		for i := 0; i < model.slice.Len(); i++ {
+			if err := f.ScanValue(indirect(model.slice.Index(i)), q.tenant); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (q *InsertQuery) beforeInsertHook(ctx context.Context) error {
 	if hook, ok := q.table.ZeroIface.(BeforeInsertHook); ok {
 		if err := hook.BeforeInsert(ctx, q); err != nil {
@@ -6,6 +6,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"iter"
+	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/uptrace/bun/dialect"
@@ -32,8 +35,11 @@ type SelectQuery struct {
 	having     []schema.QueryWithArgs
 	selFor     schema.QueryWithArgs
 
+	windows []namedWindow
 	union   []union
 	comment string
+
+	keyset *keyset
 }
 
 var _ Query = (*SelectQuery)(nil)
@@ -145,6 +151,14 @@ func (q *SelectQuery) ColumnExpr(query string, args ...any) *SelectQuery {
 	return q
 }
 
+// ColumnRef adds typed column references to the SELECT clause.
+func (q *SelectQuery) ColumnRef(columns ...ColumnRef) *SelectQuery {
+	for _, column := range columns {
+		q.addColumn(schema.SafeQuery("?", []any{column}))
+	}
+	return q
+}
+
 // ExcludeColumn excludes specific columns from being selected.
 func (q *SelectQuery) ExcludeColumn(columns ...string) *SelectQuery {
 	q.excludeColumn(columns)
@@ -171,6 +185,14 @@ func (q *SelectQuery) WhereOr(query string, args ...any) *SelectQuery {
 	return q
 }
 
+// WherePred adds WHERE conditions built from typed columns, combined with AND.
+func (q *SelectQuery) WherePred(preds ...Predicate) *SelectQuery {
+	for _, pred := range preds {
+		q.addWhere(schema.SafeQueryWithSep("?", []any{pred}, " AND "))
+	}
+	return q
+}
+
 // WhereGroup groups WHERE conditions with the given separator (AND/OR).
 func (q *SelectQuery) WhereGroup(sep string, fn func(*SelectQuery) *SelectQuery) *SelectQuery {
 	saved := q.where
@@ -198,6 +220,19 @@ func (q *SelectQuery) WhereAllWithDeleted() *SelectQuery {
 	return q
 }
 
+// WithoutTenantScope disables the tenant predicate of the model, see WithTenantFromContext.
+func (q *SelectQuery) WithoutTenantScope() *SelectQuery {
+	q.flags = q.flags.Set(withoutTenantScopeFlag)
+	return q
+}
+
+// Unscoped removes the named default scopes of the models, see Scope.
+// Without names, it removes all of them.
+func (q *SelectQuery) Unscoped(names ...string) *SelectQuery {
+	q.unscope(names)
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 // UseIndex adds a USE INDEX hint for MySQL to suggest index usage.
@@ -312,6 +347,14 @@ func (q *SelectQuery) GroupExpr(group string, args ...any) *SelectQuery {
 	return q
 }
 
+// GroupRef adds typed column references to the GROUP BY clause.
+func (q *SelectQuery) GroupRef(columns ...ColumnRef) *SelectQuery {
+	for _, column := range columns {
+		q.group = append(q.group, schema.SafeQuery("?", []any{column}))
+	}
+	return q
+}
+
 // Having adds a HAVING clause condition to filter grouped results.
 func (q *SelectQuery) Having(having string, args ...any) *SelectQuery {
 	q.having = append(q.having, schema.SafeQuery(having, args))
@@ -330,6 +373,12 @@ func (q *SelectQuery) OrderBy(colName string, sortDir Order) *SelectQuery {
 	return q
 }
 
+// OrderRef adds an ORDER BY clause for the typed column reference with explicit sort direction.
+func (q *SelectQuery) OrderRef(column ColumnRef, sortDir Order) *SelectQuery {
+	q.addOrderExpr("? ?", column, sortDir)
+	return q
+}
+
 // OrderExpr adds an ORDER BY expression with optional arguments.
 func (q *SelectQuery) OrderExpr(query string, args ...any) *SelectQuery {
 	q.addOrderExpr(query, args...)
@@ -454,6 +503,12 @@ type RelationOpts struct {
 	Apply func(*SelectQuery) *SelectQuery
 	// AdditionalJoinOnConditions adds additional conditions to the JOIN ON clause.
 	AdditionalJoinOnConditions []schema.QueryWithArgs
+	// Limit limits the number of rows selected for each base model of a has-many or m2m relation,
+	// e.g. the latest 5 comments of each post when Apply orders the comments by date.
+	Limit int
+	// BatchSize overrides the number of base models per has-many or m2m query
+	// set with WithRelationBatchSize.
+	BatchSize int
 }
 
 // RelationWithOpts adds a relation to the query with additional options.
@@ -477,6 +532,16 @@ func (q *SelectQuery) RelationWithOpts(name string, opts RelationOpts) *SelectQu
 		join.additionalJoinOnConditions = opts.AdditionalJoinOnConditions
 	}
 
+	if opts.Limit > 0 || opts.BatchSize > 0 {
+		switch join.Relation.Type {
+		case schema.HasManyRelation, schema.ManyToManyRelation:
+			join.limit = opts.Limit
+			join.batchSize = opts.BatchSize
+		default:
+			q.setErr(fmt.Errorf("bun: relation=%q: Limit and BatchSize require a has-many or m2m relation", name))
+		}
+	}
+
 	return q
 }
 
@@ -532,28 +597,54 @@ func (q *SelectQuery) _forEachInlineRelJoin(fn func(*relationJoin) error, joins
 	return nil
 }
 
+// selectJoins loads the has-many and m2m relations, including the ones nested in has-one
+// and belongs-to relations. Outside of a Conn or Tx, the relations are loaded concurrently
+// using separate connections from the pool.
 func (q *SelectQuery) selectJoins(ctx context.Context, joins []relationJoin) error {
-	for i := range joins {
-		j := &joins[i]
+	many := appendManyJoins(nil, joins)
+
+	if len(many) > 1 && q.isPooled() {
+		errs := make([]error, len(many))
+
+		var wg sync.WaitGroup
+		for i, j := range many {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = j.selectMany(ctx, q)
+			}()
+		}
+		wg.Wait()
 
-		var err error
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 
+	for _, j := range many {
+		if err := j.selectMany(ctx, q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendManyJoins(many []*relationJoin, joins []relationJoin) []*relationJoin {
+	for i := range joins {
+		j := &joins[i]
 		switch j.Relation.Type {
 		case schema.HasOneRelation, schema.BelongsToRelation:
-			err = q.selectJoins(ctx, j.JoinModel.getJoins())
-		case schema.HasManyRelation:
-			err = j.selectMany(ctx, q.db.NewSelect().Conn(q.conn))
-		case schema.ManyToManyRelation:
-			err = j.selectM2M(ctx, q.db.NewSelect().Conn(q.conn))
+			many = appendManyJoins(many, j.JoinModel.getJoins())
+		case schema.HasManyRelation, schema.ManyToManyRelation:
+			many = append(many, j)
 		default:
 			panic("not reached")
 		}
-
-		if err != nil {
-			return err
-		}
 	}
-	return nil
+	return many
 }
 
 //------------------------------------------------------------------------------
@@ -585,6 +676,7 @@ func (q *SelectQuery) appendQuery(
 	}
 
 	gen = formatterWithModel(gen, q)
+	gen = q.genWithTenant(gen)
 
 	cteCount := count && (len(q.group) > 0 || q.distinctOn != nil)
 	if cteCount {
@@ -699,6 +791,11 @@ func (q *SelectQuery) appendQuery(
 		}
 	}
 
+	b, err = q.appendWindows(gen, b)
+	if err != nil {
+		return nil, err
+	}
+
 	if !count {
 		b, err = q.appendOrder(gen, b)
 		if err != nil {
@@ -858,6 +955,7 @@ func (q *SelectQuery) Rows(ctx context.Context) (*sql.Rows, error) {
 
 	// if a comment is propagated via the context, use it
 	setCommentFromContext(ctx, q)
+	q.resolveTenant(ctx)
 
 	queryBytes, err := q.AppendQuery(q.db.gen, q.db.makeQueryBytes())
 	if err != nil {
@@ -872,6 +970,77 @@ func (q *SelectQuery) Rows(ctx context.Context) (*sql.Rows, error) {
 	return rows, err
 }
 
+// Iter executes the query and returns an iterator which scans the rows one by one
+// into new values of type T, so the result does not have to fit in memory.
+// The query model defaults to (*T)(nil) and may join has-one and belongs-to relations.
+//
+// The rows are closed when the loop is done or stopped early. Errors, including the ones
+// returned by the hooks, are reported by the iterator and end the iteration.
+func Iter[T any](ctx context.Context, q *SelectQuery) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		if err := q.iter(ctx, reflect.TypeFor[T](), func(strct reflect.Value) bool {
+			return yield(strct.Addr().Interface().(*T), nil)
+		}); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func (q *SelectQuery) iter(ctx context.Context, typ reflect.Type, yield func(reflect.Value) bool) error {
+	if q.model == nil && q.err == nil {
+		q.Model(reflect.New(reflect.PointerTo(typ)).Elem().Interface())
+	}
+	if q.err != nil {
+		return q.err
+	}
+
+	model, ok := q.model.(*structTableModel)
+	if !ok || model.table.Type != typ {
+		return fmt.Errorf("bun: Iter[%s] requires Model((*%s)(nil)), got %T", typ, typ, q.model)
+	}
+	for _, j := range model.joins {
+		switch j.Relation.Type {
+		case schema.HasManyRelation, schema.ManyToManyRelation:
+			return fmt.Errorf("bun: Iter does not support has-many and m2m relations, got %s", j.Relation.Field.GoName)
+		}
+	}
+
+	if err := q.beforeSelectHook(ctx); err != nil {
+		return err
+	}
+
+	rows, err := q.Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	model.columns = columns
+	dest := makeDest(model, len(columns))
+
+	for rows.Next() {
+		// Scan each row into a new value, mounting the joined models onto it.
+		model.strct = reflect.New(typ).Elem()
+		model.structInited = false
+
+		if err := model.scanRow(ctx, rows, dest); err != nil {
+			return err
+		}
+		if !yield(model.strct) {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return q.afterSelectHook(ctx)
+}
+
 // Exec executes the query and optionally scans results into dest.
 func (q *SelectQuery) Exec(ctx context.Context, dest ...any) (res sql.Result, err error) {
 	if q.err != nil {
@@ -883,6 +1052,7 @@ func (q *SelectQuery) Exec(ctx context.Context, dest ...any) (res sql.Result, er
 
 	// if a comment is propagated via the context, use it
 	setCommentFromContext(ctx, q)
+	q.resolveTenant(ctx)
 
 	queryBytes, err := q.AppendQuery(q.db.gen, q.db.makeQueryBytes())
 	if err != nil {
@@ -947,6 +1117,7 @@ func (q *SelectQuery) scanResult(ctx context.Context, dest ...any) (sql.Result,
 
 	// if a comment is propagated via the context, use it
 	setCommentFromContext(ctx, q)
+	q.resolveTenant(ctx)
 
 	queryBytes, err := q.AppendQuery(q.db.gen, q.db.makeQueryBytes())
 	if err != nil {
@@ -960,7 +1131,7 @@ func (q *SelectQuery) scanResult(ctx context.Context, dest ...any) (sql.Result,
 		return nil, err
 	}
 
-	if n, _ := res.RowsAffected(); n > 0 {
+	if n, _ := res.RowsAffected(); n > 0 && !q.flags.Has(skipJoinsFlag) {
 		if tableModel, ok := model.(TableModel); ok {
 			if err := q.selectJoins(ctx, tableModel.getJoins()); err != nil {
 				return nil, err
@@ -1003,6 +1174,7 @@ func (q *SelectQuery) Count(ctx context.Context) (int, error) {
 
 	// if a comment is propagated via the context, use it
 	setCommentFromContext(ctx, q)
+	q.resolveTenant(ctx)
 
 	qq := countQuery{q}
 
@@ -1118,6 +1290,7 @@ func (q *SelectQuery) Exists(ctx context.Context) (bool, error) {
 func (q *SelectQuery) selectExists(ctx context.Context) (bool, error) {
 	// if a comment is propagated via the context, use it
 	setCommentFromContext(ctx, q)
+	q.resolveTenant(ctx)
 
 	qq := selectExistsQuery{q}
 
@@ -1140,6 +1313,7 @@ func (q *SelectQuery) selectExists(ctx context.Context) (bool, error) {
 func (q *SelectQuery) whereExists(ctx context.Context) (bool, error) {
 	// if a comment is propagated via the context, use it
 	setCommentFromContext(ctx, q)
+	q.resolveTenant(ctx)
 
 	qq := whereExistsQuery{q}
 
@@ -1213,6 +1387,9 @@ func (q *SelectQuery) Clone() *SelectQuery {
 				tables:         cloneArgs(q.tables),
 				columns:        cloneArgs(q.columns),
 				modelTableName: q.modelTableName,
+				tenant:         q.tenant,
+				unscoped:       slices.Clone(q.unscoped),
+				flags:          q.flags,
 			},
 			where: make([]schema.QueryWithSep, len(q.where)),
 		},
@@ -1233,8 +1410,10 @@ func (q *SelectQuery) Clone() *SelectQuery {
 		joins:      make([]joinQuery, len(q.joins)),
 		group:      cloneArgs(q.group),
 		having:     cloneArgs(q.having),
+		windows:    append([]namedWindow(nil), q.windows...),
 		union:      make([]union, len(q.union)),
 		comment:    q.comment,
+		keyset:     q.keyset,
 	}
 
 	for i, w := range q.with {
@@ -23,6 +23,7 @@ type CreateTableQuery struct {
 	temp        bool
 	ifNotExists bool
 	fksFromRel  bool // Create foreign keys captured in table's relations.
+	withIndexes bool // Create indexes declared in the model's tags.
 
 	// varchar changes the default length for VARCHAR columns.
 	// Because some dialects require that length is always specified for VARCHAR type,
@@ -131,6 +132,14 @@ func (q *CreateTableQuery) WithForeignKeys() *CreateTableQuery {
 	return q
 }
 
+// WithIndexes creates the indexes declared with the `index` tag option after creating the table.
+// The CREATE INDEX statements are appended to the query separated by semicolons,
+// but Exec runs them one by one, since not every driver accepts multiple statements in a query.
+func (q *CreateTableQuery) WithIndexes() *CreateTableQuery {
+	q.withIndexes = true
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 // Comment adds a comment to the query, wrapped by /* ... */.
@@ -146,6 +155,41 @@ func (q *CreateTableQuery) Operation() string {
 }
 
 func (q *CreateTableQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte, err error) {
+	b, err = q.appendCreateTable(gen, b)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := q.commentOnQueries(gen)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		b = append(b, "; "...)
+		b, err = comment.AppendQuery(gen, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if q.withIndexes {
+		indexes, err := q.createIndexQueries(gen)
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indexes {
+			b = append(b, "; "...)
+			b, err = index.AppendQuery(gen, b)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return b, nil
+}
+
+func (q *CreateTableQuery) appendCreateTable(gen schema.QueryGen, b []byte) (_ []byte, err error) {
 	if q.err != nil {
 		return nil, q.err
 	}
@@ -177,8 +221,17 @@ func (q *CreateTableQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte,
 		}
 
 		b = append(b, field.SQLName...)
+		if field.Generated != "" && q.db.dialect.Name() == dialect.MSSQL {
+			// Computed columns in SQL Server do not declare a type.
+			b = appendGenerated(b, q.db.dialect.Name(), field)
+			continue
+		}
+
 		b = append(b, " "...)
 		b = q.appendSQLType(b, field)
+		if field.Generated != "" {
+			b = appendGenerated(b, q.db.dialect.Name(), field)
+		}
 		if field.NotNull && q.db.dialect.Name() != dialect.Oracle {
 			b = append(b, " NOT NULL"...)
 		}
@@ -188,10 +241,14 @@ func (q *CreateTableQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte,
 			b = q.db.dialect.AppendSequence(b, q.table, field)
 		}
 
-		if field.SQLDefault != "" {
+		if field.SQLDefault != "" && field.Generated == "" {
 			b = append(b, " DEFAULT "...)
 			b = append(b, field.SQLDefault...)
 		}
+
+		if field.Comment != "" && gen.HasFeature(feature.InlineComment) {
+			b = gen.AppendQuery(b, " COMMENT ?", field.Comment)
+		}
 	}
 
 	for i, col := range q.columns {
@@ -214,6 +271,7 @@ func (q *CreateTableQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte,
 		b = q.appendPKConstraint(b, q.table.PKs)
 	}
 	b = q.appendUniqueConstraints(gen, b)
+	b = q.appendCheckConstraints(gen, b)
 
 	if q.fksFromRel {
 		b, err = q.appendFKConstraintsRel(gen, b)
@@ -228,6 +286,10 @@ func (q *CreateTableQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte,
 
 	b = append(b, ")"...)
 
+	if q.table.Comment != "" && gen.HasFeature(feature.InlineComment) {
+		b = gen.AppendQuery(b, " COMMENT ?", q.table.Comment)
+	}
+
 	if !q.partitionBy.IsZero() {
 		b = append(b, " PARTITION BY "...)
 		b, err = q.partitionBy.AppendQuery(gen, b)
@@ -247,6 +309,63 @@ func (q *CreateTableQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte,
 	return b, nil
 }
 
+// commentOnQueries builds COMMENT ON statements for the table and column comments
+// declared in the model, if the dialect does not support inline comments.
+func (q *CreateTableQuery) commentOnQueries(gen schema.QueryGen) ([]schema.QueryWithArgs, error) {
+	if q.table == nil || !gen.HasFeature(feature.CommentOn) {
+		return nil, nil
+	}
+
+	tableName, err := q.appendFirstTable(gen, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []schema.QueryWithArgs
+	if q.table.Comment != "" {
+		queries = append(queries, schema.SafeQuery("COMMENT ON TABLE ? IS ?", []any{Safe(tableName), q.table.Comment}))
+	}
+	for _, field := range q.table.Fields {
+		if field.Comment != "" {
+			queries = append(queries, schema.SafeQuery("COMMENT ON COLUMN ?.? IS ?", []any{Safe(tableName), field.SQLName, field.Comment}))
+		}
+	}
+	return queries, nil
+}
+
+// createIndexQueries builds a CREATE INDEX query for each index declared in the model.
+func (q *CreateTableQuery) createIndexQueries(gen schema.QueryGen) ([]*CreateIndexQuery, error) {
+	tableName, err := q.appendFirstTable(gen, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]*CreateIndexQuery, 0, len(q.table.Indexes))
+	for _, idx := range q.table.Indexes {
+		iq := NewCreateIndexQuery(q.db).
+			Conn(q.conn).
+			Index(idx.Name).
+			TableExpr("?", Safe(tableName))
+		if q.ifNotExists {
+			iq = iq.IfNotExists()
+		}
+		if idx.Method != "" {
+			iq = iq.Using(idx.Method)
+		}
+		for _, f := range idx.Columns {
+			iq = iq.Column(f.Name)
+		}
+		for _, f := range idx.Include {
+			iq = iq.Include(f.Name)
+		}
+		if idx.Where != "" {
+			iq = iq.Where("?", Safe(idx.Where))
+		}
+		queries = append(queries, iq)
+	}
+	return queries, nil
+}
+
 func (q *CreateTableQuery) appendSQLType(b []byte, field *schema.Field) []byte {
 	// Most of the time these two will match, but for the cases where DiscoveredSQLType is dialect-specific,
 	// e.g. pgdialect would change sqltype.SmallInt to pgTypeSmallSerial for columns that have `bun:",autoincrement"`
@@ -271,6 +390,27 @@ func (q *CreateTableQuery) appendSQLType(b []byte, field *schema.Field) []byte {
 	return b
 }
 
+// appendGenerated appends the definition of a generated column, which must follow the column type.
+func appendGenerated(b []byte, name dialect.Name, field *schema.Field) []byte {
+	if name == dialect.MSSQL {
+		b = append(b, " AS ("...)
+		b = append(b, field.Generated...)
+		b = append(b, ")"...)
+		if !field.Virtual {
+			b = append(b, " PERSISTED"...)
+		}
+		return b
+	}
+
+	b = append(b, " GENERATED ALWAYS AS ("...)
+	b = append(b, field.Generated...)
+	b = append(b, ")"...)
+	if field.Virtual {
+		return append(b, " VIRTUAL"...)
+	}
+	return append(b, " STORED"...)
+}
+
 func (q *CreateTableQuery) appendUniqueConstraints(gen schema.QueryGen, b []byte) []byte {
 	unique := q.table.Unique
 
@@ -308,6 +448,17 @@ func (q *CreateTableQuery) appendUniqueConstraint(
 	return b
 }
 
+func (q *CreateTableQuery) appendCheckConstraints(gen schema.QueryGen, b []byte) []byte {
+	for _, check := range q.table.Checks {
+		b = append(b, ", CONSTRAINT "...)
+		b = gen.AppendIdent(b, check.Name)
+		b = append(b, " CHECK ("...)
+		b = append(b, check.Expr...)
+		b = append(b, ")"...)
+	}
+	return b
+}
+
 // appendFKConstraintsRel appends a FOREIGN KEY clause for each of the model's existing relations.
 func (q *CreateTableQuery) appendFKConstraintsRel(gen schema.QueryGen, b []byte) (_ []byte, err error) {
 	relations := q.tableModel.Table().Relations
@@ -379,7 +530,7 @@ func (q *CreateTableQuery) Exec(ctx context.Context, dest ...any) (sql.Result, e
 	// if a comment is propagated via the context, use it
 	setCommentFromContext(ctx, q)
 
-	queryBytes, err := q.AppendQuery(q.db.gen, q.db.makeQueryBytes())
+	queryBytes, err := q.appendCreateTable(q.db.gen, q.db.makeQueryBytes())
 	if err != nil {
 		return nil, err
 	}
@@ -391,6 +542,32 @@ func (q *CreateTableQuery) Exec(ctx context.Context, dest ...any) (sql.Result, e
 		return nil, err
 	}
 
+	comments, err := q.commentOnQueries(q.db.gen)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		commentBytes, err := comment.AppendQuery(q.db.gen, q.db.makeQueryBytes())
+		if err != nil {
+			return nil, err
+		}
+		if _, err := q.exec(ctx, q, internal.String(commentBytes)); err != nil {
+			return nil, err
+		}
+	}
+
+	if q.withIndexes {
+		indexes, err := q.createIndexQueries(q.db.gen)
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indexes {
+			if _, err := index.Exec(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if q.table != nil {
 		if err := q.afterCreateTableHook(ctx); err != nil {
 			return nil, err
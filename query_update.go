@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/uptrace/bun/dialect"
 
@@ -23,6 +24,7 @@ type UpdateQuery struct {
 
 	joins   []joinQuery
 	comment string
+	bulk    bool
 }
 
 var _ Query = (*UpdateQuery)(nil)
@@ -125,6 +127,18 @@ func (q *UpdateQuery) SetColumn(column string, query string, args ...any) *Updat
 	return q
 }
 
+// SetAssignment adds the assignments built from typed columns to the SET clause.
+func (q *UpdateQuery) SetAssignment(assignments ...Assignment) *UpdateQuery {
+	for _, a := range assignments {
+		column := a.column
+		if q.db.HasFeature(feature.UpdateMultiTable) {
+			column = q.table.Alias + "." + column
+		}
+		q.addSet(schema.SafeQuery("? = ?", []any{Ident(column), a.value}))
+	}
+	return q
+}
+
 // Value overwrites model value for the column.
 func (q *UpdateQuery) Value(column string, query string, args ...any) *UpdateQuery {
 	if q.table == nil {
@@ -179,6 +193,14 @@ func (q *UpdateQuery) Where(query string, args ...any) *UpdateQuery {
 	return q
 }
 
+// WherePred adds WHERE conditions built from typed columns, combined with AND.
+func (q *UpdateQuery) WherePred(preds ...Predicate) *UpdateQuery {
+	for _, pred := range preds {
+		q.addWhere(schema.SafeQueryWithSep("?", []any{pred}, " AND "))
+	}
+	return q
+}
+
 func (q *UpdateQuery) WhereOr(query string, args ...any) *UpdateQuery {
 	q.addWhere(schema.SafeQueryWithSep(query, args, " OR "))
 	return q
@@ -208,6 +230,28 @@ func (q *UpdateQuery) WhereAllWithDeleted() *UpdateQuery {
 	return q
 }
 
+// WithoutTenantScope disables the tenant predicate of the model, see WithTenantFromContext.
+func (q *UpdateQuery) WithoutTenantScope() *UpdateQuery {
+	q.flags = q.flags.Set(withoutTenantScopeFlag)
+	return q
+}
+
+// SelectAffected returns a query which selects the rows matched by the tables and
+// the WHERE conditions of the update, e.g. to read the rows before they are updated.
+// It uses the same connection and supports Bulk updates.
+func (q *UpdateQuery) SelectAffected() *SelectQuery {
+	sel := q.newSelectAffected(q.hasTableAlias(q.db.gen))
+	sel.joins = slices.Clone(q.joins)
+	return sel
+}
+
+// Unscoped removes the named default scopes of the models, see Scope.
+// Without names, it removes all of them.
+func (q *UpdateQuery) Unscoped(names ...string) *UpdateQuery {
+	q.unscope(names)
+	return q
+}
+
 // ------------------------------------------------------------------------------
 func (q *UpdateQuery) Order(orders ...string) *UpdateQuery {
 	if !q.hasFeature(feature.UpdateOrderLimit) {
@@ -264,10 +308,14 @@ func (q *UpdateQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte, err
 	if q.err != nil {
 		return nil, q.err
 	}
+	if err := q.checkWritable(q); err != nil {
+		return nil, err
+	}
 
 	b = appendComment(b, q.comment)
 
 	gen = formatterWithModel(gen, q)
+	gen = q.genWithTenant(gen)
 
 	b, err = q.appendWith(gen, b)
 	if err != nil {
@@ -362,6 +410,18 @@ func (q *UpdateQuery) mustAppendSet(gen schema.QueryGen, b []byte) (_ []byte, er
 		if err != nil {
 			return nil, err
 		}
+		if version := q.table.VersionField; version != nil {
+			// The version is incremented below.
+			fields = slices.DeleteFunc(slices.Clone(fields), func(f *schema.Field) bool {
+				return f == version
+			})
+		}
+		if q.isTenantScoped(q.table) {
+			// Rows can't be moved to another tenant.
+			fields = slices.DeleteFunc(slices.Clone(fields), func(f *schema.Field) bool {
+				return f == q.table.TenantField
+			})
+		}
 
 		b, err = q.appendSetStruct(gen, b, model, fields)
 		if err != nil {
@@ -388,12 +448,20 @@ func (q *UpdateQuery) mustAppendSet(gen schema.QueryGen, b []byte) (_ []byte, er
 		if len(b) > pos {
 			b = append(b, ", "...)
 		}
-		return q.appendSet(gen, b)
+		b, err = q.appendSet(gen, b)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if len(b) == pos {
 		return nil, errors.New("bun: empty SET clause is not allowed in the UPDATE query")
 	}
+
+	if lock := q.structVersionLock(); lock != nil {
+		b = append(b, ", "...)
+		b = appendVersionIncrement(b, lock.field, "")
+	}
 	return b, nil
 }
 
@@ -430,6 +498,7 @@ func (q *UpdateQuery) Bulk() *UpdateQuery {
 	values := q.db.NewValues(model)
 	values.customValueQuery = q.customValueQuery
 
+	q.bulk = true
 	return q.With("_data", values).
 		Model(model).
 		TableExpr("_data").
@@ -447,8 +516,14 @@ func (q *UpdateQuery) updateSliceSet(
 
 	var b []byte
 	pos := len(b)
+	version := model.table.VersionField
+	// Rows can't be moved to another tenant.
+	var tenant *schema.Field
+	if q.isTenantScoped(model.table) {
+		tenant = model.table.TenantField
+	}
 	for _, field := range fields {
-		if field.SkipUpdate() {
+		if field.SkipUpdate() || field == version || field == tenant {
 			continue
 		}
 		if len(b) != pos {
@@ -463,28 +538,47 @@ func (q *UpdateQuery) updateSliceSet(
 		b = append(b, " = _data."...)
 		b = append(b, field.SQLName...)
 	}
+
+	if version != nil {
+		if len(b) != pos {
+			b = append(b, ", "...)
+		}
+		if gen.HasFeature(feature.UpdateMultiTable) {
+			b = append(b, model.table.SQLAlias...)
+			b = append(b, '.')
+		}
+		b = appendVersionIncrement(b, version, q.tableNameOrAlias(gen, model.table))
+	}
 	return internal.String(b), nil
 }
 
 func (q *UpdateQuery) updateSliceWhere(gen schema.QueryGen, model *sliceTableModel) string {
+	fields := model.table.PKs
+	if model.table.VersionField != nil {
+		fields = append(slices.Clone(fields), model.table.VersionField)
+	}
+
 	var b []byte
-	for i, pk := range model.table.PKs {
+	for i, f := range fields {
 		if i > 0 {
 			b = append(b, " AND "...)
 		}
-		if q.hasTableAlias(gen) {
-			b = append(b, model.table.SQLAlias...)
-		} else {
-			b = append(b, model.table.SQLName...)
-		}
+		b = append(b, q.tableNameOrAlias(gen, model.table)...)
 		b = append(b, '.')
-		b = append(b, pk.SQLName...)
+		b = append(b, f.SQLName...)
 		b = append(b, " = _data."...)
-		b = append(b, pk.SQLName...)
+		b = append(b, f.SQLName...)
 	}
 	return internal.String(b)
 }
 
+func (q *UpdateQuery) tableNameOrAlias(gen schema.QueryGen, table *schema.Table) schema.Safe {
+	if q.hasTableAlias(gen) {
+		return table.SQLAlias
+	}
+	return table.SQLName
+}
+
 //------------------------------------------------------------------------------
 
 func (q *UpdateQuery) Scan(ctx context.Context, dest ...any) error {
@@ -516,6 +610,7 @@ func (q *UpdateQuery) scanOrExec(
 
 	// if a comment is propagated via the context, use it
 	setCommentFromContext(ctx, q)
+	q.resolveTenant(ctx)
 
 	// Generate the query before checking hasReturning.
 	queryBytes, err := q.AppendQuery(q.db.gen, q.db.makeQueryBytes())
@@ -536,18 +631,27 @@ func (q *UpdateQuery) scanOrExec(
 
 	query := internal.String(queryBytes)
 
+	// Remember the versions before RETURNING overwrites them.
+	lock := q.structVersionLock()
+	if q.bulk {
+		lock = q.sliceVersionLock()
+	}
+
 	var res sql.Result
 
 	if useScan {
 		res, err = q.scan(ctx, q, query, model, hasDest)
-		if err != nil {
-			return nil, err
-		}
 	} else {
 		res, err = q.exec(ctx, q, query)
-		if err != nil {
-			return nil, err
-		}
+	}
+	if lock != nil {
+		err = lock.check(res, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if lock != nil {
+		lock.increment()
 	}
 
 	if q.table != nil {
@@ -0,0 +1,187 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+// CreateViewQuery builds CREATE VIEW statements.
+type CreateViewQuery struct {
+	baseQuery
+
+	orReplace    bool
+	ifNotExists  bool
+	materialized bool
+	query        *SelectQuery
+	comment      string
+}
+
+var _ Query = (*CreateViewQuery)(nil)
+
+// NewCreateViewQuery returns a CreateViewQuery tied to the provided DB.
+func NewCreateViewQuery(db *DB) *CreateViewQuery {
+	q := &CreateViewQuery{
+		baseQuery: baseQuery{
+			db: db,
+		},
+	}
+	return q
+}
+
+func (q *CreateViewQuery) Conn(db IConn) *CreateViewQuery {
+	q.setConn(db)
+	return q
+}
+
+func (q *CreateViewQuery) Model(model any) *CreateViewQuery {
+	q.setModel(model)
+	return q
+}
+
+func (q *CreateViewQuery) Err(err error) *CreateViewQuery {
+	q.setErr(err)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateViewQuery) Table(tables ...string) *CreateViewQuery {
+	for _, table := range tables {
+		q.addTable(schema.UnsafeIdent(table))
+	}
+	return q
+}
+
+func (q *CreateViewQuery) TableExpr(query string, args ...any) *CreateViewQuery {
+	q.addTable(schema.SafeQuery(query, args))
+	return q
+}
+
+func (q *CreateViewQuery) ModelTableExpr(query string, args ...any) *CreateViewQuery {
+	q.modelTableName = schema.SafeQuery(query, args)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+// As sets the query which defines the view.
+func (q *CreateViewQuery) As(query *SelectQuery) *CreateViewQuery {
+	q.query = query
+	return q
+}
+
+// OrReplace replaces the definition of an existing view.
+// MSSQL renders it as CREATE OR ALTER VIEW.
+func (q *CreateViewQuery) OrReplace() *CreateViewQuery {
+	q.orReplace = true
+	return q
+}
+
+func (q *CreateViewQuery) IfNotExists() *CreateViewQuery {
+	q.ifNotExists = true
+	return q
+}
+
+// Materialized creates a materialized view. It is implied by
+// the materialized option of the model, e.g. `bun:"view:name,materialized"`.
+func (q *CreateViewQuery) Materialized() *CreateViewQuery {
+	q.materialized = true
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+// Comment adds a comment to the query, wrapped by /* ... */.
+func (q *CreateViewQuery) Comment(comment string) *CreateViewQuery {
+	q.comment = comment
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateViewQuery) Operation() string {
+	return "CREATE VIEW"
+}
+
+func (q *CreateViewQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte, err error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if q.query == nil {
+		return nil, errors.New("bun: CreateView requires a query, use As")
+	}
+
+	materialized := q.materialized || q.table != nil && q.table.IsMaterialized
+	if materialized && !gen.HasFeature(feature.MaterializedView) {
+		return nil, feature.NewNotSupportError(feature.MaterializedView)
+	}
+
+	b = appendComment(b, q.comment)
+
+	b = append(b, "CREATE "...)
+	if q.orReplace {
+		if gen.Dialect().Name() == dialect.MSSQL {
+			b = append(b, "OR ALTER "...)
+		} else {
+			b = append(b, "OR REPLACE "...)
+		}
+	}
+	if materialized {
+		b = append(b, "MATERIALIZED "...)
+	}
+	b = append(b, "VIEW "...)
+	if q.ifNotExists {
+		b = append(b, "IF NOT EXISTS "...)
+	}
+
+	b, err = q.appendFirstTable(gen, b)
+	if err != nil {
+		return nil, err
+	}
+
+	b = append(b, " AS "...)
+
+	b, err = q.query.AppendQuery(gen, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateViewQuery) Exec(ctx context.Context, dest ...any) (sql.Result, error) {
+	// if a comment is propagated via the context, use it
+	setCommentFromContext(ctx, q)
+
+	queryBytes, err := q.AppendQuery(q.db.gen, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	query := internal.String(queryBytes)
+
+	res, err := q.exec(ctx, q, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// String returns the generated SQL query string. The CreateViewQuery instance must not be
+// modified during query generation to ensure multiple calls to String() return identical results.
+func (q *CreateViewQuery) String() string {
+	buf, err := q.AppendQuery(q.db.QueryGen(), nil)
+	if err != nil {
+		panic(err)
+	}
+	return string(buf)
+}
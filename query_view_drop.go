@@ -0,0 +1,166 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+// DropViewQuery builds DROP VIEW statements.
+type DropViewQuery struct {
+	baseQuery
+	cascadeQuery
+
+	ifExists     bool
+	materialized bool
+	comment      string
+}
+
+var _ Query = (*DropViewQuery)(nil)
+
+// NewDropViewQuery returns a DropViewQuery tied to the provided DB.
+func NewDropViewQuery(db *DB) *DropViewQuery {
+	q := &DropViewQuery{
+		baseQuery: baseQuery{
+			db: db,
+		},
+	}
+	return q
+}
+
+func (q *DropViewQuery) Conn(db IConn) *DropViewQuery {
+	q.setConn(db)
+	return q
+}
+
+func (q *DropViewQuery) Model(model any) *DropViewQuery {
+	q.setModel(model)
+	return q
+}
+
+func (q *DropViewQuery) Err(err error) *DropViewQuery {
+	q.setErr(err)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropViewQuery) Table(tables ...string) *DropViewQuery {
+	for _, table := range tables {
+		q.addTable(schema.UnsafeIdent(table))
+	}
+	return q
+}
+
+func (q *DropViewQuery) TableExpr(query string, args ...any) *DropViewQuery {
+	q.addTable(schema.SafeQuery(query, args))
+	return q
+}
+
+func (q *DropViewQuery) ModelTableExpr(query string, args ...any) *DropViewQuery {
+	q.modelTableName = schema.SafeQuery(query, args)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropViewQuery) IfExists() *DropViewQuery {
+	q.ifExists = true
+	return q
+}
+
+// Materialized drops a materialized view. It is implied by
+// the materialized option of the model, e.g. `bun:"view:name,materialized"`.
+func (q *DropViewQuery) Materialized() *DropViewQuery {
+	q.materialized = true
+	return q
+}
+
+func (q *DropViewQuery) Cascade() *DropViewQuery {
+	q.cascade = true
+	return q
+}
+
+func (q *DropViewQuery) Restrict() *DropViewQuery {
+	q.restrict = true
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+// Comment adds a comment to the query, wrapped by /* ... */.
+func (q *DropViewQuery) Comment(comment string) *DropViewQuery {
+	q.comment = comment
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropViewQuery) Operation() string {
+	return "DROP VIEW"
+}
+
+func (q *DropViewQuery) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte, err error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	materialized := q.materialized || q.table != nil && q.table.IsMaterialized
+	if materialized && !gen.HasFeature(feature.MaterializedView) {
+		return nil, feature.NewNotSupportError(feature.MaterializedView)
+	}
+
+	b = appendComment(b, q.comment)
+
+	b = append(b, "DROP "...)
+	if materialized {
+		b = append(b, "MATERIALIZED "...)
+	}
+	b = append(b, "VIEW "...)
+	if q.ifExists {
+		b = append(b, "IF EXISTS "...)
+	}
+
+	b, err = q.appendTables(gen, b)
+	if err != nil {
+		return nil, err
+	}
+
+	b = q.appendCascade(gen, b)
+
+	return b, nil
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropViewQuery) Exec(ctx context.Context, dest ...any) (sql.Result, error) {
+	// if a comment is propagated via the context, use it
+	setCommentFromContext(ctx, q)
+
+	queryBytes, err := q.AppendQuery(q.db.gen, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	query := internal.String(queryBytes)
+
+	res, err := q.exec(ctx, q, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// String returns the generated SQL query string. The DropViewQuery instance must not be
+// modified during query generation to ensure multiple calls to String() return identical results.
+func (q *DropViewQuery) String() string {
+	buf, err := q.AppendQuery(q.db.QueryGen(), nil)
+	if err != nil {
+		panic(err)
+	}
+	return string(buf)
+}
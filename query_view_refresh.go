@@ -0,0 +1,143 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+// RefreshMaterializedViewQuery builds REFRESH MATERIALIZED VIEW statements (PostgreSQL).
+type RefreshMaterializedViewQuery struct {
+	baseQuery
+
+	concurrently bool
+	comment      string
+}
+
+var _ Query = (*RefreshMaterializedViewQuery)(nil)
+
+// NewRefreshMaterializedViewQuery returns a RefreshMaterializedViewQuery tied to the provided DB.
+func NewRefreshMaterializedViewQuery(db *DB) *RefreshMaterializedViewQuery {
+	q := &RefreshMaterializedViewQuery{
+		baseQuery: baseQuery{
+			db: db,
+		},
+	}
+	return q
+}
+
+func (q *RefreshMaterializedViewQuery) Conn(db IConn) *RefreshMaterializedViewQuery {
+	q.setConn(db)
+	return q
+}
+
+func (q *RefreshMaterializedViewQuery) Model(model any) *RefreshMaterializedViewQuery {
+	q.setModel(model)
+	return q
+}
+
+func (q *RefreshMaterializedViewQuery) Err(err error) *RefreshMaterializedViewQuery {
+	q.setErr(err)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *RefreshMaterializedViewQuery) Table(tables ...string) *RefreshMaterializedViewQuery {
+	for _, table := range tables {
+		q.addTable(schema.UnsafeIdent(table))
+	}
+	return q
+}
+
+func (q *RefreshMaterializedViewQuery) TableExpr(query string, args ...any) *RefreshMaterializedViewQuery {
+	q.addTable(schema.SafeQuery(query, args))
+	return q
+}
+
+func (q *RefreshMaterializedViewQuery) ModelTableExpr(query string, args ...any) *RefreshMaterializedViewQuery {
+	q.modelTableName = schema.SafeQuery(query, args)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+// Concurrently refreshes the view without locking out concurrent selects.
+// PostgreSQL requires the view to have a unique index to do that.
+func (q *RefreshMaterializedViewQuery) Concurrently() *RefreshMaterializedViewQuery {
+	q.concurrently = true
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+// Comment adds a comment to the query, wrapped by /* ... */.
+func (q *RefreshMaterializedViewQuery) Comment(comment string) *RefreshMaterializedViewQuery {
+	q.comment = comment
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *RefreshMaterializedViewQuery) Operation() string {
+	return "REFRESH MATERIALIZED VIEW"
+}
+
+func (q *RefreshMaterializedViewQuery) AppendQuery(
+	gen schema.QueryGen, b []byte,
+) (_ []byte, err error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if !gen.HasFeature(feature.MaterializedView) {
+		return nil, feature.NewNotSupportError(feature.MaterializedView)
+	}
+
+	b = appendComment(b, q.comment)
+
+	b = append(b, "REFRESH MATERIALIZED VIEW "...)
+	if q.concurrently {
+		b = append(b, "CONCURRENTLY "...)
+	}
+
+	b, err = q.appendFirstTable(gen, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+//------------------------------------------------------------------------------
+
+func (q *RefreshMaterializedViewQuery) Exec(ctx context.Context, dest ...any) (sql.Result, error) {
+	// if a comment is propagated via the context, use it
+	setCommentFromContext(ctx, q)
+
+	queryBytes, err := q.AppendQuery(q.db.gen, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	query := internal.String(queryBytes)
+
+	res, err := q.exec(ctx, q, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// String returns the generated SQL query string. The RefreshMaterializedViewQuery instance must not be
+// modified during query generation to ensure multiple calls to String() return identical results.
+func (q *RefreshMaterializedViewQuery) String() string {
+	buf, err := q.AppendQuery(q.db.QueryGen(), nil)
+	if err != nil {
+		panic(err)
+	}
+	return string(buf)
+}
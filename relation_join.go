@@ -18,10 +18,15 @@ type relationJoin struct {
 
 	additionalJoinOnConditions []schema.QueryWithArgs
 
-	apply   func(*SelectQuery) *SelectQuery
-	columns []schema.QueryWithArgs
+	apply     func(*SelectQuery) *SelectQuery
+	columns   []schema.QueryWithArgs
+	limit     int // the max number of rows per base model
+	batchSize int // the max number of base models per query
 }
 
+// rowNumberColumn is selected by has-many and m2m queries with a limit per base model.
+const rowNumberColumn = "_bun_row_number"
+
 func (j *relationJoin) applyTo(q *SelectQuery) {
 	if j.apply == nil {
 		return
@@ -41,31 +46,98 @@ func (j *relationJoin) applyTo(q *SelectQuery) {
 	j.columns, q.columns = q.columns, columns
 }
 
+// selectMany loads a has-many or m2m relation, selecting the rows of a batch of base models
+// per query. Nested relations are loaded once all the batches are scanned.
 func (j *relationJoin) selectMany(ctx context.Context, q *SelectQuery) error {
-	q = j.manyQuery(q)
-	if q == nil {
+	model := j.newManyModel()
+	if model == nil {
+		return nil
+	}
+
+	strcts := j.baseStructs()
+	batchSize := j.batchSize
+	if batchSize == 0 {
+		batchSize = q.db.relationBatchSize
+	}
+	if batchSize <= 0 {
+		batchSize = len(strcts)
+	}
+
+	var n int64
+	for len(strcts) > 0 {
+		batch := strcts[:min(batchSize, len(strcts))]
+		strcts = strcts[len(batch):]
+
+		batchQuery := q.db.NewSelect().Conn(q.conn).Model(model)
+		batchQuery.inheritScopes(&q.baseQuery)
+		if j.Relation.Type == schema.ManyToManyRelation {
+			batchQuery = j.m2mQuery(batchQuery, batch)
+		} else {
+			batchQuery = j.manyQuery(batchQuery, batch)
+		}
+
+		var dest []any
+		if j.limit > 0 {
+			batchQuery = j.limitPerBase(batchQuery)
+			dest = append(dest, model)
+		}
+		batchQuery.flags = batchQuery.flags.Set(skipJoinsFlag)
+
+		res, err := batchQuery.scanResult(ctx, dest...)
+		if err != nil {
+			return err
+		}
+
+		affected, _ := res.RowsAffected()
+		n += affected
+	}
+
+	if n == 0 {
 		return nil
 	}
-	return q.Scan(ctx)
+	return q.selectJoins(ctx, model.getJoins())
 }
 
-func (j *relationJoin) manyQuery(q *SelectQuery) *SelectQuery {
-	hasManyModel := newHasManyModel(j)
-	if hasManyModel == nil {
+func (j *relationJoin) newManyModel() TableModel {
+	if j.Relation.Type == schema.ManyToManyRelation {
+		if m := newM2MModel(j); m != nil {
+			return m
+		}
 		return nil
 	}
+	if m := newHasManyModel(j); m != nil {
+		return m
+	}
+	return nil
+}
 
-	q = q.Model(hasManyModel)
+// baseStructs returns the base models of the relation, skipping the ones with duplicate keys.
+func (j *relationJoin) baseStructs() []reflect.Value {
+	var strcts []reflect.Value
+	seen := make(map[internal.MapKey]struct{})
+	key := make([]any, 0, len(j.Relation.BasePKs))
+	walk(j.JoinModel.rootValue(), j.JoinModel.parentIndex(), func(v reflect.Value) {
+		key = modelKey(key[:0], v, j.Relation.BasePKs)
+		mapKey := internal.NewMapKey(key)
+		if _, ok := seen[mapKey]; ok {
+			return
+		}
+		seen[mapKey] = struct{}{}
+		strcts = append(strcts, v)
+	})
+	return strcts
+}
 
+func (j *relationJoin) manyQuery(q *SelectQuery, strcts []reflect.Value) *SelectQuery {
 	var where []byte
 
 	if q.db.HasFeature(feature.CompositeIn) {
-		return j.manyQueryCompositeIn(where, q)
+		return j.manyQueryCompositeIn(where, q, strcts)
 	}
-	return j.manyQueryMulti(where, q)
+	return j.manyQueryMulti(where, q, strcts)
 }
 
-func (j *relationJoin) manyQueryCompositeIn(where []byte, q *SelectQuery) *SelectQuery {
+func (j *relationJoin) manyQueryCompositeIn(where []byte, q *SelectQuery, strcts []reflect.Value) *SelectQuery {
 	if len(j.Relation.JoinPKs) > 1 {
 		where = append(where, '(')
 	}
@@ -74,13 +146,7 @@ func (j *relationJoin) manyQueryCompositeIn(where []byte, q *SelectQuery) *Selec
 		where = append(where, ')')
 	}
 	where = append(where, " IN ("...)
-	where = appendChildValues(
-		q.db.QueryGen(),
-		where,
-		j.JoinModel.rootValue(),
-		j.JoinModel.parentIndex(),
-		j.Relation.BasePKs,
-	)
+	where = appendChildValues(q.db.QueryGen(), where, strcts, j.Relation.BasePKs)
 	where = append(where, ")"...)
 	if len(j.additionalJoinOnConditions) > 0 {
 		where = append(where, " AND "...)
@@ -99,12 +165,11 @@ func (j *relationJoin) manyQueryCompositeIn(where []byte, q *SelectQuery) *Selec
 	return q
 }
 
-func (j *relationJoin) manyQueryMulti(where []byte, q *SelectQuery) *SelectQuery {
+func (j *relationJoin) manyQueryMulti(where []byte, q *SelectQuery, strcts []reflect.Value) *SelectQuery {
 	where = appendMultiValues(
 		q.db.QueryGen(),
 		where,
-		j.JoinModel.rootValue(),
-		j.JoinModel.parentIndex(),
+		strcts,
 		j.Relation.BasePKs,
 		j.Relation.JoinPKs,
 		j.JoinModel.Table().SQLAlias,
@@ -126,6 +191,47 @@ func (j *relationJoin) manyQueryMulti(where []byte, q *SelectQuery) *SelectQuery
 	return q
 }
 
+// limitPerBase wraps the query so it selects at most j.limit rows per base model
+// using the ROW_NUMBER window function:
+//
+//	SELECT * FROM (SELECT ..., ROW_NUMBER() OVER (PARTITION BY <base key> ORDER BY ...) AS _bun_row_number ...)
+//	WHERE _bun_row_number <= limit
+//
+// The order of the query applies to the rows of each base model.
+// The returned query has no model, because the model is already joined by the subquery.
+func (j *relationJoin) limitPerBase(q *SelectQuery) *SelectQuery {
+	joinTable := j.JoinModel.Table()
+
+	b := []byte("ROW_NUMBER() OVER (PARTITION BY ")
+	if j.Relation.Type == schema.ManyToManyRelation {
+		b = appendColumns(b, j.Relation.M2MTable.SQLAlias, j.Relation.M2MBasePKs)
+	} else {
+		b = appendColumns(b, joinTable.SQLAlias, j.Relation.JoinPKs)
+	}
+	if len(q.order) > 0 {
+		var err error
+		b, err = q.appendOrder(q.db.gen, b)
+		if err != nil {
+			q.setErr(err)
+			return q
+		}
+		q.order = nil
+	} else if len(joinTable.PKs) > 0 {
+		b = append(b, " ORDER BY "...)
+		b = appendColumns(b, joinTable.SQLAlias, joinTable.PKs)
+	}
+	b = append(b, ") AS "...)
+	b = q.db.gen.AppendIdent(b, rowNumberColumn)
+	q = q.ColumnExpr(internal.String(b))
+
+	return q.db.NewSelect().
+		Conn(q.conn).
+		TableExpr("(?) AS ?", q, Ident("_bun_limited")).
+		ColumnExpr("*").
+		Where("? <= ?", Ident(rowNumberColumn), j.limit).
+		OrderExpr("?", Ident(rowNumberColumn))
+}
+
 func (j *relationJoin) hasManyColumns(q *SelectQuery) *SelectQuery {
 	b := make([]byte, 0, 32)
 
@@ -162,25 +268,9 @@ func (j *relationJoin) hasManyColumns(q *SelectQuery) *SelectQuery {
 	return q
 }
 
-func (j *relationJoin) selectM2M(ctx context.Context, q *SelectQuery) error {
-	q = j.m2mQuery(q)
-	if q == nil {
-		return nil
-	}
-	return q.Scan(ctx)
-}
-
-func (j *relationJoin) m2mQuery(q *SelectQuery) *SelectQuery {
+func (j *relationJoin) m2mQuery(q *SelectQuery, strcts []reflect.Value) *SelectQuery {
 	gen := q.db.gen
 
-	m2mModel := newM2MModel(j)
-	if m2mModel == nil {
-		return nil
-	}
-	q = q.Model(m2mModel)
-
-	index := j.JoinModel.parentIndex()
-
 	if j.Relation.M2MTable != nil {
 		// We only need base pks to park joined models to the base model.
 		fields := j.Relation.M2MBasePKs
@@ -207,7 +297,7 @@ func (j *relationJoin) m2mQuery(q *SelectQuery) *SelectQuery {
 		join = append(join, col.SQLName...)
 	}
 	join = append(join, ") IN ("...)
-	join = appendChildValues(gen, join, j.BaseModel.rootValue(), index, j.Relation.BasePKs)
+	join = appendChildValues(gen, join, strcts, j.Relation.BasePKs)
 	join = append(join, ")"...)
 
 	if len(j.additionalJoinOnConditions) > 0 {
@@ -341,6 +431,23 @@ func (j *relationJoin) appendHasOneJoin(
 		b = j.appendSoftDelete(gen, b, q.flags)
 	}
 
+	if table := j.JoinModel.Table(); q.isTenantScoped(table) {
+		b = append(b, " AND "...)
+		b = j.appendAlias(gen, b)
+		b = append(b, '.')
+		b = append(b, table.TenantField.SQLName...)
+		b = append(b, " = "...)
+		b, err = q.appendTenantValue(gen, b, table)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if scopes := q.tableScopes(j.JoinModel.Table()); len(scopes) > 0 {
+		b = append(b, " AND "...)
+		b = appendScopes(gen, b, scopes, j.appendAlias(gen, nil))
+	}
+
 	if len(j.additionalJoinOnConditions) > 0 {
 		b = append(b, " AND "...)
 		b = appendAdditionalJoinOnConditions(gen, b, j.additionalJoinOnConditions)
@@ -350,12 +457,12 @@ func (j *relationJoin) appendHasOneJoin(
 }
 
 func appendChildValues(
-	gen schema.QueryGen, b []byte, v reflect.Value, index []int, fields []*schema.Field,
+	gen schema.QueryGen, b []byte, strcts []reflect.Value, fields []*schema.Field,
 ) []byte {
-	seen := make(map[string]struct{})
-	walk(v, index, func(v reflect.Value) {
-		start := len(b)
-
+	for i, v := range strcts {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
 		if len(fields) > 1 {
 			b = append(b, '(')
 		}
@@ -368,16 +475,6 @@ func appendChildValues(
 		if len(fields) > 1 {
 			b = append(b, ')')
 		}
-		b = append(b, ", "...)
-
-		if _, ok := seen[string(b[start:])]; ok {
-			b = b[:start]
-		} else {
-			seen[string(b[start:])] = struct{}{}
-		}
-	})
-	if len(seen) > 0 {
-		b = b[:len(b)-2] // trim ", "
 	}
 	return b
 }
@@ -385,7 +482,7 @@ func appendChildValues(
 // appendMultiValues is an alternative to appendChildValues that doesn't use the sql keyword ID
 // but instead uses old style ((k1=v1) AND (k2=v2)) OR (...) conditions.
 func appendMultiValues(
-	gen schema.QueryGen, b []byte, v reflect.Value, index []int, baseFields, joinFields []*schema.Field, joinTable schema.Safe,
+	gen schema.QueryGen, b []byte, strcts []reflect.Value, baseFields, joinFields []*schema.Field, joinTable schema.Safe,
 ) []byte {
 	// This is based on a mix of appendChildValues and query_base.appendColumns
 
@@ -394,11 +491,11 @@ func appendMultiValues(
 		panic("not reached")
 	}
 
-	// walk the relations
 	b = append(b, '(')
-	seen := make(map[string]struct{})
-	walk(v, index, func(v reflect.Value) {
-		start := len(b)
+	for i, v := range strcts {
+		if i > 0 {
+			b = append(b, ") OR ("...)
+		}
 		for i, f := range baseFields {
 			if i > 0 {
 				b = append(b, " AND "...)
@@ -418,17 +515,6 @@ func appendMultiValues(
 				b = append(b, ')')
 			}
 		}
-
-		b = append(b, ") OR ("...)
-
-		if _, ok := seen[string(b[start:])]; ok {
-			b = b[:start]
-		} else {
-			seen[string(b[start:])] = struct{}{}
-		}
-	})
-	if len(seen) > 0 {
-		b = b[:len(b)-6] // trim ") OR ("
 	}
 	b = append(b, ')')
 	return b
@@ -0,0 +1,9 @@
+package schema
+
+// Check is a CHECK constraint declared with the `check` tag option on a field or on bun.BaseModel.
+type Check struct {
+	Name string
+	Expr string
+
+	column string // column the constraint is declared on, empty for table constraints
+}
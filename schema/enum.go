@@ -0,0 +1,24 @@
+package schema
+
+import "reflect"
+
+// Enum is implemented by Go types that map to a database enumerated type,
+// e.g. PostgreSQL's CREATE TYPE ... AS ENUM. The name of the type is set
+// with the `type` tag option on the field.
+type Enum interface {
+	// EnumValues returns the values of the type in the order they are declared.
+	EnumValues() []string
+}
+
+var enumType = reflect.TypeFor[Enum]()
+
+// enumValues returns the values of the enumerated type, or nil if typ does not implement Enum.
+func enumValues(typ reflect.Type) []string {
+	switch {
+	case typ.Implements(enumType):
+		return reflect.Zero(typ).Interface().(Enum).EnumValues()
+	case reflect.PointerTo(typ).Implements(enumType):
+		return reflect.New(typ).Interface().(Enum).EnumValues()
+	}
+	return nil
+}
@@ -26,6 +26,9 @@ type Field struct {
 	UserSQLType        string
 	CreateTableSQLType string
 	SQLDefault         string
+	Comment            string
+	EnumValues         []string // values of the enumerated type, see Enum
+	Generated          string   // expression of a generated column
 
 	OnDelete string
 	OnUpdate string
@@ -35,6 +38,7 @@ type Field struct {
 	NullZero      bool
 	AutoIncrement bool
 	Identity      bool
+	Virtual       bool // generated column is computed when read rather than stored
 
 	Append AppenderFunc
 	Scan   ScannerFunc
@@ -143,6 +147,8 @@ func (f *Field) ScanWithCheck(fv reflect.Value, src any) error {
 	return f.Scan(fv, src)
 }
 
+// SkipUpdate reports whether the field must not be written by UPDATE queries.
+// Generated columns are always skipped, because their values are computed by the database.
 func (f *Field) SkipUpdate() bool {
-	return f.Tag.HasOption("skipupdate")
+	return f.Generated != "" || f.Tag.HasOption("skipupdate")
 }
@@ -0,0 +1,14 @@
+package schema
+
+// Index describes a secondary index defined on the table's columns.
+type Index struct {
+	Name    string
+	Columns []*Field
+
+	// Method is the index access method, e.g. gin. Empty means the dialect's default.
+	Method string
+	// Where is the predicate of a partial index.
+	Where string
+	// Include lists non-key columns stored in the index.
+	Include []*Field
+}
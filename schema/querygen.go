@@ -19,6 +19,11 @@ var nopQueryGen = QueryGen{
 type QueryGen struct {
 	dialect Dialect
 	args    *namedArgList
+	tenant  *tenant
+}
+
+type tenant struct {
+	value any
 }
 
 func NewQueryGen(dialect Dialect) QueryGen {
@@ -107,6 +112,7 @@ func (f QueryGen) WithArg(arg NamedArgAppender) QueryGen {
 	return QueryGen{
 		dialect: f.dialect,
 		args:    f.args.WithArg(arg),
+		tenant:  f.tenant,
 	}
 }
 
@@ -114,7 +120,23 @@ func (f QueryGen) WithNamedArg(name string, value any) QueryGen {
 	return QueryGen{
 		dialect: f.dialect,
 		args:    f.args.WithArg(&namedArg{name: name, value: value}),
+		tenant:  f.tenant,
+	}
+}
+
+// WithTenant returns a copy of the generator which scopes the tables
+// with a tenant field, including the ones in subqueries, to the tenant.
+func (f QueryGen) WithTenant(value any) QueryGen {
+	f.tenant = &tenant{value: value}
+	return f
+}
+
+// Tenant returns the tenant set with WithTenant.
+func (f QueryGen) Tenant() (any, bool) {
+	if f.tenant == nil {
+		return nil, false
 	}
+	return f.tenant.value, true
 }
 
 func (f QueryGen) FormatQuery(query string, args ...any) string {
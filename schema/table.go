@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -52,6 +53,11 @@ type Table struct {
 	SQLNameForSelects Safe
 	Alias             string
 	SQLAlias          Safe
+	Comment           string
+
+	// IsView is true if the model is mapped to a view, which makes it read-only.
+	IsView         bool
+	IsMaterialized bool // If true, the view is a materialized view.
 
 	allFields  []*Field // all fields including scanonly
 	Fields     []*Field // PKs + DataFields
@@ -65,10 +71,15 @@ type Table struct {
 	IsM2MTable bool // If true, this table is the "junction table" of an m2m relation.
 	Relations  map[string]*Relation
 	Unique     map[string][]*Field
+	Indexes    []*Index
+	Checks     []*Check
 
 	SoftDeleteField       *Field
 	UpdateSoftDeleteField func(fv reflect.Value, tm time.Time) error
 
+	VersionField *Field // integer field used for optimistic locking
+	TenantField  *Field // field with the tenant which scopes the queries, see bun.WithTenantFromContext
+
 	flags internal.Flag
 }
 
@@ -93,6 +104,8 @@ func (table *Table) init(dialect Dialect, typ reflect.Type) {
 	table.Fields = make([]*Field, 0, typ.NumField())
 	table.FieldMap = make(map[string]*Field, typ.NumField())
 	table.processFields(typ)
+	table.initIndexes()
+	table.initChecks()
 
 	hooks := []struct {
 		typ  reflect.Type
@@ -275,6 +288,8 @@ func (t *Table) processFields(typ reflect.Type) {
 		if v, ok := subfield.Tag.Options["unique"]; ok {
 			t.addUnique(subfield, embfield.prefix, v)
 		}
+		t.addIndex(subfield, embfield.prefix)
+		t.addChecks(subfield.Name, subfield.Tag.Options["check"])
 	}
 
 	if len(ebdStructs) > 0 && t.StructMap == nil {
@@ -311,17 +326,7 @@ func sortFieldsByStruct(fields []*Field) {
 }
 
 func (t *Table) addUnique(field *Field, prefix string, tagOptions []string) {
-	var names []string
-	if len(tagOptions) == 1 {
-		// Split the value by comma, this will allow multiple names to be specified.
-		// We can use this to create multiple named unique constraints where a single column
-		// might be included in multiple constraints.
-		names = strings.Split(tagOptions[0], ",")
-	} else {
-		names = tagOptions
-	}
-
-	for _, uname := range names {
+	for _, uname := range tagNames(tagOptions) {
 		if t.Unique == nil {
 			t.Unique = make(map[string][]*Field)
 		}
@@ -332,6 +337,114 @@ func (t *Table) addUnique(field *Field, prefix string, tagOptions []string) {
 	}
 }
 
+// tagNames returns the names listed in the tag option.
+func tagNames(tagOptions []string) []string {
+	if len(tagOptions) == 1 {
+		// Split the value by comma, this will allow multiple names to be specified.
+		// We can use this to create multiple named constraints or indexes where a single column
+		// might be included in multiple of them.
+		return strings.Split(tagOptions[0], ",")
+	}
+	return tagOptions
+}
+
+// addIndex adds the field to the indexes listed in its index and index_include tag options.
+// Fields which share the index name form a multi-column index. An index without a name
+// is created for the field alone and is named by initIndexes.
+func (t *Table) addIndex(field *Field, prefix string) {
+	if v, ok := field.Tag.Options["index"]; ok {
+		for _, name := range tagNames(v) {
+			idx := t.index(field, prefix, name)
+			idx.Columns = append(idx.Columns, field)
+		}
+	}
+	if v, ok := field.Tag.Options["index_include"]; ok {
+		for _, name := range tagNames(v) {
+			if name == "" {
+				panic(fmt.Errorf("bun: %s.%s: index_include requires an index name", t.TypeName, field.GoName))
+			}
+			idx := t.index(field, prefix, name)
+			idx.Include = append(idx.Include, field)
+		}
+	}
+}
+
+// index finds or creates the index and applies the index options set on the field.
+func (t *Table) index(field *Field, prefix, name string) *Index {
+	var idx *Index
+	if name != "" {
+		name = prefix + name
+		for _, other := range t.Indexes {
+			if other.Name == name {
+				idx = other
+				break
+			}
+		}
+	}
+	if idx == nil {
+		idx = &Index{Name: name}
+		t.Indexes = append(t.Indexes, idx)
+	}
+
+	if s, ok := field.Tag.Option("index_method"); ok {
+		if idx.Method != "" && idx.Method != s {
+			panic(fmt.Errorf("bun: %s.%s: index %q has conflicting index_method %q and %q", t.TypeName, field.GoName, name, idx.Method, s))
+		}
+		idx.Method = s
+	}
+	if s, ok := field.Tag.Option("index_where"); ok {
+		if idx.Where != "" && idx.Where != s {
+			panic(fmt.Errorf("bun: %s.%s: index %q has conflicting index_where %q and %q", t.TypeName, field.GoName, name, idx.Where, s))
+		}
+		idx.Where = s
+	}
+	return idx
+}
+
+// initIndexes names the indexes declared without a name after the table and the column,
+// following PostgreSQL's convention: <table>_<column>_idx.
+func (t *Table) initIndexes() {
+	tableName := strings.TrimPrefix(t.Name, t.Schema+".")
+	for _, idx := range t.Indexes {
+		if len(idx.Columns) == 0 {
+			panic(fmt.Errorf("bun: %s: index %q does not have any columns", t.TypeName, idx.Name))
+		}
+		if idx.Name == "" {
+			idx.Name = tableName + "_" + idx.Columns[0].Name + "_idx"
+		}
+	}
+}
+
+// addChecks adds CHECK constraints declared for the column, or for the table if the column is empty.
+func (t *Table) addChecks(column string, exprs []string) {
+	for _, expr := range exprs {
+		if expr == "" {
+			panic(fmt.Errorf("bun: %s: check requires an expression", t.TypeName))
+		}
+		t.Checks = append(t.Checks, &Check{Expr: expr, column: column})
+	}
+}
+
+// initChecks names CHECK constraints following PostgreSQL's convention:
+// <table>_<column>_check for column constraints and <table>_check for table constraints,
+// with a number appended to the name if it is already taken, e.g. <table>_check1.
+func (t *Table) initChecks() {
+	tableName := strings.TrimPrefix(t.Name, t.Schema+".")
+	seen := make(map[string]int)
+	for _, check := range t.Checks {
+		name := tableName + "_check"
+		if check.column != "" {
+			name = tableName + "_" + check.column + "_check"
+		}
+		if n := seen[name]; n > 0 {
+			check.Name = name + strconv.Itoa(n)
+		} else {
+			check.Name = name
+		}
+		seen[name]++
+	}
+}
+
 func (t *Table) setName(name string) {
 	t.Name = name
 	t.SQLName = t.quoteIdent(name)
@@ -382,6 +495,18 @@ func (t *Table) addField(field *Field) {
 		t.UpdateSoftDeleteField = softDeleteFieldUpdater(field)
 	}
 
+	if field.Tag.HasOption("version") {
+		t.setVersionField(field)
+	}
+
+	if field.Tag.HasOption("tenant") {
+		if t.TenantField != nil {
+			panic(fmt.Errorf("bun: %s has multiple tenant fields: %s and %s",
+				t.TypeName, t.TenantField.GoName, field.GoName))
+		}
+		t.TenantField = field
+	}
+
 	t.Fields = append(t.Fields, field)
 	if field.IsPK {
 		t.PKs = append(t.PKs, field)
@@ -471,6 +596,16 @@ func (t *Table) processBaseModelField(f reflect.StructField) {
 		t.setName(s)
 	}
 
+	if s, ok := tag.Option("view"); ok {
+		schema, _ := t.schemaFromTagName(s)
+		t.Schema = schema
+		t.setName(s)
+		t.IsView = true
+		t.IsMaterialized = tag.HasOption("materialized")
+	} else if tag.HasOption("materialized") {
+		internal.Warn.Printf("%s.%s: materialized option requires view:name", t.TypeName, f.Name)
+	}
+
 	if s, ok := tag.Option("select"); ok {
 		t.SQLNameForSelects = t.quoteTableName(s)
 	}
@@ -479,6 +614,12 @@ func (t *Table) processBaseModelField(f reflect.StructField) {
 		t.Alias = s
 		t.SQLAlias = t.quoteIdent(s)
 	}
+
+	if s, ok := tag.Option("comment"); ok {
+		t.Comment = s
+	}
+
+	t.addChecks("", tag.Options["check"])
 }
 
 // schemaFromTagName splits the bun.BaseModel tag name into schema and table name
@@ -547,11 +688,21 @@ func (t *Table) newField(sf reflect.StructField, tag tagparser.Tag) *Field {
 	if v, ok := tag.Options["unique"]; ok {
 		t.addUnique(field, "", v)
 	}
+	t.addIndex(field, "")
+	t.addChecks(field.Name, tag.Options["check"])
 	if s, ok := tag.Option("default"); ok {
 		field.SQLDefault = s
 	}
+	if s, ok := tag.Option("comment"); ok {
+		field.Comment = s
+	}
+	if s, ok := tag.Option("generated"); ok {
+		field.Generated = s
+		field.Virtual = tag.HasOption("virtual")
+	}
 	if s, ok := field.Tag.Option("type"); ok {
 		field.UserSQLType = s
+		field.EnumValues = enumValues(field.IndirectType)
 	}
 	field.DiscoveredSQLType = DiscoverSQLType(field.IndirectType)
 	field.Append = FieldAppender(t.dialect, field)
@@ -985,7 +1136,7 @@ func (t *Table) quoteIdent(s string) Safe {
 
 func isKnownTableOption(name string) bool {
 	switch name {
-	case "table", "alias", "select":
+	case "table", "view", "materialized", "alias", "select", "check", "comment":
 		return true
 	}
 	return false
@@ -1006,7 +1157,17 @@ func isKnownFieldOption(name string) bool {
 		"nullzero",
 		"default",
 		"unique",
+		"index",
+		"index_method",
+		"index_where",
+		"index_include",
+		"check",
+		"comment",
+		"generated",
+		"virtual",
 		"soft_delete",
+		"version",
+		"tenant",
 		"scanonly",
 		"skipupdate",
 
@@ -1059,6 +1220,21 @@ func parseRelationJoin(join []string) ([]string, []string) {
 
 //------------------------------------------------------------------------------
 
+func (t *Table) setVersionField(field *Field) {
+	if t.VersionField != nil {
+		panic(fmt.Errorf("bun: %s has multiple version fields: %s and %s",
+			t.TypeName, t.VersionField.GoName, field.GoName))
+	}
+	switch field.StructField.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		t.VersionField = field
+	default:
+		panic(fmt.Errorf("bun: %s.%s: version field must be an integer, got %s",
+			t.TypeName, field.GoName, field.StructField.Type))
+	}
+}
+
 func softDeleteFieldUpdater(field *Field) func(fv reflect.Value, tm time.Time) error {
 	typ := field.StructField.Type
 
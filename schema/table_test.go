@@ -335,4 +335,172 @@ func TestTable(t *testing.T) {
 		require.True(t, counter.AutoIncrement, "autoincrement")
 		require.True(t, counter.NotNull, "not null")
 	})
+	t.Run("index", func(t *testing.T) {
+		type Account struct {
+			ID        int64    `bun:",pk"`
+			Email     string   `bun:",index"`
+			TenantID  int64    `bun:",index:accounts_tenant_name_idx,index_where:deleted_at IS NULL"`
+			Name      string   `bun:",index:accounts_tenant_name_idx,index_include:accounts_tags_idx"`
+			Tags      []string `bun:",array,index:accounts_tags_idx,index_method:gin"`
+			DeletedAt string
+		}
+
+		table := tables.Get(reflect.TypeFor[*Account]())
+		require.Len(t, table.Indexes, 3)
+
+		email := table.Indexes[0]
+		require.Equal(t, "accounts_email_idx", email.Name)
+		require.Equal(t, []*Field{table.FieldMap["email"]}, email.Columns)
+
+		tenantName := table.Indexes[1]
+		require.Equal(t, "accounts_tenant_name_idx", tenantName.Name)
+		require.Equal(t, []*Field{table.FieldMap["tenant_id"], table.FieldMap["name"]}, tenantName.Columns)
+		require.Equal(t, "deleted_at IS NULL", tenantName.Where)
+
+		tags := table.Indexes[2]
+		require.Equal(t, "accounts_tags_idx", tags.Name)
+		require.Equal(t, "gin", tags.Method)
+		require.Equal(t, []*Field{table.FieldMap["tags"]}, tags.Columns)
+		require.Equal(t, []*Field{table.FieldMap["name"]}, tags.Include)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		type Product struct {
+			BaseModel `bun:"table:products,check:price <= list_price"`
+			ID        int64 `bun:",pk"`
+			Price     int64 `bun:",check:price > 0"`
+			ListPrice int64
+			Discount  int64 `bun:",check:discount >= 0,check:discount < 100"`
+		}
+
+		table := tables.Get(reflect.TypeFor[*Product]())
+		require.Len(t, table.Checks, 4)
+
+		require.Equal(t, "products_check", table.Checks[0].Name)
+		require.Equal(t, "price <= list_price", table.Checks[0].Expr)
+
+		require.Equal(t, "products_price_check", table.Checks[1].Name)
+		require.Equal(t, "price > 0", table.Checks[1].Expr)
+
+		require.Equal(t, "products_discount_check", table.Checks[2].Name)
+		require.Equal(t, "discount >= 0", table.Checks[2].Expr)
+		require.Equal(t, "products_discount_check1", table.Checks[3].Name)
+		require.Equal(t, "discount < 100", table.Checks[3].Expr)
+	})
+
+	t.Run("comment", func(t *testing.T) {
+		type Product struct {
+			BaseModel `bun:"table:products,comment:\"Goods, for sale\""`
+			ID        int64  `bun:",pk"`
+			Name      string `bun:",comment:Display name"`
+		}
+
+		table := tables.Get(reflect.TypeFor[*Product]())
+		require.Equal(t, "Goods, for sale", table.Comment)
+		require.Equal(t, "Display name", table.FieldMap["name"].Comment)
+		require.Empty(t, table.FieldMap["id"].Comment)
+	})
+
+	t.Run("view", func(t *testing.T) {
+		type ActiveUser struct {
+			BaseModel `bun:"view:active_users,alias:u"`
+			ID        int64
+		}
+
+		type UserStats struct {
+			BaseModel `bun:"view:stats.user_stats,materialized"`
+			ID        int64
+		}
+
+		table := tables.Get(reflect.TypeFor[*ActiveUser]())
+		require.Equal(t, "active_users", table.Name)
+		require.Equal(t, Safe(`"active_users"`), table.SQLNameForSelects)
+		require.True(t, table.IsView)
+		require.False(t, table.IsMaterialized)
+
+		table = tables.Get(reflect.TypeFor[*UserStats]())
+		require.Equal(t, "stats", table.Schema)
+		require.True(t, table.IsView)
+		require.True(t, table.IsMaterialized)
+	})
+
+	t.Run("version", func(t *testing.T) {
+		type Document struct {
+			ID      int64 `bun:",pk"`
+			Version int32 `bun:",version"`
+		}
+
+		table := tables.Get(reflect.TypeFor[*Document]())
+		require.Equal(t, table.FieldMap["version"], table.VersionField)
+
+		type InvalidVersion struct {
+			ID      int64  `bun:",pk"`
+			Version string `bun:",version"`
+		}
+
+		require.PanicsWithError(t, "bun: InvalidVersion.Version: version field must be an integer, got string", func() {
+			tables.Get(reflect.TypeFor[*InvalidVersion]())
+		})
+	})
+
+	t.Run("enum", func(t *testing.T) {
+		type Order struct {
+			ID      int64          `bun:",pk"`
+			Status  orderStatus    `bun:"type:order_status"`
+			Payment *paymentMethod `bun:"type:payment_method"`
+			Note    orderStatus    // not an enumerated type without the type option
+		}
+
+		table := tables.Get(reflect.TypeFor[*Order]())
+		require.Equal(t, []string{"pending", "shipped"}, table.FieldMap["status"].EnumValues)
+		require.Equal(t, []string{"card", "cash"}, table.FieldMap["payment"].EnumValues)
+		require.Nil(t, table.FieldMap["note"].EnumValues)
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		type Item struct {
+			ID       int64 `bun:",pk"`
+			Price    int64
+			Qty      int64
+			Total    int64  `bun:",generated:(price * qty)"`
+			Label    string `bun:",generated:\"'#' || id\",virtual"`
+			Verified bool   `bun:",skipupdate"`
+		}
+
+		table := tables.Get(reflect.TypeFor[*Item]())
+		require.Equal(t, "(price * qty)", table.FieldMap["total"].Generated)
+		require.False(t, table.FieldMap["total"].Virtual)
+		require.Equal(t, "'#' || id", table.FieldMap["label"].Generated)
+		require.True(t, table.FieldMap["label"].Virtual)
+
+		require.True(t, table.FieldMap["total"].SkipUpdate())
+		require.True(t, table.FieldMap["verified"].SkipUpdate())
+		require.False(t, table.FieldMap["price"].SkipUpdate())
+	})
+
+	t.Run("embedWithIndex", func(t *testing.T) {
+		type Audit struct {
+			CreatedBy string `bun:",index"`
+			UpdatedBy string `bun:",index:by"`
+		}
+
+		type Document struct {
+			ID    int64 `bun:",pk"`
+			Audit Audit `bun:"embed:audit_"`
+		}
+
+		table := tables.Get(reflect.TypeFor[*Document]())
+		require.Len(t, table.Indexes, 2)
+		require.Equal(t, "documents_audit_created_by_idx", table.Indexes[0].Name)
+		require.Equal(t, "audit_by", table.Indexes[1].Name)
+		require.Equal(t, "audit_updated_by", table.Indexes[1].Columns[0].Name)
+	})
 }
+
+type orderStatus string
+
+func (orderStatus) EnumValues() []string { return []string{"pending", "shipped"} }
+
+type paymentMethod string
+
+func (*paymentMethod) EnumValues() []string { return []string{"card", "cash"} }
@@ -25,6 +25,11 @@ func NewTables(dialect Dialect) *Tables {
 	}
 }
 
+// Dialect returns the dialect used to create tables.
+func (t *Tables) Dialect() Dialect {
+	return t.dialect
+}
+
 func (t *Tables) Register(models ...any) {
 	for _, model := range models {
 		_ = t.Get(reflect.TypeOf(model).Elem())
@@ -0,0 +1,117 @@
+package bun
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// Scope is a named default filter of a model, e.g. published = TRUE. Like soft deletes,
+// scopes are applied to SelectQuery, UpdateQuery, DeleteQuery and relation joins of the model,
+// unless the query removes them with Unscoped.
+//
+// The condition refers to the table of the model with ?TableAlias:
+//
+//	bun.NewScope("published", "?TableAlias.published = ?", true)
+type Scope struct {
+	Name  string
+	Query string
+	Args  []any
+}
+
+// NewScope returns the named scope with the condition formatted from query and args.
+func NewScope(name, query string, args ...any) Scope {
+	return Scope{Name: name, Query: query, Args: args}
+}
+
+// DefaultScoper is implemented by models which declare their default scopes.
+// Scopes registered with DB.RegisterScope are applied in addition to them.
+type DefaultScoper interface {
+	DefaultScopes() []Scope
+}
+
+// RegisterScope registers the named default scope of the model, replacing the scope
+// with the same name. It is usually called once when the application starts.
+func (db *DB) RegisterScope(model any, name string, query string, args ...any) {
+	table := db.Table(reflect.TypeOf(model))
+	db.scopes.register(table, NewScope(name, query, args...))
+}
+
+type scopeRegistry struct {
+	mu     sync.RWMutex
+	scopes map[*schema.Table][]Scope
+}
+
+func (r *scopeRegistry) register(table *schema.Table, scope Scope) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.scopes == nil {
+		r.scopes = make(map[*schema.Table][]Scope)
+	}
+
+	scopes := slices.DeleteFunc(slices.Clone(r.scopes[table]), func(other Scope) bool {
+		return other.Name == scope.Name
+	})
+	r.scopes[table] = append(scopes, scope)
+}
+
+func (r *scopeRegistry) get(table *schema.Table) []Scope {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.scopes[table]
+}
+
+//------------------------------------------------------------------------------
+
+// unscope removes the named scopes from the query or all scopes if names are empty.
+func (q *baseQuery) unscope(names []string) {
+	if len(names) == 0 {
+		q.flags = q.flags.Set(unscopedFlag)
+		return
+	}
+	q.unscoped = append(q.unscoped, names...)
+}
+
+// tableScopes returns the scopes of the table which apply to the query.
+func (q *baseQuery) tableScopes(table *schema.Table) []Scope {
+	if table == nil || q.flags.Has(unscopedFlag) {
+		return nil
+	}
+
+	var scopes []Scope
+	if scoper, ok := table.ZeroIface.(DefaultScoper); ok {
+		scopes = append(scopes, scoper.DefaultScopes()...)
+	}
+	scopes = append(scopes, q.db.scopes.get(table)...)
+
+	if len(q.unscoped) > 0 {
+		scopes = slices.DeleteFunc(scopes, func(scope Scope) bool {
+			return slices.Contains(q.unscoped, scope.Name)
+		})
+	}
+	return scopes
+}
+
+// appendScopes appends the conditions of the scopes joined with AND,
+// replacing ?TableAlias with the alias.
+func appendScopes(gen schema.QueryGen, b []byte, scopes []Scope, alias []byte) []byte {
+	gen = gen.WithNamedArg("TableAlias", schema.Safe(alias))
+	for i, scope := range scopes {
+		if i > 0 {
+			b = append(b, " AND "...)
+		}
+		b = append(b, '(')
+		b = gen.AppendQuery(b, scope.Query, scope.Args...)
+		b = append(b, ')')
+	}
+	return b
+}
+
+// inheritScopes makes the relation query keep the scopes removed from the base query.
+func (q *baseQuery) inheritScopes(base *baseQuery) {
+	q.flags = q.flags.Set(base.flags & (unscopedFlag | withoutTenantScopeFlag))
+	q.unscoped = append(q.unscoped, base.unscoped...)
+}
@@ -0,0 +1,88 @@
+package bun
+
+import (
+	"context"
+	"sync"
+)
+
+// txCallbacks holds the callbacks registered in a transaction or a savepoint.
+// The callbacks of a savepoint are moved to the parent when the savepoint is released
+// and discarded when it is rolled back, so only the outermost transaction runs them.
+type txCallbacks struct {
+	mu         sync.Mutex
+	parent     *txCallbacks
+	onCommit   []func(ctx context.Context)
+	onRollback []func(ctx context.Context)
+}
+
+func newTxCallbacks(parent *txCallbacks) *txCallbacks {
+	return &txCallbacks{parent: parent}
+}
+
+func (c *txCallbacks) addOnCommit(fn func(ctx context.Context)) {
+	c.mu.Lock()
+	c.onCommit = append(c.onCommit, fn)
+	c.mu.Unlock()
+}
+
+func (c *txCallbacks) addOnRollback(fn func(ctx context.Context)) {
+	c.mu.Lock()
+	c.onRollback = append(c.onRollback, fn)
+	c.mu.Unlock()
+}
+
+// take removes and returns the registered callbacks.
+func (c *txCallbacks) take() (onCommit, onRollback []func(ctx context.Context)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	onCommit, onRollback = c.onCommit, c.onRollback
+	c.onCommit, c.onRollback = nil, nil
+	return onCommit, onRollback
+}
+
+// release moves the callbacks of the savepoint to the parent transaction.
+func (c *txCallbacks) release() {
+	onCommit, onRollback := c.take()
+
+	c.parent.mu.Lock()
+	c.parent.onCommit = append(c.parent.onCommit, onCommit...)
+	c.parent.onRollback = append(c.parent.onRollback, onRollback...)
+	c.parent.mu.Unlock()
+}
+
+// discard drops the callbacks of the rolled back savepoint.
+func (c *txCallbacks) discard() {
+	_, _ = c.take()
+}
+
+// committed runs the commit callbacks of the transaction.
+func (c *txCallbacks) committed(ctx context.Context) {
+	onCommit, _ := c.take()
+	for _, fn := range onCommit {
+		fn(ctx)
+	}
+}
+
+// rolledBack runs the rollback callbacks of the transaction.
+func (c *txCallbacks) rolledBack(ctx context.Context) {
+	_, onRollback := c.take()
+	for _, fn := range onRollback {
+		fn(ctx)
+	}
+}
+
+// OnCommit registers the function to run after the transaction commits, e.g. to publish
+// events or invalidate caches. Functions registered in a savepoint run only if the savepoint
+// is released and the outermost transaction commits; they are discarded if the savepoint
+// is rolled back. Functions run in the order they were registered.
+func (tx Tx) OnCommit(fn func(ctx context.Context)) {
+	tx.callbacks.addOnCommit(fn)
+}
+
+// OnRollback registers the function to run after the transaction is rolled back
+// or fails to commit. Like OnCommit, functions registered in a savepoint are moved
+// to the parent when the savepoint is released and discarded when it is rolled back.
+func (tx Tx) OnRollback(fn func(ctx context.Context)) {
+	tx.callbacks.addOnRollback(fn)
+}
@@ -0,0 +1,139 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// TxRetryOption configures RunInTxWithRetry.
+type TxRetryOption func(r *txRetry)
+
+// WithTxMaxAttempts sets the max number of times the transaction runs, 3 by default.
+func WithTxMaxAttempts(n int) TxRetryOption {
+	return func(r *txRetry) {
+		r.maxAttempts = n
+	}
+}
+
+// WithTxBackoff sets the function which returns the delay before the retry of the
+// attempt, which starts from 1. By default, the delay grows exponentially from 10ms
+// up to 1s, with jitter.
+func WithTxBackoff(fn func(attempt int) time.Duration) TxRetryOption {
+	return func(r *txRetry) {
+		r.backoff = fn
+	}
+}
+
+// WithTxRetryable sets the function which reports whether the transaction which
+// failed with the error can be retried, IsRetryableTxError by default.
+//
+// For example, to retry MySQL deadlocks with github.com/go-sql-driver/mysql:
+//
+//	bun.WithTxRetryable(func(err error) bool {
+//		var mysqlErr *mysql.MySQLError
+//		return (errors.As(err, &mysqlErr) && mysqlErr.Number == 1213) || bun.IsRetryableTxError(err)
+//	})
+func WithTxRetryable(fn func(err error) bool) TxRetryOption {
+	return func(r *txRetry) {
+		r.retryable = fn
+	}
+}
+
+// IsRetryableTxError reports whether the error is a serialization failure or a deadlock,
+// i.e. SQLSTATE 40001 or 40P01. It recognizes the errors which implement SQLState() string,
+// e.g. pgdriver.Error and pgconn.PgError, and the errors which implement
+// SerializationFailure() bool or DeadlockDetected() bool.
+func IsRetryableTxError(err error) bool {
+	var sqlState interface{ SQLState() string }
+	if errors.As(err, &sqlState) {
+		switch sqlState.SQLState() {
+		case "40001", "40P01":
+			return true
+		}
+	}
+
+	var serialization interface{ SerializationFailure() bool }
+	if errors.As(err, &serialization) && serialization.SerializationFailure() {
+		return true
+	}
+
+	var deadlock interface{ DeadlockDetected() bool }
+	return errors.As(err, &deadlock) && deadlock.DeadlockDetected()
+}
+
+type txRetry struct {
+	maxAttempts int
+	backoff     func(attempt int) time.Duration
+	retryable   func(err error) bool
+}
+
+func newTxRetry(opts []TxRetryOption) *txRetry {
+	r := &txRetry{
+		maxAttempts: 3,
+		backoff:     defaultTxBackoff,
+		retryable:   IsRetryableTxError,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func defaultTxBackoff(attempt int) time.Duration {
+	const minBackoff, maxBackoff = 10 * time.Millisecond, time.Second
+
+	d := minBackoff << min(attempt-1, 10)
+	d = min(d, maxBackoff)
+	// The jitter spreads the retries of the transactions which conflicted.
+	return d/2 + rand.N(d/2+1)
+}
+
+// run calls fn until it succeeds, fails with an error which is not retryable,
+// or runs out of attempts. It returns the last error.
+func (r *txRetry) run(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= r.maxAttempts || !r.retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(r.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// RunInTxWithRetry runs the function in a transaction like RunInTx and runs it again
+// in a new transaction if the transaction fails with a retryable error, e.g. because
+// of a serialization failure with the SERIALIZABLE isolation level. The function must
+// be safe to run several times.
+func (db *DB) RunInTxWithRetry(
+	ctx context.Context,
+	opts *sql.TxOptions,
+	fn func(ctx context.Context, tx Tx) error,
+	retryOpts ...TxRetryOption,
+) error {
+	return newTxRetry(retryOpts).run(ctx, func() error {
+		return db.RunInTx(ctx, opts, fn)
+	})
+}
+
+// RunInTxWithRetry runs the function in a transaction on this connection like RunInTx
+// and runs it again if the transaction fails with a retryable error, see DB.RunInTxWithRetry.
+func (c Conn) RunInTxWithRetry(
+	ctx context.Context,
+	opts *sql.TxOptions,
+	fn func(ctx context.Context, tx Tx) error,
+	retryOpts ...TxRetryOption,
+) error {
+	return newTxRetry(retryOpts).run(ctx, func() error {
+		return c.RunInTx(ctx, opts, fn)
+	})
+}
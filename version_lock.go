@@ -0,0 +1,125 @@
+package bun
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// ErrStaleObject is returned by UpdateQuery and DeleteQuery when the model has a version field
+// and no row matches the version of the model, i.e. the row was modified or deleted concurrently.
+// Bulk updates return it if any of the rows is stale, after the other rows have been updated,
+// so they should run in a transaction.
+var ErrStaleObject = errors.New("bun: stale object: the row was modified or deleted concurrently")
+
+// versionLock remembers the versions of the rows affected by a query with optimistic locking.
+type versionLock struct {
+	field    *schema.Field
+	strcts   []reflect.Value
+	versions []int64
+}
+
+func newVersionLock(field *schema.Field, strcts ...reflect.Value) *versionLock {
+	lock := &versionLock{
+		field:    field,
+		strcts:   strcts,
+		versions: make([]int64, len(strcts)),
+	}
+	for i, strct := range strcts {
+		fv := field.Value(strct)
+		if fv.CanInt() {
+			lock.versions[i] = fv.Int()
+		} else {
+			lock.versions[i] = int64(fv.Uint())
+		}
+	}
+	return lock
+}
+
+// check returns ErrStaleObject if the query has not affected every row.
+func (l *versionLock) check(res sql.Result, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrStaleObject
+	}
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n < int64(len(l.strcts)) {
+		return ErrStaleObject
+	}
+	return nil
+}
+
+// increment sets the version of each model to the one stored in the database.
+// It does not depend on the current value, which may have been scanned from RETURNING.
+func (l *versionLock) increment() {
+	for i, strct := range l.strcts {
+		fv := l.field.Value(strct)
+		if fv.CanInt() {
+			fv.SetInt(l.versions[i] + 1)
+		} else {
+			fv.SetUint(uint64(l.versions[i]) + 1)
+		}
+	}
+}
+
+// structVersionLock returns a versionLock if the query targets a single struct
+// whose table has a version field.
+func (q *baseQuery) structVersionLock() *versionLock {
+	if q.table == nil || q.table.VersionField == nil {
+		return nil
+	}
+	model, ok := q.tableModel.(*structTableModel)
+	if !ok || !model.strct.IsValid() {
+		return nil
+	}
+	return newVersionLock(q.table.VersionField, model.strct)
+}
+
+// sliceVersionLock returns a versionLock for every element of a slice model
+// whose table has a version field.
+func (q *baseQuery) sliceVersionLock() *versionLock {
+	if q.table == nil || q.table.VersionField == nil {
+		return nil
+	}
+	model, ok := q.tableModel.(*sliceTableModel)
+	if !ok {
+		return nil
+	}
+	strcts := make([]reflect.Value, model.slice.Len())
+	for i := range strcts {
+		strcts[i] = indirect(model.slice.Index(i))
+	}
+	return newVersionLock(q.table.VersionField, strcts...)
+}
+
+// appendVersionWhere appends the predicate which matches the version of the struct model.
+func (q *whereBaseQuery) appendVersionWhere(
+	gen schema.QueryGen, b []byte, withAlias bool,
+) ([]byte, error) {
+	model, ok := q.tableModel.(*structTableModel)
+	if !ok || !model.strct.IsValid() || q.table.VersionField == nil {
+		return b, nil
+	}
+	b = append(b, " AND "...)
+	return q.appendWhereStructFields(gen, b, model, []*schema.Field{q.table.VersionField}, withAlias)
+}
+
+// appendVersionIncrement appends the assignment which increments the version.
+func appendVersionIncrement(b []byte, field *schema.Field, table schema.Safe) []byte {
+	b = append(b, field.SQLName...)
+	b = append(b, " = "...)
+	if table != "" {
+		b = append(b, table...)
+		b = append(b, '.')
+	}
+	b = append(b, field.SQLName...)
+	b = append(b, " + 1"...)
+	return b
+}
@@ -0,0 +1,324 @@
+package bun
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/schema"
+)
+
+// Window is a window specification used in the OVER clause of a window function
+// and in the WINDOW clause of SelectQuery, for example:
+//
+//	bun.NewWindow().PartitionBy("author_id").OrderBy("created_at DESC")
+type Window struct {
+	base      string
+	partition []schema.QueryWithArgs
+	order     []schema.QueryWithArgs
+	frame     string
+}
+
+var _ schema.QueryAppender = (*Window)(nil)
+
+// NewWindow returns an empty window specification, which includes all rows in a single partition.
+func NewWindow() *Window {
+	return new(Window)
+}
+
+// Base makes the window extend the named window defined with SelectQuery.Window.
+func (w *Window) Base(name string) *Window {
+	w.base = name
+	return w
+}
+
+// PartitionBy adds the columns to the PARTITION BY clause.
+func (w *Window) PartitionBy(columns ...string) *Window {
+	for _, column := range columns {
+		w.partition = append(w.partition, schema.SafeQuery("?", []any{Ident(column)}))
+	}
+	return w
+}
+
+// PartitionByExpr adds the expression to the PARTITION BY clause.
+func (w *Window) PartitionByExpr(query string, args ...any) *Window {
+	w.partition = append(w.partition, schema.SafeQuery(query, args))
+	return w
+}
+
+// OrderBy adds the columns to the ORDER BY clause. Like in SelectQuery.Order,
+// a column can be followed by the sort direction, e.g. "created_at DESC".
+func (w *Window) OrderBy(orders ...string) *Window {
+	for _, order := range orders {
+		if order == "" {
+			continue
+		}
+
+		column, dir, ok := strings.Cut(order, " ")
+		if !ok {
+			w.order = append(w.order, schema.SafeQuery("?", []any{Ident(column)}))
+			continue
+		}
+		w.order = append(w.order, schema.SafeQuery("? ?", []any{Ident(column), Order(strings.ToUpper(dir))}))
+	}
+	return w
+}
+
+// OrderByExpr adds the expression to the ORDER BY clause.
+func (w *Window) OrderByExpr(query string, args ...any) *Window {
+	w.order = append(w.order, schema.SafeQuery(query, args))
+	return w
+}
+
+// FrameBound is the start or the end of a window frame.
+type FrameBound string
+
+const (
+	UnboundedPreceding FrameBound = "UNBOUNDED PRECEDING"
+	CurrentRow         FrameBound = "CURRENT ROW"
+	UnboundedFollowing FrameBound = "UNBOUNDED FOLLOWING"
+)
+
+// Preceding returns the frame bound n rows or values before the current row.
+func Preceding(n int) FrameBound {
+	return FrameBound(strconv.Itoa(n) + " PRECEDING")
+}
+
+// Following returns the frame bound n rows or values after the current row.
+func Following(n int) FrameBound {
+	return FrameBound(strconv.Itoa(n) + " FOLLOWING")
+}
+
+// Rows sets the frame to ROWS BETWEEN start AND end.
+func (w *Window) Rows(start, end FrameBound) *Window {
+	w.frame = "ROWS BETWEEN " + string(start) + " AND " + string(end)
+	return w
+}
+
+// Range sets the frame to RANGE BETWEEN start AND end.
+func (w *Window) Range(start, end FrameBound) *Window {
+	w.frame = "RANGE BETWEEN " + string(start) + " AND " + string(end)
+	return w
+}
+
+// AppendQuery appends the parenthesized window specification.
+func (w *Window) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte, err error) {
+	b = append(b, '(')
+	start := len(b)
+
+	if w.base != "" {
+		b = gen.AppendIdent(b, w.base)
+	}
+
+	if len(w.partition) > 0 {
+		if len(b) > start {
+			b = append(b, ' ')
+		}
+		b = append(b, "PARTITION BY "...)
+		for i, f := range w.partition {
+			if i > 0 {
+				b = append(b, ", "...)
+			}
+			b, err = f.AppendQuery(gen, b)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(w.order) > 0 {
+		if len(b) > start {
+			b = append(b, ' ')
+		}
+		b = append(b, "ORDER BY "...)
+		for i, f := range w.order {
+			if i > 0 {
+				b = append(b, ", "...)
+			}
+			b, err = f.AppendQuery(gen, b)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if w.frame != "" {
+		if len(b) > start {
+			b = append(b, ' ')
+		}
+		b = append(b, w.frame...)
+	}
+
+	b = append(b, ')')
+	return b, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Func is a call of an aggregate or window function with optional FILTER and OVER clauses.
+// It is used as an argument of SelectQuery.ColumnExpr, for example:
+//
+//	q.ColumnExpr("? AS paid", bun.NewFunc("count", "*").Filter("status = ?", "paid"))
+//	q.ColumnExpr("? AS rank", bun.NewFunc("rank", "").Over(bun.NewWindow().OrderBy("score DESC")))
+//
+// Dialects without feature.AggregateFilter render the filter as a CASE WHEN expression
+// around the function arguments, e.g. count(CASE WHEN status = 'paid' THEN 1 END),
+// which only works for aggregate functions with a single argument.
+type Func struct {
+	name   string
+	args   schema.QueryWithArgs
+	filter []schema.QueryWithArgs
+
+	window     *Window
+	windowName string
+}
+
+var _ schema.QueryAppender = (*Func)(nil)
+
+// NewFunc returns a call of the named function with the arguments formatted from query and args.
+func NewFunc(name string, query string, args ...any) *Func {
+	return &Func{
+		name: name,
+		args: schema.SafeQuery(query, args),
+	}
+}
+
+// Filter adds the condition to the FILTER (WHERE ...) clause. Conditions are combined with AND.
+func (f *Func) Filter(query string, args ...any) *Func {
+	f.filter = append(f.filter, schema.SafeQuery(query, args))
+	return f
+}
+
+// Over makes the function a window function over the window.
+func (f *Func) Over(window *Window) *Func {
+	f.window = window
+	f.windowName = ""
+	return f
+}
+
+// OverWindow makes the function a window function over the named window
+// defined with SelectQuery.Window.
+func (f *Func) OverWindow(name string) *Func {
+	f.window = nil
+	f.windowName = name
+	return f
+}
+
+// AppendQuery appends the function call.
+func (f *Func) AppendQuery(gen schema.QueryGen, b []byte) (_ []byte, err error) {
+	b = append(b, f.name...)
+	b = append(b, '(')
+
+	if len(f.filter) > 0 && !gen.HasFeature(feature.AggregateFilter) {
+		b, err = f.appendCaseWhen(gen, b)
+	} else {
+		b, err = f.args.AppendQuery(gen, b)
+	}
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, ')')
+
+	if len(f.filter) > 0 && gen.HasFeature(feature.AggregateFilter) {
+		b = append(b, " FILTER (WHERE "...)
+		b, err = f.appendFilter(gen, b)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, ')')
+	}
+
+	switch {
+	case f.window != nil:
+		b = append(b, " OVER "...)
+		b, err = f.window.AppendQuery(gen, b)
+		if err != nil {
+			return nil, err
+		}
+	case f.windowName != "":
+		b = append(b, " OVER "...)
+		b = gen.AppendIdent(b, f.windowName)
+	}
+
+	return b, nil
+}
+
+// appendCaseWhen appends the arguments as CASE WHEN <filter> THEN <args> END,
+// keeping the DISTINCT keyword outside of the expression.
+func (f *Func) appendCaseWhen(gen schema.QueryGen, b []byte) (_ []byte, err error) {
+	args := f.args
+	if len(args.Query) > len("DISTINCT ") && strings.EqualFold(args.Query[:len("DISTINCT ")], "DISTINCT ") {
+		b = append(b, "DISTINCT "...)
+		args.Query = args.Query[len("DISTINCT "):]
+	}
+
+	b = append(b, "CASE WHEN "...)
+	b, err = f.appendFilter(gen, b)
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, " THEN "...)
+	if query := strings.TrimSpace(args.Query); query == "" || query == "*" {
+		b = append(b, '1')
+	} else {
+		b, err = args.AppendQuery(gen, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, " END"...)
+	return b, nil
+}
+
+func (f *Func) appendFilter(gen schema.QueryGen, b []byte) (_ []byte, err error) {
+	for i, cond := range f.filter {
+		if i > 0 {
+			b = append(b, " AND "...)
+		}
+		if len(f.filter) > 1 {
+			b = append(b, '(')
+		}
+		b, err = cond.AppendQuery(gen, b)
+		if err != nil {
+			return nil, err
+		}
+		if len(f.filter) > 1 {
+			b = append(b, ')')
+		}
+	}
+	return b, nil
+}
+
+//------------------------------------------------------------------------------
+
+type namedWindow struct {
+	name   string
+	window *Window
+}
+
+// Window adds the named window to the WINDOW clause. Window functions refer to it
+// with Func.OverWindow and other windows can extend it with Window.Base.
+func (q *SelectQuery) Window(name string, window *Window) *SelectQuery {
+	q.windows = append(q.windows, namedWindow{name: name, window: window})
+	return q
+}
+
+func (q *SelectQuery) appendWindows(gen schema.QueryGen, b []byte) (_ []byte, err error) {
+	if len(q.windows) == 0 {
+		return b, nil
+	}
+
+	b = append(b, " WINDOW "...)
+	for i, w := range q.windows {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = gen.AppendIdent(b, w.name)
+		b = append(b, " AS "...)
+		b, err = w.window.AppendQuery(gen, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}